@@ -0,0 +1,155 @@
+package ldbserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// ACLRule grants a token the listed commands on keys with one of the listed
+// prefixes in the listed databases. Empty Commands allows every command, empty
+// Prefixes every key and empty Databases every database. The default database
+// is listed as "".
+type ACLRule struct {
+	Token     string
+	Commands  []string
+	Prefixes  []string
+	Databases []string
+}
+
+// ACL maps tokens to the commands and keys they are allowed to use. Requests
+// with an unknown token are denied.
+type ACL struct {
+	rules map[string]*aclRule
+}
+
+type aclRule struct {
+	commands  map[TransportRequest_Command]bool
+	prefixes  [][]byte
+	databases map[string]bool
+}
+
+func NewACL(rules []ACLRule) (*ACL, error) {
+	acl := &ACL{rules: make(map[string]*aclRule)}
+	for _, r := range rules {
+		if len(r.Token) == 0 {
+			return nil, errors.New("acl: empty token")
+		}
+		if _, ok := acl.rules[r.Token]; ok {
+			return nil, errors.New("acl: duplicate token")
+		}
+		rule := &aclRule{}
+		if len(r.Commands) != 0 {
+			rule.commands = make(map[TransportRequest_Command]bool)
+			for _, name := range r.Commands {
+				cmd, ok := TransportRequest_Command_value[name]
+				if !ok {
+					return nil, fmt.Errorf("acl: unknown command %q", name)
+				}
+				rule.commands[TransportRequest_Command(cmd)] = true
+			}
+		}
+		for _, p := range r.Prefixes {
+			rule.prefixes = append(rule.prefixes, []byte(p))
+		}
+		if len(r.Databases) != 0 {
+			rule.databases = make(map[string]bool)
+			for _, name := range r.Databases {
+				rule.databases[name] = true
+			}
+		}
+		acl.rules[r.Token] = rule
+	}
+	return acl, nil
+}
+
+// LoadACL reads a json array of ACLRule from fname.
+func LoadACL(fname string) (*ACL, error) {
+	file, err := os.Open(fname)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var rules []ACLRule
+	if err := json.NewDecoder(file).Decode(&rules); err != nil {
+		return nil, err
+	}
+	return NewACL(rules)
+}
+
+var errDenied = errors.New("access denied")
+
+// allow checks the token of req against the command and the keys it touches.
+func (acl *ACL) allow(req *TransportRequest) error {
+	rule, ok := acl.rules[req.GetToken()]
+	if !ok {
+		return errors.New("unknown token")
+	}
+	if !rule.allowCommand(req.GetCommand()) {
+		return errDenied
+	}
+	if rule.databases != nil && !rule.databases[req.GetDatabase()] {
+		return errDenied
+	}
+
+	switch req.GetCommand() {
+	case TransportRequest_SNAPSHOT_OPEN, TransportRequest_SNAPSHOT_RELEASE, TransportRequest_REPL_STATUS, TransportRequest_CLUSTER_STATUS:
+		return nil
+	case TransportRequest_SCAN:
+		if !rule.allowRange(scanRange(req.GetRange())) {
+			return errDenied
+		}
+	case TransportRequest_BACKUP, TransportRequest_RESTORE, TransportRequest_REPL_SNAPSHOT, TransportRequest_REPL_TAIL,
+		TransportRequest_DB_CREATE, TransportRequest_DB_DROP, TransportRequest_CLUSTER_JOIN, TransportRequest_CLUSTER_LEAVE:
+		// backups, replication, whole databases and cluster members cover
+		// all keys
+		if len(rule.prefixes) != 0 {
+			return errDenied
+		}
+	case TransportRequest_BATCH:
+		for _, op := range req.Batch {
+			if !rule.allowCommand(op.GetCommand()) || !rule.allowKey(op.GetKey()) {
+				return errDenied
+			}
+		}
+	default:
+		if !rule.allowKey(requestKey(req)) {
+			return errDenied
+		}
+	}
+	return nil
+}
+
+func (rule *aclRule) allowCommand(cmd TransportRequest_Command) bool {
+	return rule.commands == nil || rule.commands[cmd]
+}
+
+func (rule *aclRule) allowKey(key []byte) bool {
+	if len(rule.prefixes) == 0 {
+		return true
+	}
+	for _, p := range rule.prefixes {
+		if bytes.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowRange reports whether the whole range lies within one prefix.
+func (rule *aclRule) allowRange(rng *util.Range) bool {
+	if len(rule.prefixes) == 0 {
+		return true
+	}
+	for _, p := range rule.prefixes {
+		limit := util.BytesPrefix(p).Limit
+		if bytes.HasPrefix(rng.Start, p) && (limit == nil || rng.Limit != nil && bytes.Compare(rng.Limit, limit) <= 0) {
+			return true
+		}
+	}
+	return false
+}
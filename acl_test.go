@@ -0,0 +1,111 @@
+package ldbserver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACL(t *testing.T) {
+
+	tempdir := os.TempDir()
+	path := filepath.Join(tempdir, fmt.Sprintf("goleveldb-test-acl%d0%d", os.Getuid(), os.Getpid()))
+	db, err := NewLevelDbServer(path)
+	if assert.NoError(t, err, "NewLevelDbServer") {
+		defer func() {
+			db.Close()
+			os.RemoveAll(path)
+		}()
+
+		_, err := NewACL([]ACLRule{{Token: "t", Commands: []string{"FETCH"}}})
+		assert.Error(t, err, "Unknown command")
+
+		acl, err := NewACL([]ACLRule{
+			{Token: "admin"},
+			{Token: "reader", Commands: []string{"GET", "SCAN"}, Prefixes: []string{"users/"}},
+			{Token: "writer", Commands: []string{"PUT", "BATCH"}, Prefixes: []string{"users/", "groups/"}},
+			{Token: "tenant", Databases: []string{"tenant"}},
+			{Token: "scoped", Prefixes: []string{"users/"}},
+		})
+		if !assert.NoError(t, err, "NewACL") {
+			return
+		}
+		db.SetACL(acl)
+
+		status := func(token string, req *TransportRequest) TransportResponse_Status {
+			if len(token) != 0 {
+				req.Token = proto.String(token)
+			}
+			return handleRequest(t, db, nil, req).GetStatus()
+		}
+		put := func(key string) *TransportRequest {
+			req := &TransportRequest{Key: []byte(key), Command: TransportRequest_PUT.Enum(), Body: &TransportBody{Data: []byte("v")}}
+			SetBodyChecksum(req.Body)
+			return req
+		}
+		get := func(key string) *TransportRequest {
+			return &TransportRequest{Key: []byte(key), Command: TransportRequest_GET.Enum()}
+		}
+		scan := func(rng *TransportRange) *TransportRequest {
+			return &TransportRequest{Command: TransportRequest_SCAN.Enum(), Range: rng}
+		}
+		batch := func(keys ...string) *TransportRequest {
+			req := &TransportRequest{Command: TransportRequest_BATCH.Enum()}
+			for _, key := range keys {
+				req.Batch = append(req.Batch, &TransportOperation{Command: TransportRequest_DELETE.Enum(), Key: []byte(key)})
+			}
+			return req
+		}
+
+		assert.Equal(t, status("", get("users/1")), TransportResponse_DENIED, "No token")
+		assert.Equal(t, status("guest", get("users/1")), TransportResponse_DENIED, "Unknown token")
+		assert.Equal(t, status("admin", put("other/1")), TransportResponse_OK, "Admin")
+
+		assert.Equal(t, status("writer", put("users/1")), TransportResponse_OK, "Writer put")
+		assert.Equal(t, status("writer", put("other/1")), TransportResponse_DENIED, "Writer put outside prefixes")
+		assert.Equal(t, status("writer", get("users/1")), TransportResponse_DENIED, "Writer get")
+		assert.Equal(t, status("writer", batch("groups/1")), TransportResponse_DENIED, "Writer batch delete")
+
+		assert.Equal(t, status("reader", get("users/1")), TransportResponse_OK, "Reader get")
+		assert.Equal(t, status("reader", get("other/1")), TransportResponse_DENIED, "Reader get outside prefixes")
+		assert.Equal(t, status("reader", put("users/1")), TransportResponse_DENIED, "Reader put")
+		assert.Equal(t, status("reader", scan(&TransportRange{Prefix: []byte("users/")})), TransportResponse_OK, "Reader scan prefix")
+		assert.Equal(t, status("reader", scan(&TransportRange{Prefix: []byte("users/1")})), TransportResponse_OK, "Reader scan narrow prefix")
+		assert.Equal(t, status("reader", scan(&TransportRange{Start: []byte("users/"), End: []byte("users/5")})), TransportResponse_OK, "Reader scan range")
+		assert.Equal(t, status("reader", scan(&TransportRange{Start: []byte("users/")})), TransportResponse_DENIED, "Reader scan open range")
+		assert.Equal(t, status("reader", scan(nil)), TransportResponse_DENIED, "Reader scan all")
+
+		inTenant := func(req *TransportRequest) *TransportRequest {
+			req.Database = proto.String("tenant")
+			return req
+		}
+		assert.Equal(t, status("tenant", get("users/1")), TransportResponse_DENIED, "Tenant default database")
+		assert.Equal(t, status("tenant", inTenant(get("users/1"))), TransportResponse_NOT_FOUND, "Tenant database")
+		assert.Equal(t, status("tenant", &TransportRequest{Command: TransportRequest_DB_DROP.Enum(), Database: proto.String("other")}), TransportResponse_DENIED, "Tenant drop other")
+		dbRequest := func(cmd TransportRequest_Command) *TransportRequest {
+			return &TransportRequest{Command: cmd.Enum(), Database: proto.String("other")}
+		}
+		assert.Equal(t, status("scoped", dbRequest(TransportRequest_DB_CREATE)), TransportResponse_DENIED, "Prefix-scoped create")
+		assert.Equal(t, status("scoped", dbRequest(TransportRequest_DB_DROP)), TransportResponse_DENIED, "Prefix-scoped drop")
+		join := &TransportRequest{Command: TransportRequest_CLUSTER_JOIN.Enum(), NodeId: proto.String("n"), NodeAddress: proto.String("127.0.0.1:1")}
+		assert.Equal(t, status("scoped", join), TransportResponse_DENIED, "Prefix-scoped cluster join")
+		assert.Equal(t, status("scoped", &TransportRequest{Command: TransportRequest_CLUSTER_LEAVE.Enum(), NodeId: proto.String("n")}), TransportResponse_DENIED, "Prefix-scoped cluster leave")
+
+		h := NewRestHandler(db)
+		for token, code := range map[string]int{"": http.StatusForbidden, "writer": http.StatusForbidden, "reader": http.StatusOK} {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/keys/users%2F1", nil)
+			if len(token) != 0 {
+				r.Header.Set("Authorization", "Bearer "+token)
+			}
+			h.ServeHTTP(w, r)
+			assert.Equal(t, w.Code, code, "REST token "+token)
+		}
+	}
+}
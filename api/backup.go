@@ -0,0 +1,136 @@
+package api
+
+import (
+	"io"
+	"time"
+
+	"github.com/govlas/ldbserver"
+)
+
+// restoreBatchSize is the number of pairs written in one batch by Restore.
+const restoreBatchSize = 1000
+
+// Backup writes a consistent copy of the database to w in the format read by
+// ldbserver.NewBackupReader. It returns the number of pairs written.
+func (cl *Client) Backup(w io.Writer) (n int, err error) {
+	req := ldbserver.TransportRequest{
+		Command: ldbserver.TransportRequest_BACKUP.Enum(),
+	}
+
+	it := &Iterator{more: true}
+	it.rs, it.err = cl.sendRequest(&req)
+	defer it.Release()
+
+	bw := ldbserver.NewBackupWriter(w)
+	for it.Next() {
+		if err = bw.Write(it.pair); err != nil {
+			return
+		}
+		n++
+	}
+	if err = it.Error(); err != nil {
+		return
+	}
+	return n, bw.Close()
+}
+
+// Restore loads the backup read from r into the database, which must be
+// empty. Keys with an expiry are written with the time they have left, and
+// left out once expired. Values keep their flags. It returns the number of
+// pairs written.
+func (cl *Client) Restore(r io.Reader) (n int, err error) {
+	it := cl.Scan(Range{Limit: 1})
+	empty := !it.Next()
+	it.Release()
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	if !empty {
+		return 0, &StatusError{Status: ldbserver.TransportResponse_CONDITION_FAILED, Message: "client.Restore: database not empty"}
+	}
+
+	br := ldbserver.NewBackupReader(r)
+	b := cl.Batch()
+	for {
+		pair, err := br.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return n, err
+		}
+		if pair.Expires != nil || pair.Flags != nil {
+			ok, err := cl.restorePair(pair)
+			if err != nil {
+				return n, err
+			}
+			if ok {
+				n++
+			}
+			continue
+		}
+		b.Put(pair.Key, pair.Value.GetData())
+		if b.Len() == restoreBatchSize {
+			if err := b.Write(); err != nil {
+				return n, err
+			}
+			n += b.Len()
+			b.Reset()
+		}
+	}
+	if err := b.SetSync(true).Write(); err != nil {
+		return n, err
+	}
+	return n + b.Len(), nil
+}
+
+// restorePair writes pair with its expiry and flags, which batches do not
+// carry. Pairs expired already are left out.
+func (cl *Client) restorePair(pair *ldbserver.TransportPair) (bool, error) {
+	req := ldbserver.TransportRequest{
+		Key:     pair.Key,
+		Command: ldbserver.TransportRequest_PUT.Enum(),
+		Body:    &ldbserver.TransportBody{Data: pair.Value.GetData()},
+		Flags:   pair.Flags,
+	}
+	if pair.Expires != nil {
+		ttl := time.Until(time.Unix(0, pair.GetExpires()))
+		if ttl <= 0 {
+			return false, nil
+		}
+		ms := uint64((ttl + time.Millisecond - 1) / time.Millisecond)
+		req.Ttl = &ms
+	}
+	ldbserver.SetBodyChecksum(req.Body)
+
+	resp, err := cl.doRequest(&req)
+	if err != nil {
+		return false, err
+	}
+	return true, responseError(resp)
+}
+
+// BackupToServer makes the server write a backup to path, relative to its
+// backup directory.
+func (cl *Client) BackupToServer(path string) error {
+	return cl.serverBackup(ldbserver.TransportRequest_BACKUP, path)
+}
+
+// RestoreFromServer makes the server load the backup at path, relative to its
+// backup directory, into the database, which must be empty.
+func (cl *Client) RestoreFromServer(path string) error {
+	return cl.serverBackup(ldbserver.TransportRequest_RESTORE, path)
+}
+
+func (cl *Client) serverBackup(cmd ldbserver.TransportRequest_Command, path string) error {
+	req := ldbserver.TransportRequest{
+		Command: cmd.Enum(),
+		Path:    &path,
+	}
+
+	if resp, err := cl.doRequest(&req); err == nil {
+		return responseError(resp)
+	} else {
+		return err
+	}
+}
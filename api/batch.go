@@ -0,0 +1,68 @@
+package api
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/govlas/ldbserver"
+)
+
+// Batch collects puts and deletes that are applied atomically by Write.
+type Batch struct {
+	cl   *Client
+	ops  []*ldbserver.TransportOperation
+	sync bool
+}
+
+func (cl *Client) Batch() *Batch {
+	return &Batch{cl: cl}
+}
+
+func (b *Batch) Put(key, value []byte) *Batch {
+	op := &ldbserver.TransportOperation{
+		Command: ldbserver.TransportRequest_PUT.Enum(),
+		Key:     key,
+		Body:    &ldbserver.TransportBody{Data: value},
+	}
+	ldbserver.SetBodyChecksum(op.Body)
+	b.ops = append(b.ops, op)
+	return b
+}
+
+func (b *Batch) Delete(key []byte) *Batch {
+	b.ops = append(b.ops, &ldbserver.TransportOperation{
+		Command: ldbserver.TransportRequest_DELETE.Enum(),
+		Key:     key,
+	})
+	return b
+}
+
+// SetSync makes Write wait until the batch is flushed to disk.
+func (b *Batch) SetSync(sync bool) *Batch {
+	b.sync = sync
+	return b
+}
+
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+func (b *Batch) Reset() {
+	b.ops = nil
+}
+
+func (b *Batch) Write() error {
+	req := ldbserver.TransportRequest{
+		Command: ldbserver.TransportRequest_BATCH.Enum(),
+		Batch:   b.ops,
+		Sync:    proto.Bool(b.sync),
+	}
+
+	if resp, err := b.cl.doRequest(&req); err == nil {
+		if err := responseError(resp); err != nil {
+			return err
+		}
+
+	} else {
+		return err
+	}
+	return nil
+}
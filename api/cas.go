@@ -0,0 +1,61 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/govlas/ldbserver"
+)
+
+// CompareAndSwap sets key to value if its current value equals old. A nil old
+// requires the key to be absent. It reports false if the condition failed.
+func (cl *Client) CompareAndSwap(key, old, value []byte) (bool, error) {
+	req := ldbserver.TransportRequest{
+		Key:     key,
+		Command: ldbserver.TransportRequest_CAS.Enum(),
+		Body:    &ldbserver.TransportBody{Data: value},
+	}
+	if old != nil {
+		req.Expected = &ldbserver.TransportBody{Data: old}
+	}
+	return cl.conditional(&req)
+}
+
+// PutIfAbsent sets key to value if the key is absent. It reports false if the
+// key exists.
+func (cl *Client) PutIfAbsent(key, value []byte) (bool, error) {
+	req := ldbserver.TransportRequest{
+		Key:     key,
+		Command: ldbserver.TransportRequest_PUT_IF_ABSENT.Enum(),
+		Body:    &ldbserver.TransportBody{Data: value},
+	}
+	return cl.conditional(&req)
+}
+
+// DeleteIfEqual deletes key if its current value equals value. It reports false
+// if the condition failed.
+func (cl *Client) DeleteIfEqual(key, value []byte) (bool, error) {
+	req := ldbserver.TransportRequest{
+		Key:      key,
+		Command:  ldbserver.TransportRequest_DELETE_IF_EQUAL.Enum(),
+		Expected: &ldbserver.TransportBody{Data: value},
+	}
+	return cl.conditional(&req)
+}
+
+func (cl *Client) conditional(req *ldbserver.TransportRequest) (bool, error) {
+	ldbserver.SetBodyChecksum(req.Body)
+	ldbserver.SetBodyChecksum(req.Expected)
+
+	if resp, err := cl.doRequest(req); err == nil {
+		if err := responseError(resp); err != nil {
+			if errors.Is(err, ErrConditionFailed) {
+				return false, nil
+			}
+			return false, err
+		}
+
+	} else {
+		return false, err
+	}
+	return true, nil
+}
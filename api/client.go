@@ -2,125 +2,242 @@ package api
 
 import (
 	"bytes"
-	"encoding/binary"
-	"encoding/json"
+	"crypto/tls"
 	"errors"
-	"io"
 	"net"
 	"net/http"
 	"runtime"
+	"sync"
+	"sync/atomic"
 
-	pio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
 	"github.com/govlas/ldbserver"
 )
 
+// maxRedirects bounds the redirects to a new cluster leader followed by one
+// request.
+const maxRedirects = 3
+
+// Client is safe for concurrent use. On stream networks requests issued by
+// several goroutines are pipelined on one connection. Writes redirected by a
+// cluster follower are sent to the leader from then on; reads stay on the
+// server the client was created for.
 type Client struct {
 	network    string
 	host       string
-	marshaling ldbserver.MarshalingType
-	conn       io.ReadWriteCloser
+	codec      ldbserver.Codec
+	st         *stream
+	seq        uint64
+	tlsConfig  *tls.Config
+	httpClient *http.Client
+	token      *string
+	database   *string
+
+	// leaderHost is the cluster leader; leaderSt is the connection to it
+	// unless it is host
+	mu         sync.Mutex
+	leaderHost string
+	leaderSt   *stream
 }
 
-func NewClient(network string, host string, mt ldbserver.MarshalingType) (cl *Client, err error) {
+func NewClient(network string, host string, mt ldbserver.MarshalingType, opts ...Option) (cl *Client, err error) {
 	cl = new(Client)
 	cl.network = network
 	cl.host = host
-	cl.marshaling = mt
+	cl.codec = mt.Codec()
+	for _, opt := range opts {
+		opt(cl)
+	}
+	if cl.codec == nil {
+		return nil, errors.New("client: unsupported marshaling type")
+	}
 
 	switch network {
 	case "unix", "tcp":
-		cl.conn, err = net.Dial(network, host)
+		conn, err := cl.dial(host)
 		if err != nil {
 			return nil, err
 		}
+		cl.st = newStream(conn, cl.codec)
 		runtime.SetFinalizer(cl, func(c *Client) {
 			c.Close()
 		})
+	case "http":
+		cl.httpClient = http.DefaultClient
+		if cl.tlsConfig != nil {
+			cl.httpClient = &http.Client{Transport: &http.Transport{TLSClientConfig: cl.tlsConfig}}
+		}
 	}
 
 	return
 }
 
-func (cl *Client) doRequest(req *ldbserver.TransportRequest) (resp *ldbserver.TransportResponse, err error) {
-
-	if cl == nil {
-		return nil, errors.New("client.DoRequest: call of nil reference")
+func (cl *Client) dial(host string) (net.Conn, error) {
+	if cl.tlsConfig != nil {
+		return tls.Dial(cl.network, host, cl.tlsConfig)
 	}
+	return net.Dial(cl.network, host)
+}
 
-	var (
-		w io.Writer
-		r io.Reader
-	)
-
-	if cl.network == "http" {
-		w = bytes.NewBuffer(nil)
-	} else {
-		r = cl.conn
-		w = cl.conn
-	}
-
-	if w != nil {
-		var content_type string
-		switch cl.marshaling {
-		case ldbserver.MarshalingTypeJson:
-			enc := json.NewEncoder(w)
-			err = enc.Encode(req)
-			content_type = "application/json"
-		case ldbserver.MarshalingTypeProtobuf:
-			enc := pio.NewUint32DelimitedWriter(w, binary.LittleEndian)
-			err = enc.WriteMsg(req)
-			content_type = "application/octet-stream"
-		}
+// doRequest sends req and returns its first response. Requests answered with
+// NOT_LEADER are sent again to the leader named in the response.
+func (cl *Client) doRequest(req *ldbserver.TransportRequest) (resp *ldbserver.TransportResponse, err error) {
+	for redirects := 0; ; redirects++ {
+		rs, err := cl.sendRequest(req)
 		if err != nil {
-			return
+			return nil, err
 		}
-		if cl.network == "http" {
-
-			hresp, err := http.Post("http://"+cl.host, content_type, w.(io.Reader))
-			if err != nil {
-				return nil, err
-			}
-			defer hresp.Body.Close()
-			r = hresp.Body
+		resp, err = rs.Next()
+		rs.Close()
+		if err != nil || resp.GetStatus() != ldbserver.TransportResponse_NOT_LEADER || len(resp.GetLeader()) == 0 || redirects == maxRedirects {
+			return resp, err
 		}
-		resp = &ldbserver.TransportResponse{}
-		switch cl.marshaling {
-		case ldbserver.MarshalingTypeJson:
-			dec := json.NewDecoder(r)
-			err = dec.Decode(resp)
-		case ldbserver.MarshalingTypeProtobuf:
-			dec := pio.NewUint32DelimitedReader(r, binary.LittleEndian, 1024*1024)
-			err = dec.ReadMsg(resp)
+		if err := cl.followLeader(resp.GetLeader()); err != nil {
+			return nil, err
 		}
+	}
+}
 
-	} else {
+// leaderCommand reports whether req is answered by the cluster leader only.
+func leaderCommand(req *ldbserver.TransportRequest) bool {
+	switch req.GetCommand() {
+	case ldbserver.TransportRequest_CLUSTER_JOIN, ldbserver.TransportRequest_CLUSTER_LEAVE:
+		return true
+	case ldbserver.TransportRequest_PUT, ldbserver.TransportRequest_DELETE, ldbserver.TransportRequest_BATCH,
+		ldbserver.TransportRequest_CAS, ldbserver.TransportRequest_PUT_IF_ABSENT, ldbserver.TransportRequest_DELETE_IF_EQUAL,
+		ldbserver.TransportRequest_PERSIST, ldbserver.TransportRequest_REPLACE:
+		// named databases are not replicated by the cluster
+		return len(req.GetDatabase()) == 0
+	}
+	return false
+}
+
+// followLeader sends the leader commands to leader from now on.
+func (cl *Client) followLeader(leader string) error {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	if leader == cl.leaderHost && (cl.leaderSt == nil || !cl.leaderSt.broken()) {
+		// another request followed the redirect first
+		return nil
+	}
+	if cl.leaderSt != nil {
+		cl.leaderSt.close()
+		cl.leaderSt = nil
+	}
+	cl.leaderHost = leader
+	if cl.st == nil || leader == cl.host {
+		return nil
+	}
+	conn, err := cl.dial(leader)
+	if err != nil {
+		cl.leaderHost = ""
+		return err
+	}
+	cl.leaderSt = newStream(conn, cl.codec)
+	return nil
+}
+
+// route returns the connection or, on http, the host req is sent to.
+func (cl *Client) route(req *ldbserver.TransportRequest) (*stream, string) {
+	if !leaderCommand(req) {
+		return cl.st, cl.host
+	}
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	if cl.leaderSt != nil && cl.leaderSt.broken() {
+		// the leader is asked for again if it has gone
+		cl.leaderSt.close()
+		cl.leaderSt, cl.leaderHost = nil, ""
+	}
+	if cl.leaderSt != nil {
+		return cl.leaderSt, cl.leaderHost
+	}
+	if cl.st == nil && len(cl.leaderHost) != 0 {
+		return nil, cl.leaderHost
+	}
+	return cl.st, cl.host
+}
+
+// sendRequest writes req and returns the stream of its responses.
+func (cl *Client) sendRequest(req *ldbserver.TransportRequest) (rs *responses, err error) {
+
+	if cl == nil {
+		return nil, errors.New("client.DoRequest: call of nil reference")
+	}
+
+	if req.Token == nil {
+		req.Token = cl.token
+	}
+	if req.Database == nil {
+		req.Database = cl.database
+	}
+	st, host := cl.route(req)
+	if st != nil {
+		return st.send(req)
+	}
+	if cl.network != "http" {
 		return nil, errors.New("client.DoRequest: no connection")
 	}
-	return
+
+	seq := atomic.AddUint64(&cl.seq, 1)
+	req.Seq = proto.Uint64(seq)
+
+	w := bytes.NewBuffer(nil)
+	if err = cl.codec.NewEncoder(w).Encode(req); err != nil {
+		return
+	}
+	scheme := "http://"
+	if cl.tlsConfig != nil {
+		scheme = "https://"
+	}
+	hresp, err := cl.httpClient.Post(scheme+host+ldbserver.EnvelopePath, cl.codec.ContentType(), w)
+	if err != nil {
+		return nil, err
+	}
+	return newBodyResponses(hresp.Body, cl.codec, seq), nil
+}
+
+// decodeResponse reads the next response from dec.
+func decodeResponse(dec ldbserver.Decoder) (*ldbserver.TransportResponse, error) {
+	resp := &ldbserver.TransportResponse{}
+	if err := dec.Decode(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 func (cl *Client) Close() {
-	if cl != nil && cl.conn != nil {
-		cl.conn.Close()
+	if cl != nil && cl.st != nil {
+		cl.st.close()
+		cl.mu.Lock()
+		if cl.leaderSt != nil {
+			cl.leaderSt.close()
+		}
+		cl.mu.Unlock()
 	}
 }
 
 func (cl *Client) Get(key []byte) (value []byte, err error) {
+	return cl.get(key, nil)
+}
+
+func (cl *Client) get(key []byte, snapshot *uint64) (value []byte, err error) {
 	req := ldbserver.TransportRequest{
-		Id:      key,
-		Command: ldbserver.TransportRequest_GET.Enum(),
+		Key:      key,
+		Command:  ldbserver.TransportRequest_GET.Enum(),
+		Snapshot: snapshot,
 	}
 
 	if resp, err := cl.doRequest(&req); err == nil {
 
-		if *resp.Status != ldbserver.TransportResponse_OK {
-			return nil, errors.New(string(resp.Body.Data))
+		if err := responseError(resp); err != nil {
+			return nil, err
 		}
 
 		if ldbserver.CheckBody(resp.Body) {
 			value = resp.Body.Data
 		} else {
-			return nil, errors.New("client.Get: bad checksum for returning data")
+			return nil, &StatusError{Status: ldbserver.TransportResponse_CHECKSUM_MISMATCH, Message: "client.Get: bad checksum for returning data"}
 		}
 	} else {
 		return nil, err
@@ -130,15 +247,15 @@ func (cl *Client) Get(key []byte) (value []byte, err error) {
 
 func (cl *Client) Put(key, value []byte) error {
 	req := ldbserver.TransportRequest{
-		Id:      key,
+		Key:     key,
 		Command: ldbserver.TransportRequest_PUT.Enum(),
 		Body:    &ldbserver.TransportBody{Data: value},
 	}
 	ldbserver.SetBodyChecksum(req.Body)
 
 	if resp, err := cl.doRequest(&req); err == nil {
-		if *resp.Status != ldbserver.TransportResponse_OK {
-			return errors.New(string(resp.Body.Data))
+		if err := responseError(resp); err != nil {
+			return err
 		}
 
 	} else {
@@ -149,13 +266,13 @@ func (cl *Client) Put(key, value []byte) error {
 
 func (cl *Client) Delete(key []byte) error {
 	req := ldbserver.TransportRequest{
-		Id:      key,
+		Key:     key,
 		Command: ldbserver.TransportRequest_DELETE.Enum(),
 	}
 
 	if resp, err := cl.doRequest(&req); err == nil {
-		if *resp.Status != ldbserver.TransportResponse_OK {
-			return errors.New(string(resp.Body.Data))
+		if err := responseError(resp); err != nil {
+			return err
 		}
 
 	} else {
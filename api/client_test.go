@@ -1,7 +1,12 @@
 package api_test
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/govlas/ldbserver"
 	"github.com/govlas/ldbserver/api"
@@ -18,14 +23,226 @@ func testClient(t *testing.T, nt string, host string, mt ldbserver.MarshalingTyp
 
 	assert.Equal(t, res, []byte("world"), "api.client.Get")
 
+	it := cli.Scan(api.Range{Prefix: []byte("hel")})
+	var keys []string
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+		assert.Equal(t, it.Value(), []byte("world"), "api.client.Scan")
+	}
+	assert.NoError(t, it.Error(), "api.client.Scan")
+	assert.Equal(t, keys, []string{"hello"}, "api.client.Scan")
+
+	err = cli.Batch().Put([]byte("hello1"), []byte("world")).Put([]byte("hello2"), []byte("world")).Delete([]byte("hello1")).Write()
+	assert.NoError(t, err, "api.client.Batch")
+
+	res, err = cli.Get([]byte("hello2"))
+	assert.NoError(t, err, "api.client.Batch")
+	assert.Equal(t, res, []byte("world"), "api.client.Batch")
+	_, err = cli.Get([]byte("hello1"))
+	assert.Error(t, err, "api.client.Batch")
+	assert.NoError(t, cli.Delete([]byte("hello2")), "api.client.Delete")
+
 	err = cli.Delete([]byte("hello"))
 	assert.NoError(t, err, "api.client.Delete")
 
 	_, err = cli.Get([]byte("hello"))
 	assert.Error(t, err, "api.client.Get empty")
+	assert.True(t, errors.Is(err, api.ErrNotFound), "api.client.Get empty")
 }
 
 func TestClient(t *testing.T) {
 	//testClient(t, "http", "localhost:8080", ldbserver.MarshalingTypeJson)
 	testClient(t, "unix", "/tmp/ldbserver.sock", ldbserver.MarshalingTypeJson)
 }
+
+func TestClientPipelining(t *testing.T) {
+	cli, err := api.NewClient("unix", "/tmp/ldbserver.sock", ldbserver.MarshalingTypeJson)
+	if !assert.NoError(t, err, "api.NewClient") {
+		return
+	}
+	defer cli.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := []byte(fmt.Sprintf("pipelined%02d", i))
+			value := []byte(fmt.Sprintf("value%02d", i))
+			assert.NoError(t, cli.Put(key, value), "api.client.Put")
+			res, err := cli.Get(key)
+			assert.NoError(t, err, "api.client.Get")
+			assert.Equal(t, res, value, "api.client.Get")
+			assert.NoError(t, cli.Delete(key), "api.client.Delete")
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestClientSnapshot(t *testing.T) {
+	cli, err := api.NewClient("unix", "/tmp/ldbserver.sock", ldbserver.MarshalingTypeJson)
+	if !assert.NoError(t, err, "api.NewClient") {
+		return
+	}
+	defer cli.Close()
+
+	key := []byte("snapshot")
+	assert.NoError(t, cli.Put(key, []byte("old")), "api.client.Put")
+	defer cli.Delete(key)
+
+	snap, err := cli.Snapshot()
+	if !assert.NoError(t, err, "api.client.Snapshot") {
+		return
+	}
+	assert.NoError(t, cli.Put(key, []byte("new")), "api.client.Put")
+
+	res, err := snap.Get(key)
+	assert.NoError(t, err, "api.Snapshot.Get")
+	assert.Equal(t, res, []byte("old"), "api.Snapshot.Get")
+
+	it := snap.Scan(api.Range{Prefix: key})
+	for it.Next() {
+		assert.Equal(t, it.Value(), []byte("old"), "api.Snapshot.Scan")
+	}
+	assert.NoError(t, it.Error(), "api.Snapshot.Scan")
+
+	res, err = cli.Get(key)
+	assert.NoError(t, err, "api.client.Get")
+	assert.Equal(t, res, []byte("new"), "api.client.Get")
+
+	assert.NoError(t, snap.Release(), "api.Snapshot.Release")
+	_, err = snap.Get(key)
+	assert.True(t, errors.Is(err, api.ErrBadRequest), "api.Snapshot.Get released")
+}
+
+func TestClientConditional(t *testing.T) {
+	cli, err := api.NewClient("unix", "/tmp/ldbserver.sock", ldbserver.MarshalingTypeJson)
+	if !assert.NoError(t, err, "api.NewClient") {
+		return
+	}
+	defer cli.Close()
+
+	key := []byte("conditional")
+	ok, err := cli.PutIfAbsent(key, []byte("one"))
+	assert.NoError(t, err, "api.client.PutIfAbsent")
+	assert.True(t, ok, "api.client.PutIfAbsent")
+	ok, err = cli.PutIfAbsent(key, []byte("two"))
+	assert.NoError(t, err, "api.client.PutIfAbsent")
+	assert.False(t, ok, "api.client.PutIfAbsent")
+
+	ok, err = cli.CompareAndSwap(key, []byte("two"), []byte("three"))
+	assert.NoError(t, err, "api.client.CompareAndSwap")
+	assert.False(t, ok, "api.client.CompareAndSwap")
+	ok, err = cli.CompareAndSwap(key, []byte("one"), []byte("two"))
+	assert.NoError(t, err, "api.client.CompareAndSwap")
+	assert.True(t, ok, "api.client.CompareAndSwap")
+
+	ok, err = cli.DeleteIfEqual(key, []byte("one"))
+	assert.NoError(t, err, "api.client.DeleteIfEqual")
+	assert.False(t, ok, "api.client.DeleteIfEqual")
+	ok, err = cli.DeleteIfEqual(key, []byte("two"))
+	assert.NoError(t, err, "api.client.DeleteIfEqual")
+	assert.True(t, ok, "api.client.DeleteIfEqual")
+
+	ok, err = cli.CompareAndSwap(key, nil, []byte("one"))
+	assert.NoError(t, err, "api.client.CompareAndSwap")
+	assert.True(t, ok, "api.client.CompareAndSwap absent")
+	assert.NoError(t, cli.Delete(key), "api.client.Delete")
+}
+
+func TestClientTTL(t *testing.T) {
+	cli, err := api.NewClient("unix", "/tmp/ldbserver.sock", ldbserver.MarshalingTypeJson)
+	if !assert.NoError(t, err, "api.NewClient") {
+		return
+	}
+	defer cli.Close()
+
+	key, kept := []byte("ttl"), []byte("ttl-kept")
+	assert.NoError(t, cli.PutWithTTL(key, []byte("value"), 200*time.Millisecond), "api.client.PutWithTTL")
+	assert.NoError(t, cli.PutWithTTL(kept, []byte("value"), 200*time.Millisecond), "api.client.PutWithTTL")
+	ttl, err := cli.TTL(key)
+	assert.NoError(t, err, "api.client.TTL")
+	assert.True(t, ttl > 0 && ttl <= 200*time.Millisecond, "api.client.TTL")
+	assert.NoError(t, cli.Persist(kept), "api.client.Persist")
+	ttl, err = cli.TTL(kept)
+	assert.NoError(t, err, "api.client.TTL")
+	assert.Equal(t, ttl, api.NoTTL, "api.client.TTL persisted")
+
+	time.Sleep(300 * time.Millisecond)
+	_, err = cli.Get(key)
+	assert.True(t, errors.Is(err, api.ErrNotFound), "api.client.Get expired")
+	_, err = cli.TTL(key)
+	assert.True(t, errors.Is(err, api.ErrNotFound), "api.client.TTL expired")
+	_, err = cli.Get(kept)
+	assert.NoError(t, err, "api.client.Get persisted")
+	assert.NoError(t, cli.Delete(kept), "api.client.Delete")
+}
+
+func TestClientDatabases(t *testing.T) {
+	cli, err := api.NewClient("unix", "/tmp/ldbserver.sock", ldbserver.MarshalingTypeJson)
+	if !assert.NoError(t, err, "api.NewClient") {
+		return
+	}
+	defer cli.Close()
+
+	err = cli.CreateDatabase("client-test")
+	if errors.Is(err, api.ErrBadRequest) {
+		t.Skip("server started without --db-dir")
+	}
+	if !assert.NoError(t, err, "api.client.CreateDatabase") {
+		return
+	}
+	assert.True(t, errors.Is(cli.CreateDatabase("client-test"), api.ErrConditionFailed), "api.client.CreateDatabase twice")
+
+	named, err := api.NewClient("unix", "/tmp/ldbserver.sock", ldbserver.MarshalingTypeJson, api.WithDatabase("client-test"))
+	if assert.NoError(t, err, "api.NewClient") {
+		defer named.Close()
+		key := []byte("databases")
+		assert.NoError(t, named.Put(key, []byte("named")), "api.client.Put")
+		value, err := named.Get(key)
+		assert.NoError(t, err, "api.client.Get")
+		assert.Equal(t, value, []byte("named"), "api.client.Get")
+		_, err = cli.Get(key)
+		assert.True(t, errors.Is(err, api.ErrNotFound), "api.client.Get default database")
+	}
+
+	assert.NoError(t, cli.DropDatabase("client-test"), "api.client.DropDatabase")
+	assert.True(t, errors.Is(cli.DropDatabase("client-test"), api.ErrNotFound), "api.client.DropDatabase twice")
+}
+
+func TestClientBackup(t *testing.T) {
+	cli, err := api.NewClient("unix", "/tmp/ldbserver.sock", ldbserver.MarshalingTypeJson)
+	if !assert.NoError(t, err, "api.NewClient") {
+		return
+	}
+	defer cli.Close()
+
+	assert.NoError(t, cli.Put([]byte("backup"), []byte("value")), "api.client.Put")
+	buf := bytes.NewBuffer(nil)
+	n, err := cli.Backup(buf)
+	assert.NoError(t, err, "api.client.Backup")
+	assert.True(t, n > 0, "api.client.Backup")
+
+	_, err = cli.Restore(bytes.NewReader(buf.Bytes()))
+	assert.True(t, errors.Is(err, api.ErrConditionFailed), "api.client.Restore into non-empty database")
+
+	err = cli.CreateDatabase("client-backup")
+	if errors.Is(err, api.ErrBadRequest) {
+		t.Skip("server started without --db-dir")
+	}
+	if !assert.NoError(t, err, "api.client.CreateDatabase") {
+		return
+	}
+	defer cli.DropDatabase("client-backup")
+
+	named, err := api.NewClient("unix", "/tmp/ldbserver.sock", ldbserver.MarshalingTypeJson, api.WithDatabase("client-backup"))
+	if assert.NoError(t, err, "api.NewClient") {
+		defer named.Close()
+		restored, err := named.Restore(buf)
+		assert.NoError(t, err, "api.client.Restore")
+		assert.Equal(t, restored, n, "api.client.Restore")
+		value, err := named.Get([]byte("backup"))
+		assert.NoError(t, err, "api.client.Get")
+		assert.Equal(t, value, []byte("value"), "api.client.Get")
+	}
+}
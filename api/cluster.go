@@ -0,0 +1,62 @@
+package api
+
+import (
+	"github.com/govlas/ldbserver"
+)
+
+// ClusterStatus reports the raft state of the server, the cluster leader and
+// the members.
+func (cl *Client) ClusterStatus() (*ldbserver.ClusterStatus, error) {
+	req := ldbserver.TransportRequest{
+		Command: ldbserver.TransportRequest_CLUSTER_STATUS.Enum(),
+	}
+
+	resp, err := cl.doRequest(&req)
+	if err != nil {
+		return nil, err
+	}
+	if err := responseError(resp); err != nil {
+		return nil, err
+	}
+	return resp.GetCluster(), nil
+}
+
+// Leader returns the id of the cluster leader, the address clients reach it
+// at. It fails with ErrNotLeader while the cluster has no leader.
+func (cl *Client) Leader() (string, error) {
+	status, err := cl.ClusterStatus()
+	if err != nil {
+		return "", err
+	}
+	if len(status.GetLeader()) == 0 {
+		return "", ErrNotLeader
+	}
+	return status.GetLeader(), nil
+}
+
+// JoinCluster adds the node id, whose raft transport listens at address, to
+// the cluster.
+func (cl *Client) JoinCluster(id, address string) error {
+	return cl.clusterAdmin(ldbserver.TransportRequest_CLUSTER_JOIN, id, address)
+}
+
+// LeaveCluster removes the node id from the cluster.
+func (cl *Client) LeaveCluster(id string) error {
+	return cl.clusterAdmin(ldbserver.TransportRequest_CLUSTER_LEAVE, id, "")
+}
+
+func (cl *Client) clusterAdmin(cmd ldbserver.TransportRequest_Command, id, address string) error {
+	req := ldbserver.TransportRequest{
+		Command: cmd.Enum(),
+		NodeId:  &id,
+	}
+	if len(address) != 0 {
+		req.NodeAddress = &address
+	}
+
+	if resp, err := cl.doRequest(&req); err == nil {
+		return responseError(resp)
+	} else {
+		return err
+	}
+}
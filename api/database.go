@@ -0,0 +1,31 @@
+package api
+
+import (
+	"github.com/govlas/ldbserver"
+)
+
+// CreateDatabase creates the database named name. It fails with
+// ErrConditionFailed if the database exists. Clients reach it with
+// WithDatabase.
+func (cl *Client) CreateDatabase(name string) error {
+	return cl.admin(ldbserver.TransportRequest_DB_CREATE, name)
+}
+
+// DropDatabase removes the database named name with all its keys. It fails
+// with ErrNotFound if the database does not exist.
+func (cl *Client) DropDatabase(name string) error {
+	return cl.admin(ldbserver.TransportRequest_DB_DROP, name)
+}
+
+func (cl *Client) admin(cmd ldbserver.TransportRequest_Command, name string) error {
+	req := ldbserver.TransportRequest{
+		Command:  cmd.Enum(),
+		Database: &name,
+	}
+
+	if resp, err := cl.doRequest(&req); err == nil {
+		return responseError(resp)
+	} else {
+		return err
+	}
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"github.com/govlas/ldbserver"
+)
+
+// Sentinel errors matching the status of a failed response with errors.Is.
+var (
+	ErrNotFound         = &StatusError{Status: ldbserver.TransportResponse_NOT_FOUND, Message: "not found"}
+	ErrBadRequest       = &StatusError{Status: ldbserver.TransportResponse_BAD_REQUEST, Message: "bad request"}
+	ErrChecksumMismatch = &StatusError{Status: ldbserver.TransportResponse_CHECKSUM_MISMATCH, Message: "checksum mismatch"}
+	ErrInternal         = &StatusError{Status: ldbserver.TransportResponse_INTERNAL, Message: "internal error"}
+	ErrConditionFailed  = &StatusError{Status: ldbserver.TransportResponse_CONDITION_FAILED, Message: "condition failed"}
+	ErrDenied           = &StatusError{Status: ldbserver.TransportResponse_DENIED, Message: "access denied"}
+	ErrReadOnly         = &StatusError{Status: ldbserver.TransportResponse_READ_ONLY, Message: "read-only replica"}
+	ErrNotLeader        = &StatusError{Status: ldbserver.TransportResponse_NOT_LEADER, Message: "not the cluster leader"}
+)
+
+// StatusError is returned for a response with a status other than OK.
+type StatusError struct {
+	Status  ldbserver.TransportResponse_Status
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is a StatusError with the same status.
+func (e *StatusError) Is(target error) bool {
+	t, ok := target.(*StatusError)
+	return ok && t.Status == e.Status
+}
+
+func responseError(resp *ldbserver.TransportResponse) error {
+	if resp.GetStatus() == ldbserver.TransportResponse_OK {
+		return nil
+	}
+	return &StatusError{Status: resp.GetStatus(), Message: string(resp.GetBody().GetData())}
+}
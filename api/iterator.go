@@ -0,0 +1,111 @@
+package api
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/govlas/ldbserver"
+)
+
+// Range selects the keys returned by Client.Scan. Start is inclusive, End is
+// exclusive; both narrow the keys matching Prefix. A zero Limit means no limit.
+type Range struct {
+	Start   []byte
+	End     []byte
+	Prefix  []byte
+	Limit   int
+	Reverse bool
+}
+
+// Iterator walks over the pairs returned by Client.Scan.
+type Iterator struct {
+	rs    *responses
+	pairs []*ldbserver.TransportPair
+	pos   int
+	more  bool
+	key   []byte
+	value []byte
+	err   error
+
+	// pair is the current pair along with its metadata, kept by
+	// Client.Backup
+	pair *ldbserver.TransportPair
+}
+
+func (cl *Client) Scan(rng Range) *Iterator {
+	return cl.scan(rng, nil)
+}
+
+func (cl *Client) scan(rng Range, snapshot *uint64) *Iterator {
+	req := ldbserver.TransportRequest{
+		Command:  ldbserver.TransportRequest_SCAN.Enum(),
+		Snapshot: snapshot,
+		Range: &ldbserver.TransportRange{
+			Start:   rng.Start,
+			End:     rng.End,
+			Prefix:  rng.Prefix,
+			Limit:   proto.Uint32(uint32(rng.Limit)),
+			Reverse: proto.Bool(rng.Reverse),
+		},
+	}
+
+	it := &Iterator{more: true}
+	it.rs, it.err = cl.sendRequest(&req)
+	return it
+}
+
+func (it *Iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.pos >= len(it.pairs) {
+		if !it.more {
+			it.Release()
+			return false
+		}
+		resp, err := it.rs.Next()
+		if err != nil {
+			it.err = err
+			it.Release()
+			return false
+		}
+		if err := responseError(resp); err != nil {
+			it.err = err
+			it.more = false
+			it.Release()
+			return false
+		}
+		it.pairs, it.pos, it.more = resp.Pairs, 0, resp.GetMore()
+	}
+
+	pair := it.pairs[it.pos]
+	it.pos++
+	if !ldbserver.CheckBody(pair.Value) {
+		it.err = &StatusError{Status: ldbserver.TransportResponse_CHECKSUM_MISMATCH, Message: "client.Scan: bad checksum for returning data"}
+		it.Release()
+		return false
+	}
+	it.key, it.value, it.pair = pair.Key, pair.Value.GetData(), pair
+	return true
+}
+
+func (it *Iterator) Key() []byte {
+	return it.key
+}
+
+func (it *Iterator) Value() []byte {
+	return it.value
+}
+
+func (it *Iterator) Error() error {
+	return it.err
+}
+
+// Release discards the rest of the scan. It must be called if the iterator is
+// abandoned before Next returns false.
+func (it *Iterator) Release() {
+	if it.rs == nil {
+		return
+	}
+	it.pairs, it.more = nil, false
+	it.rs.Close()
+	it.rs = nil
+}
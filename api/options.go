@@ -0,0 +1,41 @@
+package api
+
+import (
+	"crypto/tls"
+
+	"github.com/govlas/ldbserver"
+)
+
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// WithTLS makes the client connect with TLS. For mutual TLS cfg must hold the
+// client certificate.
+func WithTLS(cfg *tls.Config) Option {
+	return func(cl *Client) {
+		cl.tlsConfig = cfg
+	}
+}
+
+// WithToken authenticates every request of the client with token.
+func WithToken(token string) Option {
+	return func(cl *Client) {
+		cl.token = &token
+	}
+}
+
+// WithCodec makes the client talk in the format of c, such as a codec
+// registered with ldbserver.RegisterCodec, instead of its marshaling type.
+func WithCodec(c ldbserver.Codec) Option {
+	return func(cl *Client) {
+		cl.codec = c
+	}
+}
+
+// WithDatabase sends every request of the client to the database named name
+// instead of the default one.
+func WithDatabase(name string) Option {
+	return func(cl *Client) {
+		cl.database = &name
+	}
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/govlas/ldbserver"
+)
+
+// ReplicationStatus reports whether the server is a primary or a replica, its
+// log position and, on replicas, the lag behind the primary.
+func (cl *Client) ReplicationStatus() (*ldbserver.ReplicationStatus, error) {
+	req := ldbserver.TransportRequest{
+		Command: ldbserver.TransportRequest_REPL_STATUS.Enum(),
+	}
+
+	resp, err := cl.doRequest(&req)
+	if err != nil {
+		return nil, err
+	}
+	if err := responseError(resp); err != nil {
+		return nil, err
+	}
+	return resp.GetReplication(), nil
+}
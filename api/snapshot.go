@@ -0,0 +1,55 @@
+package api
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/govlas/ldbserver"
+)
+
+// Snapshot reads a consistent point-in-time view of the database. It belongs
+// to the connection of its client and is released by the server when the
+// connection is closed or when it is idle for too long.
+type Snapshot struct {
+	cl *Client
+	id uint64
+}
+
+func (cl *Client) Snapshot() (*Snapshot, error) {
+	req := ldbserver.TransportRequest{
+		Command: ldbserver.TransportRequest_SNAPSHOT_OPEN.Enum(),
+	}
+
+	if resp, err := cl.doRequest(&req); err == nil {
+		if err := responseError(resp); err != nil {
+			return nil, err
+		}
+		return &Snapshot{cl: cl, id: resp.GetSnapshot()}, nil
+
+	} else {
+		return nil, err
+	}
+}
+
+func (s *Snapshot) Get(key []byte) (value []byte, err error) {
+	return s.cl.get(key, proto.Uint64(s.id))
+}
+
+func (s *Snapshot) Scan(rng Range) *Iterator {
+	return s.cl.scan(rng, proto.Uint64(s.id))
+}
+
+func (s *Snapshot) Release() error {
+	req := ldbserver.TransportRequest{
+		Command:  ldbserver.TransportRequest_SNAPSHOT_RELEASE.Enum(),
+		Snapshot: proto.Uint64(s.id),
+	}
+
+	if resp, err := s.cl.doRequest(&req); err == nil {
+		if err := responseError(resp); err != nil {
+			return err
+		}
+
+	} else {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/govlas/ldbserver"
+)
+
+var errClosed = errors.New("client: connection closed")
+
+// stream multiplexes requests on one connection. Responses are matched to
+// their requests by seq, so the server may answer out of order.
+type stream struct {
+	codec ldbserver.Codec
+	conn  io.ReadWriteCloser
+	wmu   sync.Mutex
+	enc   ldbserver.Encoder
+
+	mu      sync.Mutex
+	seq     uint64
+	pending map[uint64]*responses
+	err     error
+}
+
+func newStream(conn io.ReadWriteCloser, codec ldbserver.Codec) *stream {
+	st := &stream{
+		codec:   codec,
+		conn:    conn,
+		enc:     codec.NewEncoder(conn),
+		pending: make(map[uint64]*responses),
+	}
+	go st.readLoop()
+	return st
+}
+
+func (st *stream) send(req *ldbserver.TransportRequest) (*responses, error) {
+	st.wmu.Lock()
+	defer st.wmu.Unlock()
+
+	st.mu.Lock()
+	if st.err != nil {
+		st.mu.Unlock()
+		return nil, st.err
+	}
+	st.seq++
+	rs := newQueueResponses(st.seq)
+	st.pending[st.seq] = rs
+	st.mu.Unlock()
+
+	req.Seq = proto.Uint64(rs.seq)
+	if err := st.enc.Encode(req); err != nil {
+		st.fail(err)
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (st *stream) readLoop() {
+	dec := st.codec.NewDecoder(st.conn)
+	for {
+		resp, err := decodeResponse(dec)
+		if err != nil {
+			st.fail(err)
+			return
+		}
+
+		st.mu.Lock()
+		var rs *responses
+		if resp.Seq != nil {
+			rs = st.pending[resp.GetSeq()]
+		} else {
+			// servers predating the seq field answer in order
+			for seq, p := range st.pending {
+				if rs == nil || seq < rs.seq {
+					rs = p
+				}
+			}
+		}
+		if rs != nil && !resp.GetMore() {
+			delete(st.pending, rs.seq)
+		}
+		st.mu.Unlock()
+
+		if rs != nil {
+			rs.push(resp)
+		}
+	}
+}
+
+// fail breaks the connection and all requests waiting on it.
+func (st *stream) fail(err error) {
+	st.mu.Lock()
+	if st.err == nil {
+		st.err = err
+	}
+	pending := st.pending
+	st.pending = make(map[uint64]*responses)
+	st.mu.Unlock()
+
+	st.conn.Close()
+	for _, rs := range pending {
+		rs.fail(st.err)
+	}
+}
+
+// broken reports whether the connection failed or was closed.
+func (st *stream) broken() bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.err != nil
+}
+
+func (st *stream) close() {
+	st.fail(errClosed)
+}
+
+// responses holds the responses to one request. They are either read from an
+// http body or queued by the stream reader.
+type responses struct {
+	seq uint64
+
+	dec  ldbserver.Decoder
+	body io.Closer
+
+	mu     sync.Mutex
+	queue  []*ldbserver.TransportResponse
+	err    error
+	closed bool
+	ready  chan struct{}
+}
+
+func newBodyResponses(body io.ReadCloser, codec ldbserver.Codec, seq uint64) *responses {
+	return &responses{
+		seq:  seq,
+		dec:  codec.NewDecoder(body),
+		body: body,
+	}
+}
+
+func newQueueResponses(seq uint64) *responses {
+	return &responses{seq: seq, ready: make(chan struct{}, 1)}
+}
+
+func (rs *responses) push(resp *ldbserver.TransportResponse) {
+	rs.mu.Lock()
+	if !rs.closed {
+		rs.queue = append(rs.queue, resp)
+	}
+	rs.mu.Unlock()
+	rs.signal()
+}
+
+func (rs *responses) fail(err error) {
+	rs.mu.Lock()
+	rs.err = err
+	rs.mu.Unlock()
+	rs.signal()
+}
+
+func (rs *responses) signal() {
+	select {
+	case rs.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (rs *responses) Next() (resp *ldbserver.TransportResponse, err error) {
+	if rs.dec != nil {
+		if resp, err = decodeResponse(rs.dec); err != nil {
+			return nil, err
+		}
+		// servers predating the seq field do not echo it
+		if resp.Seq != nil && resp.GetSeq() != rs.seq {
+			return nil, errors.New("client: response to another request")
+		}
+		return
+	}
+
+	for {
+		rs.mu.Lock()
+		if len(rs.queue) > 0 {
+			resp = rs.queue[0]
+			rs.queue = rs.queue[1:]
+			rs.mu.Unlock()
+			return resp, nil
+		}
+		err = rs.err
+		rs.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		<-rs.ready
+	}
+}
+
+// Close discards responses that are not read yet.
+func (rs *responses) Close() {
+	if rs.body != nil {
+		rs.body.Close()
+		return
+	}
+	rs.mu.Lock()
+	rs.closed = true
+	rs.queue = nil
+	rs.mu.Unlock()
+}
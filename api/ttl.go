@@ -0,0 +1,64 @@
+package api
+
+import (
+	"time"
+
+	"github.com/govlas/ldbserver"
+)
+
+// NoTTL is the time to live reported for keys which do not expire.
+const NoTTL time.Duration = -1
+
+// PutWithTTL sets key to value, which expires after ttl. It is rounded up to
+// milliseconds; zero or less makes the key persistent like Put.
+func (cl *Client) PutWithTTL(key, value []byte, ttl time.Duration) error {
+	req := ldbserver.TransportRequest{
+		Key:     key,
+		Command: ldbserver.TransportRequest_PUT.Enum(),
+		Body:    &ldbserver.TransportBody{Data: value},
+	}
+	if ttl > 0 {
+		ms := uint64((ttl + time.Millisecond - 1) / time.Millisecond)
+		req.Ttl = &ms
+	}
+	ldbserver.SetBodyChecksum(req.Body)
+
+	resp, err := cl.doRequest(&req)
+	if err != nil {
+		return err
+	}
+	return responseError(resp)
+}
+
+// TTL returns the time left before key expires, or NoTTL if it does not
+// expire. Missing and expired keys give ErrNotFound.
+func (cl *Client) TTL(key []byte) (time.Duration, error) {
+	req := ldbserver.TransportRequest{
+		Key:     key,
+		Command: ldbserver.TransportRequest_TTL.Enum(),
+	}
+	resp, err := cl.doRequest(&req)
+	if err != nil {
+		return 0, err
+	}
+	if err := responseError(resp); err != nil {
+		return 0, err
+	}
+	if resp.GetTtl() < 0 {
+		return NoTTL, nil
+	}
+	return time.Duration(resp.GetTtl()) * time.Millisecond, nil
+}
+
+// Persist removes the expiry of key.
+func (cl *Client) Persist(key []byte) error {
+	req := ldbserver.TransportRequest{
+		Key:     key,
+		Command: ldbserver.TransportRequest_PERSIST.Enum(),
+	}
+	resp, err := cl.doRequest(&req)
+	if err != nil {
+		return err
+	}
+	return responseError(resp)
+}
@@ -0,0 +1,261 @@
+package ldbserver
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
+	"github.com/govlas/logger"
+)
+
+// A backup file is a sequence of BackupRecord messages, each prefixed by its
+// little-endian uint32 length. The last record holds the number of pairs, so
+// that truncated files are detected. Pairs hold the keys of clients along
+// with their expiry and flags.
+
+// maxBackupRecordSize bounds the records read from a backup file.
+const maxBackupRecordSize = 64 * 1024 * 1024
+
+// restoreBatchSize is the number of pairs written in one batch on restore.
+const restoreBatchSize = 1000
+
+var (
+	errNoBackupDir      = errors.New("backup files on the server are disabled")
+	errBadBackupPath    = errors.New("bad backup path")
+	errDatabaseNotEmpty = errors.New("database not empty")
+	errBackupTruncated  = errors.New("backup truncated")
+)
+
+// BackupWriter writes a backup file.
+type BackupWriter struct {
+	w     pio.WriteCloser
+	count uint64
+}
+
+func NewBackupWriter(w io.Writer) *BackupWriter {
+	return &BackupWriter{w: pio.NewUint32DelimitedWriter(w, binary.LittleEndian)}
+}
+
+// Write adds pair, as sent by SCAN and BACKUP.
+func (bw *BackupWriter) Write(pair *TransportPair) error {
+	SetBodyChecksum(pair.Value)
+	if err := bw.w.WriteMsg(&BackupRecord{Pair: pair}); err != nil {
+		return err
+	}
+	bw.count++
+	return nil
+}
+
+// Close writes the last record. It does not close the underlying writer.
+func (bw *BackupWriter) Close() error {
+	return bw.w.WriteMsg(&BackupRecord{Count: proto.Uint64(bw.count)})
+}
+
+// BackupReader reads a backup file written by BackupWriter.
+type BackupReader struct {
+	r     pio.ReadCloser
+	count uint64
+	done  bool
+}
+
+func NewBackupReader(r io.Reader) *BackupReader {
+	return &BackupReader{r: pio.NewUint32DelimitedReader(r, binary.LittleEndian, maxBackupRecordSize)}
+}
+
+// Next returns the next pair. It returns io.EOF after the last pair and an
+// error if the file is truncated or corrupt.
+func (br *BackupReader) Next() (*TransportPair, error) {
+	if br.done {
+		return nil, io.EOF
+	}
+	rec := &BackupRecord{}
+	if err := br.r.ReadMsg(rec); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = errBackupTruncated
+		}
+		return nil, err
+	}
+	if rec.Pair == nil {
+		br.done = true
+		if rec.GetCount() != br.count {
+			return nil, errBackupTruncated
+		}
+		return nil, io.EOF
+	}
+	if !CheckBody(rec.Pair.Value) {
+		return nil, ErrChecksumMismatch
+	}
+	br.count++
+	return rec.Pair, nil
+}
+
+// Backup writes a consistent copy of the keys of r to w. Expired keys are
+// kept with their expiry.
+func Backup(w io.Writer, r Reader) (n int, err error) {
+	bw := NewBackupWriter(w)
+	it := iterateData(r, nil, nil, time.Time{})
+	defer it.Release()
+	for ok := it.First(); ok; ok = it.Next() {
+		if err = bw.Write(dataPair(it.Key(), it.Value(), it.meta)); err != nil {
+			return
+		}
+		n++
+	}
+	if err = it.Error(); err != nil {
+		return
+	}
+	return n, bw.Close()
+}
+
+// Restore loads the backup read from r into st, which must be empty and not
+// written to by others meanwhile. A failed restore leaves st empty again.
+func Restore(st Storage, r io.Reader) (n int, err error) {
+	if empty, err := storageEmpty(st); err != nil {
+		return 0, err
+	} else if !empty {
+		return 0, errDatabaseNotEmpty
+	}
+
+	if n, err = restorePairs(st, r); err != nil {
+		if cerr := clearStorage(st); cerr != nil {
+			logger.Warning("removing a partial restore: %v", cerr)
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// restorePairs writes the pairs of the backup read from r to st.
+func restorePairs(st Storage, r io.Reader) (n int, err error) {
+	br := NewBackupReader(r)
+	ops := make([]Operation, 0, restoreBatchSize)
+	for {
+		pair, err := br.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return n, err
+		}
+		ops = writeOps(ops, pair.Key, pair.Value.GetData(), false, meta{}, pairMeta(pair))
+		if n++; n%restoreBatchSize == 0 {
+			if err := st.Batch(ops, false); err != nil {
+				return n, err
+			}
+			ops = ops[:0]
+		}
+	}
+	if err := st.Batch(ops, true); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// SetBackupDir enables BACKUP and RESTORE of files on the server. Their paths
+// are relative to dir.
+func (s *leveldbServer) SetBackupDir(dir string) {
+	s.backupDir = dir
+}
+
+// backupPath returns the file named by req in the backup directory.
+func (s *leveldbServer) backupPath(req *TransportRequest) (string, error) {
+	if len(s.backupDir) == 0 {
+		return "", errNoBackupDir
+	}
+	name := filepath.Clean(req.GetPath())
+	if len(req.GetPath()) == 0 || filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+		return "", errBadBackupPath
+	}
+	return filepath.Join(s.backupDir, name), nil
+}
+
+// backup streams a snapshot of the live keys of d to tr, or writes it to the
+// backup file named by req.
+func (s *leveldbServer) backup(d *database, tr Transporter, req *TransportRequest) error {
+	if req.Path == nil {
+		snap, err := d.st.Snapshot()
+		if err != nil {
+			return tr.SendResponse(answer(req, makeDbErrorResponse(err)))
+		}
+		defer snap.Release()
+		it := iterateData(snap, nil, nil, time.Now())
+		defer it.Release()
+		return sendPairs(tr, req, it, 0, false)
+	}
+
+	path, err := s.backupPath(req)
+	if err != nil {
+		return tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_BAD_REQUEST, err)))
+	}
+	if err := backupFile(path, d.st); err != nil {
+		return tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_INTERNAL, err)))
+	}
+	return tr.SendResponse(answer(req, &TransportResponse{Status: TransportResponse_OK.Enum()}))
+}
+
+// backupFile writes a snapshot of st to path. The file appears only when it
+// is complete.
+func backupFile(path string, st Storage) error {
+	snap, err := st.Snapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	if _, err := Backup(file, snap); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// restore loads the backup file named by req into d. Other requests wait for
+// the end of the restore.
+func (s *leveldbServer) restore(d *database, req *TransportRequest) *TransportResponse {
+	path, err := s.backupPath(req)
+	if err != nil {
+		return MakeErrorResponse(TransportResponse_BAD_REQUEST, err)
+	}
+	if err := d.lock(); err != nil {
+		return makeDbErrorResponse(err)
+	}
+	defer d.unlock()
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return MakeErrorResponse(TransportResponse_NOT_FOUND, err)
+		}
+		return MakeErrorResponse(TransportResponse_INTERNAL, err)
+	}
+	defer file.Close()
+
+	switch _, err := Restore(d.st, file); err {
+	case nil:
+		return &TransportResponse{Status: TransportResponse_OK.Enum()}
+	case errDatabaseNotEmpty:
+		return MakeErrorResponse(TransportResponse_CONDITION_FAILED, err)
+	case errBackupTruncated, ErrChecksumMismatch:
+		return MakeErrorResponse(TransportResponse_BAD_REQUEST, err)
+	default:
+		return MakeErrorResponse(TransportResponse_INTERNAL, err)
+	}
+}
@@ -0,0 +1,118 @@
+package ldbserver
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupFile(t *testing.T) {
+	src := NewMemStorage()
+	for i := 0; i < restoreBatchSize+10; i++ {
+		m := meta{flags: uint32(i)}
+		if i%2 == 0 {
+			m.expires = int64(i + 1)
+		}
+		src.Batch(writeOps(nil, []byte(fmt.Sprintf("key%05d", i)), []byte(fmt.Sprintf("value%d", i)), false, meta{}, m), false)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	n, err := Backup(buf, src)
+	assert.NoError(t, err, "Backup")
+	assert.Equal(t, n, restoreBatchSize+10, "Backup")
+	data := buf.Bytes()
+
+	dst := NewMemStorage()
+	n, err = Restore(dst, bytes.NewReader(data))
+	assert.NoError(t, err, "Restore")
+	assert.Equal(t, n, restoreBatchSize+10, "Restore")
+	m, value, err := read(dst, []byte("key00042"))
+	assert.NoError(t, err, "Get restored")
+	assert.Equal(t, value, []byte("value42"), "Get restored")
+	assert.Equal(t, m, meta{expires: 43, flags: 42}, "Get restored metadata")
+	it := dst.Iterate(expiryIndexPrefix, nil)
+	assert.True(t, it.First(), "Restored expiry index")
+	it.Release()
+
+	_, err = Restore(dst, bytes.NewReader(data))
+	assert.Equal(t, err, errDatabaseNotEmpty, "Restore into non-empty database")
+	dst = NewMemStorage()
+	_, err = Restore(dst, bytes.NewReader(data[:len(data)-1]))
+	assert.Equal(t, err, errBackupTruncated, "Restore truncated backup")
+	_, _, err = read(dst, []byte("key00042"))
+	assert.Equal(t, err, ErrNotFound, "Get after failed restore")
+	n, err = Restore(dst, bytes.NewReader(data))
+	assert.NoError(t, err, "Restore after failed restore")
+	assert.Equal(t, n, restoreBatchSize+10, "Restore after failed restore")
+
+	br := NewBackupReader(bytes.NewReader(data[:len(data)/2]))
+	for err == nil {
+		_, err = br.Next()
+	}
+	assert.Equal(t, err, errBackupTruncated, "Read truncated backup")
+
+	_, err = NewBackupReader(bytes.NewReader(nil)).Next()
+	assert.Equal(t, err, errBackupTruncated, "Read empty file")
+	buf.Reset()
+	Backup(buf, NewMemStorage())
+	_, err = NewBackupReader(buf).Next()
+	assert.Equal(t, err, io.EOF, "Read empty backup")
+}
+
+func TestLevelDBBackup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ldbserver-backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := NewLevelDbServer(filepath.Join(dir, "db"))
+	if !assert.NoError(t, err, "NewLevelDbServer") {
+		return
+	}
+	defer db.Close()
+	for _, key := range []string{"a", "b", "c"} {
+		serveCommand(t, db, TransportRequest_PUT, []byte(key), []byte("v"+key), MarshalingTypeProtobuf, true)
+	}
+
+	backup := func(path string) *TransportResponse {
+		req := &TransportRequest{Command: TransportRequest_BACKUP.Enum()}
+		if len(path) != 0 {
+			req.Path = proto.String(path)
+		}
+		return handleRequest(t, db, nil, req)
+	}
+	restore := func(database, path string) *TransportResponse {
+		return handleRequest(t, db, nil, &TransportRequest{Command: TransportRequest_RESTORE.Enum(), Database: proto.String(database), Path: proto.String(path)})
+	}
+
+	if resp := backup(""); assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Backup to client") {
+		assert.Len(t, resp.Pairs, 3, "Backup to client")
+	}
+	assert.Equal(t, backup("full").GetStatus(), TransportResponse_BAD_REQUEST, "Backup without backup dir")
+
+	db.SetBackupDir(filepath.Join(dir, "backups"))
+	db.SetDatabaseDir(filepath.Join(dir, "databases"), nil)
+	os.MkdirAll(filepath.Join(dir, "backups"), 0755)
+	os.MkdirAll(filepath.Join(dir, "databases"), 0755)
+
+	assert.Equal(t, backup("../full").GetStatus(), TransportResponse_BAD_REQUEST, "Backup outside backup dir")
+	assert.Equal(t, backup("/tmp/full").GetStatus(), TransportResponse_BAD_REQUEST, "Backup to absolute path")
+	assert.Equal(t, backup("full").GetStatus(), TransportResponse_OK, "Backup to file")
+
+	assert.Equal(t, restore("", "full").GetStatus(), TransportResponse_CONDITION_FAILED, "Restore into non-empty database")
+	assert.Equal(t, handleRequest(t, db, nil, &TransportRequest{Command: TransportRequest_DB_CREATE.Enum(), Database: proto.String("copy")}).GetStatus(), TransportResponse_OK, "Create")
+	assert.Equal(t, restore("copy", "missing").GetStatus(), TransportResponse_NOT_FOUND, "Restore missing file")
+	assert.Equal(t, restore("copy", "full").GetStatus(), TransportResponse_OK, "Restore")
+	resp := handleRequest(t, db, nil, &TransportRequest{Command: TransportRequest_GET.Enum(), Key: []byte("b"), Database: proto.String("copy")})
+	if assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Get restored") {
+		assert.Equal(t, resp.Body.Data, []byte("vb"), "Get restored")
+	}
+}
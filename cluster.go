@@ -0,0 +1,416 @@
+package ldbserver
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/govlas/logger"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/raft"
+)
+
+const (
+	// clusterApplyTimeout bounds the wait for a write to be committed.
+	clusterApplyTimeout = 10 * time.Second
+	// raftSnapshotsRetained is the number of raft snapshots kept on disk.
+	raftSnapshotsRetained = 2
+)
+
+var (
+	errNoCluster      = errors.New("cluster mode disabled")
+	errNotLeader      = errors.New("not the cluster leader")
+	errClusterRestore = errors.New("RESTORE of the default database is not replicated by the cluster")
+	errNoNode         = errors.New("no node_id or node_address in request")
+	errCommitUnknown  = errors.New("leadership lost before the write was committed; it may be applied or not")
+	errClusterData    = errors.New("the default database is not empty and the node has no raft state; start it on an empty database or allow dropping its keys")
+
+	// sweepLogEntry makes every node delete the keys expired at the time the
+	// entry was appended. It can not be mistaken for a TransportRequest,
+	// whose encoding never starts with a zero byte.
+	sweepLogEntry = []byte{0}
+)
+
+// ClusterConfig configures the raft node of a server.
+type ClusterConfig struct {
+	// NodeID identifies the node in the cluster. It is the address clients
+	// reach the node at, since followers redirect writes to the id of the
+	// leader.
+	NodeID string
+	// Bind is the tcp address of the raft transport. Advertise is the
+	// address other nodes reach it at; empty means Bind.
+	Bind      string
+	Advertise string
+	// Dir keeps the raft log and snapshots. Empty keeps them in memory, which
+	// only suits tests.
+	Dir string
+	// Bootstrap makes a new cluster with this node as its only member. It is
+	// ignored if Dir holds the state of an earlier run.
+	Bootstrap bool
+	// ClearDatabase allows a node without raft state to drop the keys of its
+	// default database, which are not part of the cluster.
+	ClearDatabase bool
+}
+
+// Cluster is the raft node of a server. Writes to the default database are
+// committed to the raft log before they are applied, on every node in the
+// same order. Reads are answered from the local database, which may lag
+// behind the leader on followers.
+type Cluster struct {
+	id        string
+	raft      *raft.Raft
+	transport *raft.NetworkTransport
+	store     *raftStore
+}
+
+// EnableCluster makes the default database part of a raft cluster. The
+// database is rebuilt from the raft snapshot and log, so its current keys
+// are dropped; a node without raft state refuses to drop them unless
+// cfg.ClearDatabase is set. It must be called before serving.
+func (s *leveldbServer) EnableCluster(cfg ClusterConfig) (*Cluster, error) {
+	if len(cfg.NodeID) == 0 {
+		return nil, errors.New("cluster node id is empty")
+	}
+
+	var advertise net.Addr
+	if len(cfg.Advertise) != 0 {
+		addr, err := net.ResolveTCPAddr("tcp", cfg.Advertise)
+		if err != nil {
+			return nil, err
+		}
+		advertise = addr
+	}
+	transport, err := raft.NewTCPTransport(cfg.Bind, advertise, 3, clusterApplyTimeout, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cluster{id: cfg.NodeID, transport: transport}
+	var (
+		logs   raft.LogStore
+		stable raft.StableStore
+		snaps  raft.SnapshotStore
+	)
+	if len(cfg.Dir) == 0 {
+		mem := raft.NewInmemStore()
+		logs, stable, snaps = mem, mem, raft.NewInmemSnapshotStore()
+	} else {
+		err = os.MkdirAll(cfg.Dir, 0755)
+		if err == nil {
+			c.store, err = openRaftStore(filepath.Join(cfg.Dir, "log"))
+		}
+		if err == nil {
+			snaps, err = raft.NewFileSnapshotStore(cfg.Dir, raftSnapshotsRetained, os.Stderr)
+		}
+		if err != nil {
+			c.close()
+			return nil, err
+		}
+		logs, stable = c.store, c.store
+	}
+
+	existing, err := raft.HasExistingState(logs, stable, snaps)
+	if err == nil {
+		err = c.clearDatabase(s.db.st, existing, cfg.ClearDatabase)
+	}
+	if err != nil {
+		c.close()
+		return nil, err
+	}
+
+	rc := raft.DefaultConfig()
+	rc.LocalID = raft.ServerID(cfg.NodeID)
+	rc.Logger = hclog.New(&hclog.LoggerOptions{Name: "raft", Level: hclog.Warn, Output: os.Stderr})
+	if c.raft, err = raft.NewRaft(rc, &clusterFSM{d: s.db}, logs, stable, snaps, transport); err != nil {
+		c.close()
+		return nil, err
+	}
+
+	if cfg.Bootstrap && !existing {
+		err = c.raft.BootstrapCluster(raft.Configuration{Servers: []raft.Server{{
+			ID:      rc.LocalID,
+			Address: transport.LocalAddr(),
+		}}}).Error()
+		if err != nil {
+			c.close()
+			return nil, err
+		}
+	}
+	s.mu.Lock()
+	s.cluster = c
+	s.mu.Unlock()
+	return c, nil
+}
+
+// clearDatabase empties st before the raft state is applied to it. The keys
+// of a node with raft state are those it applied before, the keys of a new
+// node are dropped only if force.
+func (c *Cluster) clearDatabase(st Storage, existing, force bool) error {
+	empty, err := storageEmpty(st)
+	if err != nil || empty {
+		return err
+	}
+	switch {
+	case existing:
+		logger.Info("cluster node %s: rebuilding the default database from the raft state", c.id)
+	case force:
+		logger.Warning("cluster node %s: dropping the keys of the default database, which has no raft state", c.id)
+	default:
+		return errClusterData
+	}
+	return clearStorage(st)
+}
+
+// ID returns the id of the node.
+func (c *Cluster) ID() string {
+	return c.id
+}
+
+// RaftAddress returns the address other nodes reach the raft transport at.
+func (c *Cluster) RaftAddress() string {
+	return string(c.transport.LocalAddr())
+}
+
+// Leader returns the id of the leader, or an empty string if there is none.
+func (c *Cluster) Leader() string {
+	_, id := c.raft.LeaderWithID()
+	return string(id)
+}
+
+func (c *Cluster) close() {
+	if c.raft != nil {
+		c.raft.Shutdown().Error()
+	}
+	c.transport.Close()
+	if c.store != nil {
+		c.store.Close()
+	}
+}
+
+// clusterRequest answers the cluster commands and the writes to the default
+// database committed through raft. It returns nil for other requests.
+func (s *leveldbServer) clusterRequest(req *TransportRequest) *TransportResponse {
+	cmd := req.GetCommand()
+	if cmd == TransportRequest_CLUSTER_JOIN || cmd == TransportRequest_CLUSTER_LEAVE || cmd == TransportRequest_CLUSTER_STATUS {
+		if s.cluster == nil {
+			return MakeErrorResponse(TransportResponse_BAD_REQUEST, errNoCluster)
+		}
+		return s.cluster.admin(req)
+	}
+	if s.cluster == nil || len(req.GetDatabase()) != 0 {
+		return nil
+	}
+	switch cmd {
+	case TransportRequest_PUT, TransportRequest_DELETE, TransportRequest_BATCH,
+		TransportRequest_CAS, TransportRequest_PUT_IF_ABSENT, TransportRequest_DELETE_IF_EQUAL,
+		TransportRequest_PERSIST, TransportRequest_REPLACE:
+		return s.cluster.apply(req)
+	case TransportRequest_RESTORE:
+		return MakeErrorResponse(TransportResponse_BAD_REQUEST, errClusterRestore)
+	}
+	return nil
+}
+
+// apply commits the write of req to the raft log and returns the response of
+// the database.
+func (c *Cluster) apply(req *TransportRequest) *TransportResponse {
+	if c.raft.State() != raft.Leader {
+		return c.notLeader()
+	}
+	// only the write itself goes to the log
+	data, err := proto.Marshal(&TransportRequest{
+		Command:  req.Command,
+		Key:      requestKey(req),
+		Body:     req.Body,
+		Batch:    req.Batch,
+		Sync:     req.Sync,
+		Expected: req.Expected,
+		Ttl:      req.Ttl,
+		Flags:    req.Flags,
+
+		ExpectedVersion: req.ExpectedVersion,
+	})
+	if err != nil {
+		return MakeErrorResponse(TransportResponse_BAD_REQUEST, err)
+	}
+	f := c.raft.Apply(data, clusterApplyTimeout)
+	if err := f.Error(); err != nil {
+		return c.raftError(err)
+	}
+	return f.Response().(*TransportResponse)
+}
+
+// sweep deletes the expired keys of d through the raft log if the node is
+// the leader, until stop is closed. Entries are only appended while keys
+// have expired, so that an idle cluster does not grow its log.
+func (c *Cluster) sweep(d *database, stop <-chan struct{}) {
+	for c.raft.State() == raft.Leader {
+		expired, err := d.expired(time.Now())
+		if err != nil {
+			logger.WarningErr(err)
+			return
+		}
+		if !expired {
+			return
+		}
+		f := c.raft.Apply(sweepLogEntry, clusterApplyTimeout)
+		if err := f.Error(); err != nil {
+			if err != raft.ErrNotLeader && err != raft.ErrLeadershipLost {
+				logger.WarningErr(err)
+			}
+			return
+		}
+		res := f.Response().(sweepResult)
+		if res.err != nil {
+			logger.WarningErr(res.err)
+			return
+		}
+		if res.n < ttlSweepBatchSize {
+			return
+		}
+		select {
+		case <-stop:
+			return
+		default:
+		}
+	}
+}
+
+func (c *Cluster) admin(req *TransportRequest) *TransportResponse {
+	var f raft.Future
+	switch req.GetCommand() {
+	case TransportRequest_CLUSTER_STATUS:
+		return c.status()
+	case TransportRequest_CLUSTER_JOIN:
+		if len(req.GetNodeId()) == 0 || len(req.GetNodeAddress()) == 0 {
+			return MakeErrorResponse(TransportResponse_BAD_REQUEST, errNoNode)
+		}
+		if c.raft.State() != raft.Leader {
+			return c.notLeader()
+		}
+		f = c.raft.AddVoter(raft.ServerID(req.GetNodeId()), raft.ServerAddress(req.GetNodeAddress()), 0, clusterApplyTimeout)
+	default:
+		if len(req.GetNodeId()) == 0 {
+			return MakeErrorResponse(TransportResponse_BAD_REQUEST, errNoNode)
+		}
+		if c.raft.State() != raft.Leader {
+			return c.notLeader()
+		}
+		f = c.raft.RemoveServer(raft.ServerID(req.GetNodeId()), 0, clusterApplyTimeout)
+	}
+	if err := f.Error(); err != nil {
+		return c.raftError(err)
+	}
+	return &TransportResponse{Status: TransportResponse_OK.Enum()}
+}
+
+func (c *Cluster) status() *TransportResponse {
+	status := &ClusterStatus{
+		NodeId:       proto.String(c.id),
+		State:        proto.String(c.raft.State().String()),
+		Leader:       proto.String(c.Leader()),
+		AppliedIndex: proto.Uint64(c.raft.AppliedIndex()),
+	}
+	f := c.raft.GetConfiguration()
+	if err := f.Error(); err != nil {
+		return MakeErrorResponse(TransportResponse_INTERNAL, err)
+	}
+	for _, srv := range f.Configuration().Servers {
+		status.Nodes = append(status.Nodes, &ClusterNode{
+			Id:      proto.String(string(srv.ID)),
+			Address: proto.String(string(srv.Address)),
+			Voter:   proto.Bool(srv.Suffrage == raft.Voter),
+		})
+	}
+	return &TransportResponse{Status: TransportResponse_OK.Enum(), Leader: status.Leader, Cluster: status}
+}
+
+// notLeader redirects the client to the leader.
+func (c *Cluster) notLeader() *TransportResponse {
+	resp := MakeErrorResponse(TransportResponse_NOT_LEADER, errNotLeader)
+	if leader := c.Leader(); len(leader) != 0 {
+		resp.Leader = proto.String(leader)
+	}
+	return resp
+}
+
+// raftError reports a failed raft operation. Only writes refused by a
+// follower are redirected: a write whose leader lost its leadership may have
+// been committed anyway, and sending it again could apply it twice.
+func (c *Cluster) raftError(err error) *TransportResponse {
+	switch err {
+	case raft.ErrNotLeader:
+		return c.notLeader()
+	case raft.ErrLeadershipLost:
+		return MakeErrorResponse(TransportResponse_INTERNAL, errCommitUnknown)
+	}
+	return MakeErrorResponse(TransportResponse_INTERNAL, err)
+}
+
+// clusterFSM applies the committed writes to the default database.
+type clusterFSM struct {
+	d *database
+}
+
+// sweepResult is the response to sweepLogEntry.
+type sweepResult struct {
+	n   int
+	err error
+}
+
+func (f *clusterFSM) Apply(log *raft.Log) interface{} {
+	// expiries are relative to the time the leader logged the write, so that
+	// they are the same on every node
+	now := log.AppendedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if bytes.Equal(log.Data, sweepLogEntry) {
+		n, err := f.d.sweep(now, ttlSweepBatchSize)
+		return sweepResult{n, err}
+	}
+	req := &TransportRequest{}
+	if err := proto.Unmarshal(log.Data, req); err != nil {
+		return MakeErrorResponse(TransportResponse_INTERNAL, err)
+	}
+	return f.d.execute(nil, req, now)
+}
+
+// Snapshot and Restore use the backup format.
+func (f *clusterFSM) Snapshot() (raft.FSMSnapshot, error) {
+	snap, err := f.d.st.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &clusterSnapshot{snap: snap}, nil
+}
+
+func (f *clusterFSM) Restore(r io.ReadCloser) error {
+	defer r.Close()
+	if err := clearStorage(f.d.st); err != nil {
+		return err
+	}
+	_, err := Restore(f.d.st, r)
+	return err
+}
+
+type clusterSnapshot struct {
+	snap Snapshot
+}
+
+func (cs *clusterSnapshot) Persist(sink raft.SnapshotSink) error {
+	if _, err := Backup(sink, cs.snap); err != nil {
+		sink.Cancel()
+		return err
+	}
+	return sink.Close()
+}
+
+func (cs *clusterSnapshot) Release() {
+	cs.snap.Release()
+}
@@ -0,0 +1,196 @@
+package ldbserver_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/govlas/ldbserver"
+	"github.com/govlas/ldbserver/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCluster(t *testing.T) {
+	type node struct {
+		addr    string
+		db      ldbserver.DBServer
+		cluster *ldbserver.Cluster
+		ns      *ldbserver.NetworkServer
+		done    chan error
+		cli     *api.Client
+	}
+	var nodes []*node
+	for i := 0; i < 3; i++ {
+		n := &node{addr: freeAddr(t), done: make(chan error, 1)}
+		db := ldbserver.NewServer(ldbserver.NewMemStorage())
+		defer db.Close()
+		n.db = db
+		var err error
+		n.cluster, err = db.EnableCluster(ldbserver.ClusterConfig{NodeID: n.addr, Bind: "127.0.0.1:0", Bootstrap: i == 0})
+		if !assert.NoError(t, err, "EnableCluster") {
+			return
+		}
+		n.ns = ldbserver.NewNetworkServer("tcp", n.addr)
+		go func() {
+			n.done <- n.ns.ListenAndServe(db, ldbserver.JsonProtobufTransportFactory{Mt: ldbserver.MarshalingTypeProtobuf})
+		}()
+		defer func() {
+			n.ns.Stop()
+			<-n.done
+		}()
+		nodes = append(nodes, n)
+	}
+	eventually := func(msg string, f func() bool) {
+		for i := 0; i < 1000; i++ {
+			if f() {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatal(msg)
+	}
+	eventually("no leader", func() bool { return nodes[0].cluster.Leader() == nodes[0].addr })
+
+	for _, n := range nodes {
+		cli, err := api.NewClient("tcp", n.addr, ldbserver.MarshalingTypeProtobuf)
+		if !assert.NoError(t, err, "api.NewClient") {
+			return
+		}
+		defer cli.Close()
+		n.cli = cli
+	}
+	// joins sent to a follower are redirected to the leader
+	assert.NoError(t, nodes[0].cli.JoinCluster(nodes[1].addr, nodes[1].cluster.RaftAddress()), "api.client.JoinCluster")
+	eventually("leader unknown to the joined node", func() bool {
+		leader, err := nodes[1].cli.Leader()
+		return err == nil && leader == nodes[0].addr
+	})
+	assert.NoError(t, nodes[1].cli.JoinCluster(nodes[2].addr, nodes[2].cluster.RaftAddress()), "api.client.JoinCluster redirected")
+
+	status, err := nodes[2].cli.ClusterStatus()
+	if assert.NoError(t, err, "api.client.ClusterStatus") {
+		assert.Len(t, status.Nodes, 3, "api.client.ClusterStatus")
+	}
+	eventually("leader unknown to followers", func() bool {
+		leader, err := nodes[2].cli.Leader()
+		return err == nil && leader == nodes[0].addr
+	})
+
+	// writes sent to followers are committed by the leader and applied
+	// everywhere
+	for i, n := range nodes {
+		key := []byte(fmt.Sprint("key", i))
+		assert.NoError(t, n.cli.Put(key, []byte("value")), "api.client.Put")
+		for _, m := range nodes {
+			eventually("write not applied", func() bool {
+				value, err := m.cli.Get(key)
+				return err == nil && string(value) == "value"
+			})
+		}
+	}
+	ok, err := nodes[1].cli.CompareAndSwap([]byte("key0"), []byte("value"), []byte("swapped"))
+	assert.NoError(t, err, "api.client.CompareAndSwap")
+	assert.True(t, ok, "api.client.CompareAndSwap")
+	ok, err = nodes[2].cli.CompareAndSwap([]byte("key0"), []byte("value"), []byte("again"))
+	assert.NoError(t, err, "api.client.CompareAndSwap stale")
+	assert.False(t, ok, "api.client.CompareAndSwap stale")
+
+	// the leader deletes expired keys through the raft log
+	assert.NoError(t, nodes[1].cli.PutWithTTL([]byte("expiring"), []byte("value"), 10*time.Millisecond), "api.client.PutWithTTL")
+	status, err = nodes[0].cli.ClusterStatus()
+	if assert.NoError(t, err, "api.client.ClusterStatus") {
+		eventually("expired key not swept", func() bool {
+			swept, err := nodes[2].cli.ClusterStatus()
+			return err == nil && swept.GetAppliedIndex() > status.GetAppliedIndex()
+		})
+	}
+
+	// a new leader is elected when the leader fails
+	nodes[0].ns.Stop()
+	<-nodes[0].done
+	nodes[0].done <- nil
+	nodes[0].db.Close()
+	eventually("no new leader", func() bool {
+		return nodes[1].cli.Put([]byte("after"), []byte("failover")) == nil
+	})
+	eventually("write not applied after failover", func() bool {
+		value, err := nodes[2].cli.Get([]byte("after"))
+		return err == nil && string(value) == "failover"
+	})
+
+	assert.NoError(t, nodes[2].cli.LeaveCluster(nodes[0].addr), "api.client.LeaveCluster")
+	status, err = nodes[1].cli.ClusterStatus()
+	if assert.NoError(t, err, "api.client.ClusterStatus") {
+		assert.Len(t, status.Nodes, 2, "api.client.ClusterStatus after leave")
+	}
+}
+
+func TestClusterRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ldbserver-cluster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// the database is rebuilt from the raft log kept in dir
+	addr := freeAddr(t)
+	for run := 0; run < 2; run++ {
+		db := ldbserver.NewServer(ldbserver.NewMemStorage())
+		cluster, err := db.EnableCluster(ldbserver.ClusterConfig{NodeID: addr, Bind: "127.0.0.1:0", Dir: dir, Bootstrap: true})
+		if !assert.NoError(t, err, "EnableCluster") {
+			return
+		}
+		ns := ldbserver.NewNetworkServer("tcp", addr)
+		done := make(chan error, 1)
+		go func() {
+			done <- ns.ListenAndServe(db, ldbserver.JsonProtobufTransportFactory{Mt: ldbserver.MarshalingTypeJson})
+		}()
+		for i := 0; i < 500 && cluster.Leader() != addr; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		cli, err := api.NewClient("tcp", addr, ldbserver.MarshalingTypeJson)
+		if assert.NoError(t, err, "api.NewClient") {
+			if run == 0 {
+				assert.NoError(t, cli.Put([]byte("hello"), []byte("world")), "api.client.Put")
+				assert.NoError(t, cli.Delete([]byte("hello")), "api.client.Delete")
+				assert.NoError(t, cli.Put([]byte("hello"), []byte("again")), "api.client.Put")
+			}
+			for i := 0; i < 500; i++ {
+				if _, err := cli.Get([]byte("hello")); err == nil {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			value, err := cli.Get([]byte("hello"))
+			assert.NoError(t, err, fmt.Sprintf("api.client.Get run %d", run))
+			assert.Equal(t, value, []byte("again"), fmt.Sprintf("api.client.Get run %d", run))
+			cli.Close()
+		}
+		ns.Stop()
+		<-done
+		db.Close()
+	}
+}
+
+func TestClusterExistingDatabase(t *testing.T) {
+	st := ldbserver.NewMemStorage()
+	assert.NoError(t, st.Batch([]ldbserver.Operation{{Key: []byte("dhello"), Value: []byte("world")}}, false), "Batch")
+	db := ldbserver.NewServer(st)
+	defer db.Close()
+
+	// the keys of a node without raft state are only dropped on request
+	_, err := db.EnableCluster(ldbserver.ClusterConfig{NodeID: freeAddr(t), Bind: "127.0.0.1:0", Bootstrap: true})
+	assert.Error(t, err, "EnableCluster of a non-empty database")
+	it := st.Iterate([]byte("d"), []byte("e"))
+	assert.True(t, it.First(), "keys kept")
+	it.Release()
+
+	_, err = db.EnableCluster(ldbserver.ClusterConfig{NodeID: freeAddr(t), Bind: "127.0.0.1:0", Bootstrap: true, ClearDatabase: true})
+	assert.NoError(t, err, "EnableCluster with ClearDatabase")
+	it = st.Iterate([]byte("d"), []byte("e"))
+	assert.False(t, it.First(), "keys dropped")
+	it.Release()
+}
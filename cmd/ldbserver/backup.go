@@ -0,0 +1,140 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/govlas/ldbserver"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// runBackup runs the backup and restore subcommands:
+//
+//	ldbserver backup [flags] file
+//	ldbserver restore [flags] file
+//
+// With --db they work on a database directory no server has open, otherwise
+// on a running server. The file "-" is stdout or stdin.
+func runBackup(cmd string, args []string) error {
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	arg_db := fs.String("db", "", "path to a database not opened by a server")
+	client := addClientFlags(fs)
+	arg_on_server := fs.Bool("on-server", false, "file is in the backup directory of the server")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: ldbserver %s [flags] file\n", cmd)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	fname := fs.Arg(0)
+
+	if len(*arg_db) != 0 {
+		// a backup leaves the database as it is
+		st, err := ldbserver.OpenLevelDBStorage(*arg_db, &opt.Options{ReadOnly: cmd == "backup"})
+		if err != nil {
+			return err
+		}
+		defer st.Close()
+		if cmd == "backup" {
+			return writeBackup(fname, func(w io.Writer) (int, error) {
+				snap, err := st.Snapshot()
+				if err != nil {
+					return 0, err
+				}
+				defer snap.Release()
+				return ldbserver.Backup(w, snap)
+			})
+		}
+		return readBackup(fname, func(r io.Reader) (int, error) {
+			return ldbserver.Restore(st, r)
+		})
+	}
+
+	cl, err := client.dial()
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	switch {
+	case *arg_on_server && cmd == "backup":
+		return cl.BackupToServer(fname)
+	case *arg_on_server:
+		return cl.RestoreFromServer(fname)
+	case cmd == "backup":
+		return writeBackup(fname, cl.Backup)
+	default:
+		return readBackup(fname, cl.Restore)
+	}
+}
+
+// runUpgrade runs the upgrade subcommand, which rewrites the databases at
+// the given paths in the current layout:
+//
+//	ldbserver upgrade path...
+func runUpgrade(args []string) error {
+	fs := flag.NewFlagSet("upgrade", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: ldbserver upgrade path...")
+		fmt.Fprintln(os.Stderr, "rewrites databases written by an older server, which no server may have open; the originals are kept at path.old")
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	for _, path := range fs.Args() {
+		if err := ldbserver.UpgradeLevelDB(path, nil); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// writeBackup writes a backup to fname. A partial file is removed.
+func writeBackup(fname string, backup func(io.Writer) (int, error)) error {
+	if fname == "-" {
+		_, err := backup(os.Stdout)
+		return err
+	}
+	file, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+	n, err := backup(file)
+	if err == nil {
+		err = file.Sync()
+	}
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(fname)
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d pairs written to %s\n", n, fname)
+	return nil
+}
+
+func readBackup(fname string, restore func(io.Reader) (int, error)) error {
+	r := io.Reader(os.Stdin)
+	if fname != "-" {
+		file, err := os.Open(fname)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+	n, err := restore(r)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d pairs restored from %s\n", n, fname)
+	return nil
+}
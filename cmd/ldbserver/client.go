@@ -0,0 +1,59 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"flag"
+
+	"github.com/govlas/ldbserver"
+	"github.com/govlas/ldbserver/api"
+)
+
+// clientFlags are the flags of the subcommands talking to a running server.
+type clientFlags struct {
+	net, host, form, database, token *string
+	tlsCA, tlsCert, tlsKey           *string
+}
+
+func addClientFlags(fs *flag.FlagSet) *clientFlags {
+	return &clientFlags{
+		net:      fs.String("net", "unix", "network type of the server (http,tcp,unix)"),
+		host:     fs.String("host", "/tmp/ldbserver.sock", "network host of the server"),
+		form:     fs.String("form", "json", "format of marshaling ("+formats()+")"),
+		database: fs.String("database", "", "named database on the server"),
+		token:    fs.String("token", "", "api token"),
+		tlsCA:    fs.String("tls-ca", "", "CA file verifying the server certificate (enables TLS)"),
+		tlsCert:  fs.String("tls-cert", "", "client certificate file for mutual TLS"),
+		tlsKey:   fs.String("tls-key", "", "client key file for mutual TLS"),
+	}
+}
+
+func (f *clientFlags) dial() (*api.Client, error) {
+	codec := ldbserver.LookupCodec(*f.form)
+	if codec == nil {
+		return nil, errors.New("--form must be one of " + formats())
+	}
+	opts := []api.Option{api.WithCodec(codec)}
+	if len(*f.token) != 0 {
+		opts = append(opts, api.WithToken(*f.token))
+	}
+	if len(*f.database) != 0 {
+		opts = append(opts, api.WithDatabase(*f.database))
+	}
+	if len(*f.tlsCA) != 0 {
+		cfg := &tls.Config{}
+		var err error
+		if cfg.RootCAs, err = ldbserver.LoadCertPool(*f.tlsCA); err != nil {
+			return nil, err
+		}
+		if len(*f.tlsCert) != 0 {
+			cert, err := tls.LoadX509KeyPair(*f.tlsCert, *f.tlsKey)
+			if err != nil {
+				return nil, err
+			}
+			cfg.Certificates = []tls.Certificate{cert}
+		}
+		opts = append(opts, api.WithTLS(cfg))
+	}
+	return api.NewClient(*f.net, *f.host, ldbserver.MarshalingTypeJson, opts...)
+}
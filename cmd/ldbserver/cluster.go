@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// runCluster runs the cluster subcommand:
+//
+//	ldbserver cluster [flags] status
+//	ldbserver cluster [flags] join id raft-address
+//	ldbserver cluster [flags] leave id
+//
+// Membership changes sent to a follower are redirected to the leader.
+func runCluster(args []string) error {
+	fs := flag.NewFlagSet("cluster", flag.ExitOnError)
+	client := addClientFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: ldbserver cluster [flags] status|join id raft-address|leave id")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	nargs := map[string]int{"status": 1, "join": 3, "leave": 2}
+	if fs.NArg() == 0 || nargs[fs.Arg(0)] != fs.NArg() {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	cl, err := client.dial()
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	switch fs.Arg(0) {
+	case "join":
+		return cl.JoinCluster(fs.Arg(1), fs.Arg(2))
+	case "leave":
+		return cl.LeaveCluster(fs.Arg(1))
+	}
+	status, err := cl.ClusterStatus()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("node %s: %s, leader %s, applied index %d\n", status.GetNodeId(), status.GetState(), status.GetLeader(), status.GetAppliedIndex())
+	for _, n := range status.Nodes {
+		suffrage := "voter"
+		if !n.GetVoter() {
+			suffrage = "nonvoter"
+		}
+		fmt.Printf("%s\t%s\t%s\n", n.GetId(), n.GetAddress(), suffrage)
+	}
+	return nil
+}
@@ -2,18 +2,63 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
+	"strings"
 
+	"github.com/govlas/ldbserver"
 	"github.com/govlas/logger"
 )
 
-type Config struct {
-	Db     string
-	Host   string
+// ListenerConfig is one address of the server. An empty Format means the
+// Format of the Config.
+type ListenerConfig struct {
 	Net    string
+	Host   string
 	Format string
 }
 
+// DatabaseConfig is a named database served besides Db. Options left zero
+// are taken from the Config.
+type DatabaseConfig struct {
+	Path     string
+	ReadOnly bool
+	ldbserver.LevelDBOptions
+}
+
+type Config struct {
+	Db              string
+	Storage         string
+	Host            string
+	Net             string
+	Format          string
+	Listeners       []ListenerConfig
+	Databases       map[string]DatabaseConfig
+	DatabaseDir     string
+	BackupDir       string
+	Workers         int
+	TLSCert         string
+	TLSKey          string
+	TLSCA           string
+	ACL             string
+	Metrics         string
+	ShutdownTimeout int
+	ReplicationLog  int
+	Primary         string
+	PrimaryFormat   string
+	PrimaryToken    string
+	PrimaryTLSCA    string
+	ClusterID       string
+	RaftBind        string
+	RaftAdvertise   string
+	RaftDir         string
+	Bootstrap       bool
+	Join            string
+	JoinTLSCA       string
+	ClusterClearDb  bool
+	ldbserver.LevelDBOptions
+}
+
 func LoadConfig(fname string) (ret *Config) {
 	if file, err := os.Open(fname); !logger.ErrorErr(err) {
 		defer file.Close()
@@ -26,3 +71,47 @@ func LoadConfig(fname string) (ret *Config) {
 	}
 	return
 }
+
+// listenFlags collects repeated --listen flags of the form net,host[,format].
+type listenFlags []ListenerConfig
+
+func (f *listenFlags) String() string {
+	var s []string
+	for _, l := range *f {
+		s = append(s, strings.TrimSuffix(l.Net+","+l.Host+","+l.Format, ","))
+	}
+	return strings.Join(s, " ")
+}
+
+func (f *listenFlags) Set(value string) error {
+	parts := strings.Split(value, ",")
+	if len(parts) < 2 || len(parts) > 3 {
+		return errors.New("listener must be net,host[,format]")
+	}
+	l := ListenerConfig{Net: parts[0], Host: parts[1]}
+	if len(parts) == 3 {
+		l.Format = parts[2]
+	}
+	*f = append(*f, l)
+	return nil
+}
+
+// databaseFlags collects repeated --database flags of the form name=path.
+type databaseFlags map[string]DatabaseConfig
+
+func (f databaseFlags) String() string {
+	var s []string
+	for name, d := range f {
+		s = append(s, name+"="+d.Path)
+	}
+	return strings.Join(s, " ")
+}
+
+func (f databaseFlags) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 || len(kv[0]) == 0 || len(kv[1]) == 0 {
+		return errors.New("database must be name=path")
+	}
+	f[kv[0]] = DatabaseConfig{Path: kv[1]}
+	return nil
+}
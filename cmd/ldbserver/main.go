@@ -1,14 +1,20 @@
 package main
 
 import (
+	"crypto/tls"
 	"flag"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/govlas/ldbserver"
+	"github.com/govlas/ldbserver/api"
 	"github.com/govlas/logger"
 )
 
@@ -16,15 +22,66 @@ func main() {
 	logger.EnableColored()
 	logger.SetFileName(logger.FileNameShort)
 
+	if len(os.Args) > 1 && (os.Args[1] == "backup" || os.Args[1] == "restore") {
+		if err := runBackup(os.Args[1], os.Args[2:]); err != nil {
+			logger.FatalErr(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "upgrade" {
+		if err := runUpgrade(os.Args[2:]); err != nil {
+			logger.FatalErr(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "cluster" {
+		if err := runCluster(os.Args[2:]); err != nil {
+			logger.FatalErr(err)
+		}
+		return
+	}
+
 	var (
-		mf     ldbserver.MarshalingType
+		codec  ldbserver.Codec
 		config *Config
 	)
 	{
 		arg_db := flag.String("db", "", "path to database")
-		arg_net := flag.String("net", "unix", "network type (http,tcp,unix)")
+		arg_storage := flag.String("storage", "leveldb", "storage of the default database (leveldb,memory)")
+		arg_databases := make(databaseFlags)
+		flag.Var(arg_databases, "database", "named database name=path; may be repeated")
+		arg_db_dir := flag.String("db-dir", "", "directory of databases created by clients (creating is disabled if empty)")
+		arg_backup_dir := flag.String("backup-dir", "", "directory of backup files written and read by the server (disabled if empty)")
+		arg_block_cache := flag.Int("block-cache", 0, "block cache size in MiB (0 is the goleveldb default)")
+		arg_write_buffer := flag.Int("write-buffer", 0, "write buffer size in MiB (0 is the goleveldb default)")
+		arg_bloom_bits := flag.Int("bloom-bits", 0, "bits per key of bloom filters (0 disables them)")
+		arg_compression := flag.String("compression", "", "compression of tables (snappy,none)")
+		arg_max_open_files := flag.Int("max-open-files", 0, "tables kept open (0 is the goleveldb default)")
+		arg_net := flag.String("net", "unix", "network type (grpc,http,memcache,resp,tcp,unix)")
 		arg_host := flag.String("host", "/tmp/ldbserver.sock", "network host")
-		arg_form := flag.String("form", "json", "format of marshaling (json,protobuf)")
+		arg_form := flag.String("form", "json", "format of marshaling ("+formats()+")")
+		var arg_listen listenFlags
+		flag.Var(&arg_listen, "listen", "listener net,host[,form]; may be repeated, replaces --net and --host")
+		arg_workers := flag.Int("workers", ldbserver.DefaultConnWorkers, "requests served concurrently per connection")
+		arg_tls_cert := flag.String("tls-cert", "", "TLS certificate file (enables TLS)")
+		arg_tls_key := flag.String("tls-key", "", "TLS key file")
+		arg_tls_ca := flag.String("tls-ca", "", "CA file verifying client certificates (enables mutual TLS)")
+		arg_acl := flag.String("acl", "", "json file with access rules of api tokens")
+		arg_metrics := flag.String("metrics", "", "host of the http metrics endpoint (disabled if empty)")
+		arg_shutdown_timeout := flag.Int("shutdown-timeout", int(ldbserver.DefaultShutdownTimeout/time.Second), "seconds in-flight requests may take to finish on exit")
+		arg_replication_log := flag.Int("replication-log", 0, "log entries kept for replicas (0 disables replication from this server)")
+		arg_primary := flag.String("primary", "", "tcp host of the primary to replicate (makes the server a read-only replica)")
+		arg_primary_form := flag.String("primary-form", "json", "format of marshaling of the primary ("+formats()+")")
+		arg_primary_token := flag.String("primary-token", "", "api token sent to the primary")
+		arg_primary_tls_ca := flag.String("primary-tls-ca", "", "CA file verifying the primary certificate (enables TLS to the primary)")
+		arg_raft_bind := flag.String("raft-bind", "", "tcp address of the raft transport (enables cluster mode)")
+		arg_raft_advertise := flag.String("raft-advertise", "", "raft address other nodes reach this node at (default --raft-bind)")
+		arg_raft_dir := flag.String("raft-dir", "", "directory of the raft log and snapshots")
+		arg_cluster_id := flag.String("cluster-id", "", "node id, the address clients reach the node at (default the first tcp listener with a host address)")
+		arg_bootstrap := flag.Bool("bootstrap", false, "start a new cluster with this node as its only member")
+		arg_join := flag.String("join", "", "tcp host of a cluster member this node asks to join")
+		arg_join_tls_ca := flag.String("join-tls-ca", "", "CA file verifying the certificate of the --join member (enables TLS to it)")
+		arg_cluster_clear_db := flag.Bool("cluster-clear-db", false, "drop the keys of a non-empty --db on a node without raft state")
 		arg_usage := flag.Bool("usage", false, "print usage")
 		arg_config := flag.String("config", "", "json config (skips other flags)")
 
@@ -33,6 +90,9 @@ func main() {
 			flag.CommandLine.VisitAll(func(flag *flag.Flag) {
 				fmt.Fprintf(os.Stderr, "\t--%s: %s. Default: \"%s\"\n", flag.Name, flag.Usage, flag.DefValue)
 			})
+			fmt.Fprintln(os.Stderr, "ldbserver backup|restore [flags] file: back up or restore a database, see ldbserver backup -h")
+			fmt.Fprintln(os.Stderr, "ldbserver cluster [flags] status|join|leave: manage cluster members, see ldbserver cluster -h")
+			fmt.Fprintln(os.Stderr, "ldbserver upgrade path...: rewrite databases written by an older server, see ldbserver upgrade -h")
 
 		}
 
@@ -46,10 +106,42 @@ func main() {
 		if len(*arg_config) == 0 {
 
 			config = &Config{
-				Db:     *arg_db,
-				Host:   *arg_host,
-				Net:    *arg_net,
-				Format: *arg_form,
+				Db:              *arg_db,
+				Storage:         *arg_storage,
+				Host:            *arg_host,
+				Net:             *arg_net,
+				Format:          *arg_form,
+				Listeners:       arg_listen,
+				Databases:       arg_databases,
+				DatabaseDir:     *arg_db_dir,
+				BackupDir:       *arg_backup_dir,
+				Workers:         *arg_workers,
+				TLSCert:         *arg_tls_cert,
+				TLSKey:          *arg_tls_key,
+				TLSCA:           *arg_tls_ca,
+				ACL:             *arg_acl,
+				Metrics:         *arg_metrics,
+				ShutdownTimeout: *arg_shutdown_timeout,
+				ReplicationLog:  *arg_replication_log,
+				Primary:         *arg_primary,
+				PrimaryFormat:   *arg_primary_form,
+				PrimaryToken:    *arg_primary_token,
+				PrimaryTLSCA:    *arg_primary_tls_ca,
+				ClusterID:       *arg_cluster_id,
+				RaftBind:        *arg_raft_bind,
+				RaftAdvertise:   *arg_raft_advertise,
+				RaftDir:         *arg_raft_dir,
+				Bootstrap:       *arg_bootstrap,
+				Join:            *arg_join,
+				JoinTLSCA:       *arg_join_tls_ca,
+				ClusterClearDb:  *arg_cluster_clear_db,
+				LevelDBOptions: ldbserver.LevelDBOptions{
+					BlockCacheMB:    *arg_block_cache,
+					WriteBufferMB:   *arg_write_buffer,
+					BloomFilterBits: *arg_bloom_bits,
+					Compression:     *arg_compression,
+					MaxOpenFiles:    *arg_max_open_files,
+				},
 			}
 		} else {
 			config = LoadConfig(*arg_config)
@@ -61,47 +153,254 @@ func main() {
 		logger.Fatal("no config for run server")
 	}
 
-	if len(config.Db) == 0 {
-		logger.Fatal("--db must be a valid path")
+	switch config.Storage {
+	case "", "leveldb":
+		if len(config.Db) == 0 {
+			logger.Fatal("--db must be a valid path")
+		}
+	case "memory":
+	default:
+		logger.Fatal("--storage must be 'leveldb' or 'memory'")
+	}
+
+	if err := config.LevelDBOptions.Validate(); err != nil {
+		logger.Fatal("bad leveldb options: %v", err)
+	}
+	for name, d := range config.Databases {
+		if err := d.LevelDBOptions.Validate(); err != nil {
+			logger.Fatal("bad leveldb options of database %s: %v", name, err)
+		}
 	}
 
-	switch config.Format {
-	case "json":
-		mf = ldbserver.MarshalingTypeJson
-	case "protobuf":
-		mf = ldbserver.MarshalingTypeProtobuf
-	default:
-		logger.Fatal("--form must be 'json' or 'protobuf'")
+	if codec = ldbserver.LookupCodec(config.Format); codec == nil {
+		logger.Fatal("--form must be one of %s", formats())
+	}
+
+	listeners := config.Listeners
+	if len(listeners) == 0 {
+		listeners = []ListenerConfig{{Net: config.Net, Host: config.Host}}
+	}
+	specs := make([]ldbserver.Listener, 0, len(listeners))
+	for _, l := range listeners {
+		spec := ldbserver.Listener{Net: l.Net, Host: l.Host}
+		if len(l.Format) != 0 && (l.Net == "resp" || l.Net == "memcache") {
+			logger.Fatal("listener %s,%s speaks a foreign protocol and takes no format", l.Net, l.Host)
+		} else if len(l.Format) != 0 && l.Net == "grpc" {
+			logger.Fatal("listener %s,%s speaks gRPC and takes no format", l.Net, l.Host)
+		} else if len(l.Format) != 0 {
+			lc := ldbserver.LookupCodec(l.Format)
+			if lc == nil {
+				logger.Fatal("format of listener %s,%s must be one of %s", l.Net, l.Host, formats())
+			}
+			spec.Transport = ldbserver.CodecTransportFactory{Codec: lc}
+		}
+		specs = append(specs, spec)
+	}
+
+	var tc *tls.Config
+	if len(config.TLSCert) != 0 {
+		var err error
+		if tc, err = ldbserver.NewServerTLSConfig(config.TLSCert, config.TLSKey, config.TLSCA); err != nil {
+			logger.FatalErr(err)
+		}
+	} else if len(config.TLSCA) != 0 {
+		logger.Fatal("--tls-ca requires --tls-cert and --tls-key")
+	}
+
+	var replica *ldbserver.Replica
+	if len(config.Primary) != 0 {
+		if config.ReplicationLog > 0 {
+			logger.Fatal("--primary and --replication-log exclude each other")
+		}
+		replica = ldbserver.NewReplica("tcp", config.Primary, ldbserver.MarshalingTypeJson)
+		if len(config.PrimaryFormat) != 0 {
+			pc := ldbserver.LookupCodec(config.PrimaryFormat)
+			if pc == nil {
+				logger.Fatal("--primary-form must be one of %s", formats())
+			}
+			replica.SetCodec(pc)
+		}
+		replica.SetToken(config.PrimaryToken)
+		if len(config.PrimaryTLSCA) != 0 {
+			cfg := &tls.Config{}
+			var err error
+			if cfg.RootCAs, err = ldbserver.LoadCertPool(config.PrimaryTLSCA); err != nil {
+				logger.FatalErr(err)
+			}
+			if tc != nil {
+				// the server certificate authenticates the replica
+				cfg.Certificates = tc.Certificates
+			}
+			replica.SetTLSConfig(cfg)
+		}
+	}
+
+	var cluster *ldbserver.ClusterConfig
+	if len(config.RaftBind) != 0 {
+		if len(config.Primary) != 0 || config.ReplicationLog > 0 {
+			logger.Fatal("cluster mode excludes --primary and --replication-log")
+		}
+		cluster = &ldbserver.ClusterConfig{
+			NodeID:        config.ClusterID,
+			Bind:          config.RaftBind,
+			Advertise:     config.RaftAdvertise,
+			Dir:           config.RaftDir,
+			Bootstrap:     config.Bootstrap,
+			ClearDatabase: config.ClusterClearDb,
+		}
+		// clients on other hosts are redirected to the id of the leader
+		for _, l := range specs {
+			if len(cluster.NodeID) == 0 && l.Net == "tcp" && hostAddress(l.Host) {
+				cluster.NodeID = l.Host
+			}
+		}
+		if len(cluster.NodeID) == 0 {
+			logger.Fatal("cluster mode requires --cluster-id, the address clients reach the node at, or a tcp listener on such an address")
+		}
+		if len(cluster.Dir) == 0 {
+			logger.Fatal("cluster mode requires --raft-dir")
+		}
+	}
+
+	var acl *ldbserver.ACL
+	if len(config.ACL) != 0 {
+		var err error
+		if acl, err = ldbserver.LoadACL(config.ACL); err != nil {
+			logger.FatalErr(err)
+		}
 	}
 
 	logger.Info("---START---")
 
-	db, err := ldbserver.NewLevelDbServer(config.Db)
-	if err != nil {
+	var (
+		st  ldbserver.Storage
+		err error
+	)
+	if config.Storage == "memory" {
+		st = ldbserver.NewMemStorage()
+	} else if st, err = ldbserver.OpenLevelDBStorage(config.Db, config.LevelDBOptions.Options()); err != nil {
 		logger.FatalErr(err)
 	}
+	db := ldbserver.NewServer(st)
 	defer db.Close()
-	ns := ldbserver.NewNetworkServer(config.Net, config.Host)
+	db.SetACL(acl)
+	for name, d := range config.Databases {
+		o := d.LevelDBOptions.Merge(config.LevelDBOptions).Options()
+		o.ReadOnly = d.ReadOnly
+		if err := db.AddDatabase(name, d.Path, o); err != nil {
+			logger.Fatal("database %s: %v", name, err)
+		}
+	}
+	if len(config.DatabaseDir) != 0 {
+		if err := os.MkdirAll(config.DatabaseDir, 0755); err != nil {
+			logger.FatalErr(err)
+		}
+		db.SetDatabaseDir(config.DatabaseDir, config.LevelDBOptions.Options())
+	}
+	db.SetBackupDir(config.BackupDir)
+	if config.ReplicationLog > 0 {
+		db.EnableReplicationLog(config.ReplicationLog)
+	}
+	if replica != nil {
+		db.ReplicateFrom(replica)
+	}
+	var node *ldbserver.Cluster
+	if cluster != nil {
+		if node, err = db.EnableCluster(*cluster); err != nil {
+			logger.FatalErr(err)
+		}
+	}
+	ns := ldbserver.NewMultiNetworkServer(specs...)
+	if config.Workers > 0 {
+		ns.SetConnWorkers(config.Workers)
+	}
+	ns.SetTLSConfig(tc)
+	if config.ShutdownTimeout > 0 {
+		ns.SetShutdownTimeout(time.Duration(config.ShutdownTimeout) * time.Second)
+	}
+
+	if len(config.Metrics) != 0 {
+		m := ldbserver.NewMetrics()
+		db.SetMetrics(m)
+		ns.SetMetrics(m)
+		go func() {
+			logger.WarningErr(http.ListenAndServe(config.Metrics, m))
+		}()
+	}
 
-	if config.Net == "unix" {
-		defer os.Remove(config.Host)
+	for _, l := range specs {
+		if l.Net == "unix" {
+			defer os.Remove(l.Host)
+		}
 	}
 
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		err := ns.ListenAndServe(db, ldbserver.JsonProtobufTransportFactory{mf})
+		err := ns.ListenAndServe(db, ldbserver.CodecTransportFactory{Codec: codec})
 		if err != nil && err != ldbserver.ErrStopped {
 			logger.WarningErr(err)
 		}
 	}()
 
+	if node != nil && len(config.Join) != 0 {
+		var jc *tls.Config
+		if len(config.JoinTLSCA) != 0 || tc != nil {
+			jc = &tls.Config{}
+			if len(config.JoinTLSCA) != 0 {
+				if jc.RootCAs, err = ldbserver.LoadCertPool(config.JoinTLSCA); err != nil {
+					logger.FatalErr(err)
+				}
+			}
+			if tc != nil {
+				// the server certificate authenticates the node
+				jc.Certificates = tc.Certificates
+			}
+		}
+		go joinCluster(config.Join, codec, jc, node)
+	}
+
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, os.Kill, syscall.SIGTERM)
 
 	<-c
+	// the database is closed only after the in-flight requests are drained
 	ns.Stop()
 	wg.Wait()
 	logger.Info("normal exit")
 }
+
+// joinCluster asks the member at host to add node until it succeeds. A nil
+// tc connects without TLS.
+func joinCluster(host string, codec ldbserver.Codec, tc *tls.Config, node *ldbserver.Cluster) {
+	opts := []api.Option{api.WithCodec(codec)}
+	if tc != nil {
+		opts = append(opts, api.WithTLS(tc))
+	}
+	for {
+		cl, err := api.NewClient("tcp", host, ldbserver.MarshalingTypeJson, opts...)
+		if err == nil {
+			err = cl.JoinCluster(node.ID(), node.RaftAddress())
+			cl.Close()
+		}
+		if err == nil {
+			logger.Info("joined the cluster of %s", host)
+			return
+		}
+		logger.Warning("joining the cluster of %s: %v", host, err)
+		time.Sleep(time.Second)
+	}
+}
+
+// hostAddress reports whether the listener host names an address of this
+// host, which other hosts can reach, rather than all its interfaces.
+func hostAddress(host string) bool {
+	h, _, err := net.SplitHostPort(host)
+	return err == nil && len(h) != 0 && !net.ParseIP(h).IsUnspecified()
+}
+
+// formats lists the names of the registered codecs.
+func formats() string {
+	return strings.Join(ldbserver.CodecNames(), ",")
+}
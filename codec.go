@@ -0,0 +1,178 @@
+package ldbserver
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+	pio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// maxProtobufMessageSize bounds the protobuf messages read by the protobuf
+// codec.
+const maxProtobufMessageSize = 1024 * 1024
+
+// Codec is a marshaling format of the messages exchanged by servers and
+// clients. Encoders and decoders of a codec handle *TransportRequest and
+// *TransportResponse values, one message after another on a stream.
+type Codec interface {
+	NewEncoder(w io.Writer) Encoder
+	NewDecoder(r io.Reader) Decoder
+	// ContentType is the media type of http requests in the format.
+	ContentType() string
+}
+
+// Encoder writes consecutive messages. It is not safe for concurrent use.
+type Encoder interface {
+	Encode(v interface{}) error
+}
+
+// Decoder reads consecutive messages. It may read ahead of the message it
+// returns, so a stream is read by one decoder only.
+type Decoder interface {
+	Decode(v interface{}) error
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = make(map[string]Codec)
+)
+
+// marshalingTypeNames are the codec names of the marshaling types.
+var marshalingTypeNames = map[MarshalingType]string{
+	MarshalingTypeJson:     "json",
+	MarshalingTypeProtobuf: "protobuf",
+	MarshalingTypeMsgpack:  "msgpack",
+	MarshalingTypeCBOR:     "cbor",
+}
+
+func init() {
+	RegisterCodec("json", jsonCodec{})
+	RegisterCodec("protobuf", protobufCodec{maxSize: maxProtobufMessageSize})
+	RegisterCodec("msgpack", msgpackCodec{})
+	RegisterCodec("cbor", cborCodec{})
+}
+
+// RegisterCodec makes c available under name, for instance to the --form
+// flag of ldbserver. It panics if a codec of that name is registered already.
+func RegisterCodec(name string, c Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	if c == nil {
+		panic("ldbserver: RegisterCodec of nil codec " + name)
+	}
+	if _, dup := codecs[name]; dup {
+		panic("ldbserver: RegisterCodec called twice for " + name)
+	}
+	codecs[name] = c
+}
+
+// LookupCodec returns the codec registered under name, or nil.
+func LookupCodec(name string) Codec {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	return codecs[name]
+}
+
+// CodecNames returns the sorted names of the registered codecs.
+func CodecNames() []string {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	names := make([]string, 0, len(codecs))
+	for name := range codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String returns the codec name of mt.
+func (mt MarshalingType) String() string {
+	if name, ok := marshalingTypeNames[mt]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Codec returns the codec of mt, or nil if mt is unknown.
+func (mt MarshalingType) Codec() Codec {
+	if name, ok := marshalingTypeNames[mt]; ok {
+		return LookupCodec(name)
+	}
+	return nil
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) NewEncoder(w io.Writer) Encoder { return json.NewEncoder(w) }
+func (jsonCodec) NewDecoder(r io.Reader) Decoder { return json.NewDecoder(r) }
+func (jsonCodec) ContentType() string            { return "application/json" }
+
+// protobufCodec frames messages by their size as a little-endian uint32.
+// Decoders reject messages larger than maxSize.
+type protobufCodec struct {
+	maxSize int
+}
+
+func (protobufCodec) NewEncoder(w io.Writer) Encoder {
+	return protobufEncoder{pio.NewUint32DelimitedWriter(w, binary.LittleEndian)}
+}
+
+func (c protobufCodec) NewDecoder(r io.Reader) Decoder {
+	return protobufDecoder{pio.NewUint32DelimitedReader(r, binary.LittleEndian, c.maxSize)}
+}
+
+func (protobufCodec) ContentType() string { return "application/octet-stream" }
+
+var errNotProtobuf = errors.New("protobuf codec: value is not a protobuf message")
+
+type protobufEncoder struct{ w pio.Writer }
+
+func (e protobufEncoder) Encode(v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return errNotProtobuf
+	}
+	return e.w.WriteMsg(msg)
+}
+
+type protobufDecoder struct{ r pio.Reader }
+
+func (d protobufDecoder) Decode(v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return errNotProtobuf
+	}
+	return d.r.ReadMsg(msg)
+}
+
+// msgpackCodec names the fields by their json tags.
+type msgpackCodec struct{}
+
+func (msgpackCodec) NewEncoder(w io.Writer) Encoder {
+	enc := msgpack.NewEncoder(w)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	return enc
+}
+
+func (msgpackCodec) NewDecoder(r io.Reader) Decoder {
+	dec := msgpack.NewDecoder(r)
+	dec.SetCustomStructTag("json")
+	return dec
+}
+
+func (msgpackCodec) ContentType() string { return "application/msgpack" }
+
+// cborCodec names the fields by their json tags, which cbor falls back to.
+type cborCodec struct{}
+
+func (cborCodec) NewEncoder(w io.Writer) Encoder { return cbor.NewEncoder(w) }
+func (cborCodec) NewDecoder(r io.Reader) Decoder { return cbor.NewDecoder(r) }
+func (cborCodec) ContentType() string            { return "application/cbor" }
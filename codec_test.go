@@ -0,0 +1,91 @@
+package ldbserver
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lineCodec is a codec registered by the test: json messages, each on a
+// line starting with '#'.
+type lineCodec struct{}
+
+func (lineCodec) NewEncoder(w io.Writer) Encoder { return lineEncoder{w} }
+func (lineCodec) NewDecoder(r io.Reader) Decoder { return jsonCodec{}.NewDecoder(&lineReader{r: r}) }
+func (lineCodec) ContentType() string            { return "application/x-lines" }
+
+type lineEncoder struct{ w io.Writer }
+
+func (e lineEncoder) Encode(v interface{}) error {
+	if _, err := io.WriteString(e.w, "#"); err != nil {
+		return err
+	}
+	return jsonCodec{}.NewEncoder(e.w).Encode(v)
+}
+
+// lineReader drops the '#' starting every line.
+type lineReader struct {
+	r    io.Reader
+	line bool
+}
+
+func (lr *lineReader) Read(p []byte) (int, error) {
+	n, err := lr.r.Read(p)
+	out := p[:0]
+	for _, c := range p[:n] {
+		if c == '#' && !lr.line {
+			lr.line = true
+			continue
+		}
+		if c == '\n' {
+			lr.line = false
+		}
+		out = append(out, c)
+	}
+	return len(out), err
+}
+
+func TestCodecRegistry(t *testing.T) {
+	for _, mt := range []MarshalingType{MarshalingTypeJson, MarshalingTypeProtobuf, MarshalingTypeMsgpack, MarshalingTypeCBOR} {
+		assert.Equal(t, mt.Codec(), LookupCodec(mt.String()), "codec of "+mt.String())
+	}
+	assert.Nil(t, MarshalingType(100).Codec(), "unknown marshaling type")
+	assert.Nil(t, LookupCodec("nope"), "unregistered codec")
+
+	// the registry outlives repeated runs of the test
+	if LookupCodec("lines") == nil {
+		RegisterCodec("lines", lineCodec{})
+	}
+	assert.Equal(t, CodecNames(), []string{"cbor", "json", "lines", "msgpack", "protobuf"}, "CodecNames")
+	assert.Panics(t, func() { RegisterCodec("lines", lineCodec{}) }, "duplicate codec")
+	assert.Panics(t, func() { RegisterCodec("nil", nil) }, "nil codec")
+
+	db := NewServer(NewMemStorage())
+	defer db.Close()
+
+	var (
+		in    = bytes.NewBuffer(nil)
+		out   = bytes.NewBuffer(nil)
+		codec = LookupCodec("lines")
+		tr    = CodecTransportFactory{codec}.NewTransporter(in, out)
+	)
+	put := &TransportRequest{Command: TransportRequest_PUT.Enum(), Key: []byte("k"), Body: &TransportBody{Data: []byte("v")}}
+	SetBodyChecksum(put.Body)
+	enc := codec.NewEncoder(in)
+	assert.NoError(t, enc.Encode(put), "Encode")
+	assert.NoError(t, enc.Encode(&TransportRequest{Command: TransportRequest_GET.Enum(), Key: []byte("k")}), "Encode")
+	assert.NoError(t, db.serve(tr), "PUT")
+	assert.NoError(t, db.serve(tr), "GET")
+
+	assert.Equal(t, out.Bytes()[0], byte('#'), "encoded by the codec")
+	dec := codec.NewDecoder(out)
+	for _, want := range []string{"", "v"} {
+		resp := &TransportResponse{}
+		if assert.NoError(t, dec.Decode(resp), "Decode") {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "status")
+			assert.Equal(t, string(resp.GetBody().GetData()), want, "value")
+		}
+	}
+}
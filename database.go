@@ -0,0 +1,212 @@
+package ldbserver
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+var (
+	errUnknownDatabase    = errors.New("unknown database")
+	errDatabaseExists     = errors.New("database exists")
+	errBadDatabaseName    = errors.New("bad database name")
+	errNoDatabaseDir      = errors.New("creating databases is disabled")
+	errConfiguredDatabase = errors.New("configured databases can not be dropped")
+)
+
+// database is one storage served by a leveldbServer. The default database
+// has an empty name. Named databases are leveldbs opened by the first request
+// naming them.
+type database struct {
+	name       string
+	path       string
+	options    *opt.Options
+	configured bool
+
+	// mu is held for reading by the requests using st and for writing while
+	// st is opened or dropped
+	mu      sync.RWMutex
+	st      Storage
+	dropped bool
+	locks   keyLocks
+}
+
+// validDatabaseName reports whether name can be used as a directory name.
+func validDatabaseName(name string) bool {
+	if len(name) == 0 || len(name) > 255 || name == "." || name == ".." {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (d *database) open() (err error) {
+	d.st, err = OpenLevelDBStorage(d.path, d.options)
+	return
+}
+
+// acquire opens the database if needed and keeps it from being dropped or
+// closed until release is called.
+func (d *database) acquire() error {
+	for {
+		d.mu.RLock()
+		if d.st != nil {
+			return nil
+		}
+		d.mu.RUnlock()
+
+		d.mu.Lock()
+		if d.dropped {
+			d.mu.Unlock()
+			return errUnknownDatabase
+		}
+		if d.st == nil {
+			if err := d.open(); err != nil {
+				d.mu.Unlock()
+				return err
+			}
+		}
+		d.mu.Unlock()
+	}
+}
+
+func (d *database) release() {
+	d.mu.RUnlock()
+}
+
+// lock opens the database if needed and keeps every other request out of it
+// until unlock is called.
+func (d *database) lock() error {
+	d.mu.Lock()
+	if d.dropped {
+		d.mu.Unlock()
+		return errUnknownDatabase
+	}
+	if d.st == nil {
+		if err := d.open(); err != nil {
+			d.mu.Unlock()
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *database) unlock() {
+	d.mu.Unlock()
+}
+
+func (d *database) close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.st != nil {
+		d.st.Close()
+		d.st = nil
+	}
+	d.dropped = true
+}
+
+// drop closes the database once its requests are done and removes its files.
+func (d *database) drop() error {
+	d.close()
+	if len(d.path) == 0 {
+		return nil
+	}
+	return os.RemoveAll(d.path)
+}
+
+// database returns the database named name.
+func (s *leveldbServer) database(name string) (*database, error) {
+	if len(name) == 0 {
+		return s.db, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d, ok := s.databases[name]; ok {
+		return d, nil
+	}
+	if len(s.dbDir) == 0 || !validDatabaseName(name) {
+		return nil, errUnknownDatabase
+	}
+	// databases created before a restart are found on disk
+	path := filepath.Join(s.dbDir, name)
+	if fi, err := os.Stat(path); err != nil || !fi.IsDir() {
+		return nil, errUnknownDatabase
+	}
+	d := &database{name: name, path: path, options: s.dbOptions}
+	s.databases[name] = d
+	return d, nil
+}
+
+func (s *leveldbServer) createDatabase(name string) error {
+	if !validDatabaseName(name) {
+		return errBadDatabaseName
+	}
+	if len(s.dbDir) == 0 {
+		return errNoDatabaseDir
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	path := filepath.Join(s.dbDir, name)
+	if _, ok := s.databases[name]; ok {
+		return errDatabaseExists
+	}
+	if _, err := os.Stat(path); err == nil {
+		return errDatabaseExists
+	}
+	d := &database{name: name, path: path, options: s.dbOptions}
+	if err := d.open(); err != nil {
+		return err
+	}
+	s.databases[name] = d
+	return nil
+}
+
+func (s *leveldbServer) dropDatabase(name string) error {
+	d, err := s.database(name)
+	if err != nil {
+		return err
+	}
+	if d == s.db || d.configured {
+		return errConfiguredDatabase
+	}
+	// d stays registered while it is dropped, so that it is not found on
+	// disk again
+	err = d.drop()
+	s.mu.Lock()
+	if s.databases[name] == d {
+		delete(s.databases, name)
+	}
+	s.mu.Unlock()
+	return err
+}
+
+// admin creates or drops the database named by req.
+func (s *leveldbServer) admin(req *TransportRequest) *TransportResponse {
+	var err error
+	if req.GetCommand() == TransportRequest_DB_CREATE {
+		err = s.createDatabase(req.GetDatabase())
+	} else {
+		err = s.dropDatabase(req.GetDatabase())
+	}
+	switch err {
+	case nil:
+		return &TransportResponse{Status: TransportResponse_OK.Enum()}
+	case errUnknownDatabase:
+		return MakeErrorResponse(TransportResponse_NOT_FOUND, err)
+	case errDatabaseExists:
+		return MakeErrorResponse(TransportResponse_CONDITION_FAILED, err)
+	case errBadDatabaseName, errNoDatabaseDir, errConfiguredDatabase:
+		return MakeErrorResponse(TransportResponse_BAD_REQUEST, err)
+	default:
+		return MakeErrorResponse(TransportResponse_INTERNAL, err)
+	}
+}
@@ -0,0 +1,96 @@
+package ldbserver
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+func TestLevelDBDatabases(t *testing.T) {
+
+	tempdir := os.TempDir()
+	path := filepath.Join(tempdir, fmt.Sprintf("goleveldb-test-databases%d0%d", os.Getuid(), os.Getpid()))
+	db, err := NewLevelDbServer(filepath.Join(path, "default"))
+	if assert.NoError(t, err, "NewLevelDbServer") {
+		defer func() {
+			db.Close()
+			os.RemoveAll(path)
+		}()
+
+		status := func(database string, cmd TransportRequest_Command, key, value string) *TransportResponse {
+			req := &TransportRequest{Command: cmd.Enum(), Database: proto.String(database)}
+			if len(key) != 0 {
+				req.Key = []byte(key)
+			}
+			if len(value) != 0 {
+				req.Body = &TransportBody{Data: []byte(value)}
+				SetBodyChecksum(req.Body)
+			}
+			return handleRequest(t, db, nil, req)
+		}
+
+		assert.Equal(t, status("users", TransportRequest_DB_CREATE, "", "").GetStatus(), TransportResponse_BAD_REQUEST, "Create without database dir")
+		db.SetDatabaseDir(filepath.Join(path, "created"), nil)
+		assert.NoError(t, os.MkdirAll(filepath.Join(path, "created"), 0755), "MkdirAll")
+
+		assert.Equal(t, status("users", TransportRequest_GET, "k", "").GetStatus(), TransportResponse_NOT_FOUND, "Unknown database")
+		assert.Equal(t, status("../users", TransportRequest_DB_CREATE, "", "").GetStatus(), TransportResponse_BAD_REQUEST, "Bad name")
+		assert.Equal(t, status("users", TransportRequest_DB_CREATE, "", "").GetStatus(), TransportResponse_OK, "Create")
+		assert.Equal(t, status("users", TransportRequest_DB_CREATE, "", "").GetStatus(), TransportResponse_CONDITION_FAILED, "Create twice")
+
+		assert.Equal(t, status("users", TransportRequest_PUT, "k", "users").GetStatus(), TransportResponse_OK, "Put")
+		assert.Equal(t, status("", TransportRequest_PUT, "k", "default").GetStatus(), TransportResponse_OK, "Put default")
+		if resp := status("users", TransportRequest_GET, "k", ""); assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Get") {
+			assert.Equal(t, resp.Body.Data, []byte("users"), "Get")
+		}
+		if resp := status("", TransportRequest_GET, "k", ""); assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Get default") {
+			assert.Equal(t, resp.Body.Data, []byte("default"), "Get default")
+		}
+
+		// snapshots belong to the database they were opened on
+		sess := newSession()
+		defer sess.close()
+		resp := handleRequest(t, db, sess, &TransportRequest{Command: TransportRequest_SNAPSHOT_OPEN.Enum(), Database: proto.String("users")})
+		if assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Open snapshot") {
+			get := &TransportRequest{Command: TransportRequest_GET.Enum(), Key: []byte("k"), Snapshot: resp.Snapshot}
+			assert.Equal(t, handleRequest(t, db, sess, get).GetStatus(), TransportResponse_BAD_REQUEST, "Snapshot of another database")
+			get.Database = proto.String("users")
+			assert.Equal(t, handleRequest(t, db, sess, get).GetStatus(), TransportResponse_OK, "Snapshot get")
+		}
+
+		// created databases are found again after a restart
+		db.Close()
+		db, err = NewLevelDbServer(filepath.Join(path, "default"))
+		if !assert.NoError(t, err, "NewLevelDbServer") {
+			return
+		}
+		db.SetDatabaseDir(filepath.Join(path, "created"), nil)
+		if resp := status("users", TransportRequest_GET, "k", ""); assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Get after restart") {
+			assert.Equal(t, resp.Body.Data, []byte("users"), "Get after restart")
+		}
+
+		assert.Equal(t, status("", TransportRequest_DB_DROP, "", "").GetStatus(), TransportResponse_BAD_REQUEST, "Drop default")
+		assert.Equal(t, status("users", TransportRequest_DB_DROP, "", "").GetStatus(), TransportResponse_OK, "Drop")
+		assert.Equal(t, status("users", TransportRequest_GET, "k", "").GetStatus(), TransportResponse_NOT_FOUND, "Get dropped")
+		assert.Equal(t, status("users", TransportRequest_DB_DROP, "", "").GetStatus(), TransportResponse_NOT_FOUND, "Drop twice")
+		_, err = os.Stat(filepath.Join(path, "created", "users"))
+		assert.True(t, os.IsNotExist(err), "Dropped files")
+
+		if archive, err := OpenLevelDBStorage(filepath.Join(path, "archive"), nil); assert.NoError(t, err, "OpenLevelDBStorage") {
+			archive.Batch(writeOps(nil, []byte("k"), []byte("archive"), false, meta{}, meta{}), false)
+			archive.Close()
+		}
+		assert.NoError(t, db.AddDatabase("archive", filepath.Join(path, "archive"), &opt.Options{ReadOnly: true}), "AddDatabase")
+		assert.Error(t, db.AddDatabase("archive", filepath.Join(path, "archive"), nil), "AddDatabase twice")
+		assert.Equal(t, status("archive", TransportRequest_GET, "k", "").GetStatus(), TransportResponse_OK, "Get read-only")
+		assert.Equal(t, status("archive", TransportRequest_PUT, "k", "v").GetStatus(), TransportResponse_DENIED, "Put read-only")
+		assert.NoError(t, db.AddDatabase("logs", filepath.Join(path, "logs"), nil), "AddDatabase")
+		assert.Equal(t, status("logs", TransportRequest_PUT, "k", "logs").GetStatus(), TransportResponse_OK, "Put configured")
+		assert.Equal(t, status("logs", TransportRequest_DB_DROP, "", "").GetStatus(), TransportResponse_BAD_REQUEST, "Drop configured")
+	}
+}
@@ -0,0 +1,313 @@
+package ldbserver
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// loadBatchSize is the number of operations Load writes in one batch.
+const loadBatchSize = 1000
+
+// GrpcCodec marshals the messages of the Ldb service with gogo protobuf.
+// Servers returned by NewGrpcServer use it, and clients should too:
+//
+//	grpc.WithDefaultCallOptions(grpc.ForceCodec(ldbserver.GrpcCodec{}))
+type GrpcCodec struct{}
+
+func (GrpcCodec) Marshal(v interface{}) ([]byte, error) {
+	return proto.Marshal(v.(proto.Message))
+}
+
+func (GrpcCodec) Unmarshal(data []byte, v interface{}) error {
+	return proto.Unmarshal(data, v.(proto.Message))
+}
+
+func (GrpcCodec) Name() string {
+	return "proto"
+}
+
+// NewGrpcServer returns a gRPC server of the Ldb service answering requests
+// with db. opts add interceptors, credentials and other options to the
+// server.
+func NewGrpcServer(db DBServer, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.ForceServerCodec(GrpcCodec{}),
+		grpc.StatsHandler(grpcSessions{}),
+	}, opts...)
+	s := grpc.NewServer(opts...)
+	RegisterLdbServer(s, &grpcService{db: db})
+	return s
+}
+
+// grpcService implements LdbServer with the handler of a DBServer.
+type grpcService struct {
+	db DBServer
+}
+
+func (g *grpcService) Get(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_GET, req)
+}
+
+func (g *grpcService) Put(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_PUT, req)
+}
+
+func (g *grpcService) Delete(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_DELETE, req)
+}
+
+func (g *grpcService) Batch(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_BATCH, req)
+}
+
+func (g *grpcService) Cas(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_CAS, req)
+}
+
+func (g *grpcService) PutIfAbsent(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_PUT_IF_ABSENT, req)
+}
+
+func (g *grpcService) DeleteIfEqual(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_DELETE_IF_EQUAL, req)
+}
+
+func (g *grpcService) Replace(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_REPLACE, req)
+}
+
+func (g *grpcService) Ttl(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_TTL, req)
+}
+
+func (g *grpcService) Persist(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_PERSIST, req)
+}
+
+func (g *grpcService) SnapshotOpen(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_SNAPSHOT_OPEN, req)
+}
+
+func (g *grpcService) SnapshotRelease(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_SNAPSHOT_RELEASE, req)
+}
+
+func (g *grpcService) Scan(req *TransportRequest, stream Ldb_ScanServer) error {
+	return g.stream(TransportRequest_SCAN, req, stream)
+}
+
+func (g *grpcService) Backup(req *TransportRequest, stream Ldb_BackupServer) error {
+	return g.stream(TransportRequest_BACKUP, req, stream)
+}
+
+func (g *grpcService) Restore(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_RESTORE, req)
+}
+
+// Load writes the received operations in batches of loadBatchSize. A failed
+// batch ends the load; the batches before it stay written.
+func (g *grpcService) Load(stream Ldb_LoadServer) error {
+	var ops []*TransportOperation
+	flush := func() error {
+		if len(ops) == 0 {
+			return nil
+		}
+		_, err := g.unary(stream.Context(), TransportRequest_BATCH, &TransportRequest{Batch: ops})
+		ops = nil
+		return err
+	}
+	for {
+		op, err := stream.Recv()
+		if err == io.EOF {
+			if err := flush(); err != nil {
+				return err
+			}
+			return stream.SendAndClose(&TransportResponse{Status: TransportResponse_OK.Enum()})
+		}
+		if err != nil {
+			return err
+		}
+		if ops = append(ops, op); len(ops) == loadBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (g *grpcService) CreateDatabase(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_DB_CREATE, req)
+}
+
+func (g *grpcService) DropDatabase(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_DB_DROP, req)
+}
+
+func (g *grpcService) ReplicationStatus(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_REPL_STATUS, req)
+}
+
+func (g *grpcService) ClusterJoin(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_CLUSTER_JOIN, req)
+}
+
+func (g *grpcService) ClusterLeave(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_CLUSTER_LEAVE, req)
+}
+
+func (g *grpcService) ClusterStatus(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return g.unary(ctx, TransportRequest_CLUSTER_STATUS, req)
+}
+
+// unary answers a request of cmd with a single response.
+func (g *grpcService) unary(ctx context.Context, cmd TransportRequest_Command, req *TransportRequest) (*TransportResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	if err := grpcRequest(ctx, cmd, req); err != nil {
+		return nil, err
+	}
+	rr := new(responseRecorder)
+	if err := g.db.handle(grpcSession(ctx), rr, req); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := grpcError(rr.resp); err != nil {
+		if md := leaderTrailer(rr.resp); md != nil {
+			grpc.SetTrailer(ctx, md)
+		}
+		return nil, err
+	}
+	setResponseChecksums(rr.resp)
+	return rr.resp, nil
+}
+
+// stream answers a request of cmd with the responses streamed by the
+// server. A failed response ends the stream with its error.
+func (g *grpcService) stream(cmd TransportRequest_Command, req *TransportRequest, stream grpc.ServerStream) error {
+	if err := grpcRequest(stream.Context(), cmd, req); err != nil {
+		return err
+	}
+	tr := &grpcTransporter{stream: stream}
+	if err := g.db.handle(grpcSession(stream.Context()), tr, req); err != nil {
+		return err
+	}
+	return tr.err
+}
+
+// grpcTransporter sends the responses to a request to a server stream.
+type grpcTransporter struct {
+	stream grpc.ServerStream
+	err    error
+}
+
+func (t *grpcTransporter) GetRequest() (*TransportRequest, error) {
+	return nil, errors.New("grpcTransporter: no request to read")
+}
+
+func (t *grpcTransporter) SendResponse(resp *TransportResponse) error {
+	if t.err = grpcError(resp); t.err != nil {
+		if md := leaderTrailer(resp); md != nil {
+			t.stream.SetTrailer(md)
+		}
+		return nil
+	}
+	setResponseChecksums(resp)
+	return t.stream.SendMsg(resp)
+}
+
+// grpcRequest sets the command of req and the token and the database sent
+// as metadata if the request has none.
+func grpcRequest(ctx context.Context, cmd TransportRequest_Command, req *TransportRequest) error {
+	req.Command = cmd.Enum()
+	if !CheckBody(req.Body) {
+		return status.Error(codes.DataLoss, ErrChecksumMismatch.Error())
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if auth := md.Get("authorization"); req.Token == nil && len(auth) != 0 {
+		req.Token = parseBearer(auth[0])
+	}
+	if db := md.Get("database"); req.Database == nil && len(db) != 0 {
+		req.Database = proto.String(db[0])
+	}
+	return nil
+}
+
+// grpcError returns the gRPC error of a failed response, or nil.
+func grpcError(resp *TransportResponse) error {
+	code := grpcCode(resp.GetStatus())
+	if code == codes.OK {
+		return nil
+	}
+	return status.Error(code, string(resp.GetBody().GetData()))
+}
+
+func grpcCode(st TransportResponse_Status) codes.Code {
+	switch st {
+	case TransportResponse_OK:
+		return codes.OK
+	case TransportResponse_NOT_FOUND:
+		return codes.NotFound
+	case TransportResponse_BAD_REQUEST:
+		return codes.InvalidArgument
+	case TransportResponse_CHECKSUM_MISMATCH:
+		return codes.DataLoss
+	case TransportResponse_CONDITION_FAILED, TransportResponse_READ_ONLY:
+		return codes.FailedPrecondition
+	case TransportResponse_DENIED:
+		return codes.PermissionDenied
+	case TransportResponse_NOT_LEADER:
+		return codes.Unavailable
+	case TransportResponse_INTERNAL:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// leaderTrailer returns the "leader" metadata of a NOT_LEADER response
+// naming the leader, or nil.
+func leaderTrailer(resp *TransportResponse) metadata.MD {
+	if len(resp.GetLeader()) == 0 {
+		return nil
+	}
+	return metadata.Pairs("leader", resp.GetLeader())
+}
+
+type grpcSessionKey struct{}
+
+// grpcSessions gives every gRPC connection a session, so that the snapshots
+// opened on it live until it is closed.
+type grpcSessions struct{}
+
+// grpcSession returns the session of the connection of a call.
+func grpcSession(ctx context.Context) *session {
+	sess, _ := ctx.Value(grpcSessionKey{}).(*session)
+	return sess
+}
+
+func (grpcSessions) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, grpcSessionKey{}, newSession())
+}
+
+func (grpcSessions) HandleConn(ctx context.Context, s stats.ConnStats) {
+	if _, ok := s.(*stats.ConnEnd); ok {
+		if sess := grpcSession(ctx); sess != nil {
+			sess.finish()
+			sess.close()
+		}
+	}
+}
+
+func (grpcSessions) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (grpcSessions) HandleRPC(context.Context, stats.RPCStats) {}
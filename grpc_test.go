@@ -0,0 +1,138 @@
+package ldbserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestGrpc(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	db := NewServer(NewMemStorage())
+	defer db.Close()
+	ns := NewNetworkServer("grpc", addr)
+	done := make(chan error, 1)
+	go func() {
+		done <- ns.ListenAndServe(db, JsonProtobufTransportFactory{Mt: MarshalingTypeJson})
+	}()
+	defer func() {
+		ns.Stop()
+		<-done
+	}()
+
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(GrpcCodec{})))
+	if !assert.NoError(t, err, "grpc.NewClient") {
+		return
+	}
+	defer conn.Close()
+	cl := NewLdbClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	body := func(data string) *TransportBody {
+		b := &TransportBody{Data: []byte(data)}
+		SetBodyChecksum(b)
+		return b
+	}
+	request := func(cmd TransportRequest_Command, key string) *TransportRequest {
+		return &TransportRequest{Command: cmd.Enum(), Key: []byte(key)}
+	}
+	code := func(err error) codes.Code {
+		return status.Code(err)
+	}
+
+	put := request(TransportRequest_PUT, "a")
+	put.Body = body("va")
+	_, err = cl.Put(ctx, put)
+	assert.NoError(t, err, "Put")
+	resp, err := cl.Get(ctx, request(TransportRequest_GET, "a"))
+	if assert.NoError(t, err, "Get") {
+		assert.Equal(t, resp.Body.GetData(), []byte("va"), "Get")
+	}
+	_, err = cl.Get(ctx, request(TransportRequest_GET, "x"))
+	assert.Equal(t, code(err), codes.NotFound, "Get missing")
+	resp, err = cl.Get(ctx, &TransportRequest{Key: []byte("a")})
+	if assert.NoError(t, err, "Get without command") {
+		assert.Equal(t, resp.Body.GetData(), []byte("va"), "Get without command")
+	}
+	// the method decides the command
+	_, err = cl.PutIfAbsent(ctx, put)
+	assert.Equal(t, code(err), codes.FailedPrecondition, "PutIfAbsent existing")
+	put.Body = &TransportBody{Checksum: proto.Uint32(1), Data: []byte("va")}
+	_, err = cl.Put(ctx, put)
+	assert.Equal(t, code(err), codes.DataLoss, "Put bad checksum")
+
+	// snapshots live as long as the connection
+	snap, err := cl.SnapshotOpen(ctx, request(TransportRequest_SNAPSHOT_OPEN, ""))
+	if assert.NoError(t, err, "SnapshotOpen") {
+		_, err = cl.Delete(ctx, request(TransportRequest_DELETE, "a"))
+		assert.NoError(t, err, "Delete")
+		get := request(TransportRequest_GET, "a")
+		get.Snapshot = snap.Snapshot
+		resp, err = cl.Get(ctx, get)
+		if assert.NoError(t, err, "Get from snapshot") {
+			assert.Equal(t, resp.Body.GetData(), []byte("va"), "Get from snapshot")
+		}
+		release := request(TransportRequest_SNAPSHOT_RELEASE, "")
+		release.Snapshot = snap.Snapshot
+		_, err = cl.SnapshotRelease(ctx, release)
+		assert.NoError(t, err, "SnapshotRelease")
+	}
+
+	load, err := cl.Load(ctx)
+	if !assert.NoError(t, err, "Load") {
+		return
+	}
+	const n = 2*loadBatchSize + 10
+	for i := 0; i < n; i++ {
+		key := fmt.Sprintf("k%05d", i)
+		err = load.Send(&TransportOperation{Command: TransportRequest_PUT.Enum(), Key: []byte(key), Body: body("v" + key)})
+		if !assert.NoError(t, err, "Load.Send") {
+			return
+		}
+	}
+	_, err = load.CloseAndRecv()
+	assert.NoError(t, err, "Load.CloseAndRecv")
+
+	req := request(TransportRequest_SCAN, "")
+	req.Range = &TransportRange{Prefix: []byte("k")}
+	scan, err := cl.Scan(ctx, req)
+	if !assert.NoError(t, err, "Scan") {
+		return
+	}
+	var count, responses int
+	for {
+		resp, err := scan.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err, "Scan.Recv") {
+			return
+		}
+		for _, pair := range resp.Pairs {
+			assert.Equal(t, string(pair.Value.GetData()), "v"+string(pair.Key), "scanned value")
+		}
+		count += len(resp.Pairs)
+		responses++
+	}
+	assert.Equal(t, count, n, "scanned pairs")
+	assert.Equal(t, responses, (n+scanBatchSize-1)/scanBatchSize, "scan responses")
+}
@@ -0,0 +1,50 @@
+package ldbserver
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+)
+
+const keyLockStripes = 256
+
+// keyLocks serializes writes to the same key. Keys are hashed to a fixed set of
+// mutexes, so unrelated keys may share a lock.
+type keyLocks struct {
+	stripes [keyLockStripes]sync.Mutex
+}
+
+func keyStripe(key []byte) int {
+	h := fnv.New32a()
+	h.Write(key)
+	return int(h.Sum32() % keyLockStripes)
+}
+
+// lock locks key and returns the function unlocking it.
+func (l *keyLocks) lock(key []byte) func() {
+	m := &l.stripes[keyStripe(key)]
+	m.Lock()
+	return m.Unlock
+}
+
+// lockAll locks all keys in a fixed order and returns the function unlocking
+// them.
+func (l *keyLocks) lockAll(keys [][]byte) func() {
+	seen := make(map[int]bool, len(keys))
+	stripes := make([]int, 0, len(keys))
+	for _, key := range keys {
+		if i := keyStripe(key); !seen[i] {
+			seen[i] = true
+			stripes = append(stripes, i)
+		}
+	}
+	sort.Ints(stripes)
+	for _, i := range stripes {
+		l.stripes[i].Lock()
+	}
+	return func() {
+		for _, i := range stripes {
+			l.stripes[i].Unlock()
+		}
+	}
+}
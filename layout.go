@@ -0,0 +1,127 @@
+package ldbserver
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// The server keeps its own keys next to those of clients, so the keys of a
+// Storage are split into namespaces by their first byte:
+//
+//	layoutKey                   the version of the layout
+//	dataPrefix key              the value of a client key, behind its metadata
+//	expiryIndexPrefix ts key    the keys expiring at ts, big-endian unix
+//	                            nanoseconds, ordered by time
+//
+// Clients only reach the data namespace, whatever their keys start with.
+
+var (
+	// layoutKey sorts before all other keys; layoutEnd right after it.
+	layoutKey         = []byte{}
+	layoutEnd         = []byte{0}
+	layoutVersion     = []byte("ldbserver/2")
+	dataPrefix        = []byte("d")
+	dataEnd           = []byte("e")
+	expiryIndexPrefix = []byte("x")
+
+	errBadValue = errors.New("corrupt stored value")
+)
+
+// meta is the metadata stored along with the value of a key.
+type meta struct {
+	// expires is the time the key expires at in unix nanoseconds, zero if it
+	// does not expire
+	expires int64
+	// flags are opaque to the server
+	flags uint32
+	// version grows with every write of the key
+	version uint64
+}
+
+func (m meta) expired(now time.Time) bool {
+	return m.expires != 0 && m.expires <= now.UnixNano()
+}
+
+func dataKey(key []byte) []byte {
+	return append(append(make([]byte, 0, len(dataPrefix)+len(key)), dataPrefix...), key...)
+}
+
+func expiryIndexKey(expires int64, key []byte) []byte {
+	b := make([]byte, len(expiryIndexPrefix)+8, len(expiryIndexPrefix)+8+len(key))
+	copy(b, expiryIndexPrefix)
+	binary.BigEndian.PutUint64(b[len(expiryIndexPrefix):], uint64(expires))
+	return append(b, key...)
+}
+
+// encodeValue returns the stored form of value: the length of the metadata
+// and its fields as uvarints, trailing zero fields left out, then the value.
+func encodeValue(m meta, value []byte) []byte {
+	var (
+		fields = []uint64{uint64(m.expires), uint64(m.flags), m.version}
+		header = make([]byte, 0, len(fields)*binary.MaxVarintLen64)
+		tmp    [binary.MaxVarintLen64]byte
+	)
+	for len(fields) != 0 && fields[len(fields)-1] == 0 {
+		fields = fields[:len(fields)-1]
+	}
+	for _, f := range fields {
+		header = append(header, tmp[:binary.PutUvarint(tmp[:], f)]...)
+	}
+	b := make([]byte, 0, binary.MaxVarintLen64+len(header)+len(value))
+	b = append(b, tmp[:binary.PutUvarint(tmp[:], uint64(len(header)))]...)
+	return append(append(b, header...), value...)
+}
+
+// decodeValue splits a value written by encodeValue. The returned value
+// shares b.
+func decodeValue(b []byte) (meta, []byte, error) {
+	var m meta
+	n, k := binary.Uvarint(b)
+	if k <= 0 || n > uint64(len(b)-k) {
+		return m, nil, errBadValue
+	}
+	header, value := b[k:k+int(n)], b[k+int(n):]
+	var fields [3]uint64
+	for i := 0; len(header) != 0 && i < len(fields); i++ {
+		if fields[i], k = binary.Uvarint(header); k <= 0 {
+			return m, nil, errBadValue
+		}
+		header = header[k:]
+	}
+	m.expires, m.flags, m.version = int64(fields[0]), uint32(fields[1]), fields[2]
+	return m, value, nil
+}
+
+// dataPair returns the pair of key as sent in scans and backups.
+func dataPair(key, value []byte, m meta) *TransportPair {
+	pair := &TransportPair{Key: key, Value: &TransportBody{Data: value}}
+	if m.expires != 0 {
+		pair.Expires = proto.Int64(m.expires)
+	}
+	if m.flags != 0 {
+		pair.Flags = proto.Uint32(m.flags)
+	}
+	if m.version != 0 {
+		pair.Version = proto.Uint64(m.version)
+	}
+	return pair
+}
+
+// pairMeta returns the metadata carried by pair.
+func pairMeta(pair *TransportPair) meta {
+	return meta{expires: pair.GetExpires(), flags: pair.GetFlags(), version: pair.GetVersion()}
+}
+
+// nextVersion returns the version of a value written at now over a key whose
+// metadata was old. Versions start from the clock, so that a key deleted and
+// written again does not go back to an earlier version.
+func nextVersion(old meta, now time.Time) uint64 {
+	v := uint64(now.UnixNano())
+	if v <= old.version {
+		v = old.version + 1
+	}
+	return v
+}
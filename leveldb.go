@@ -1,27 +1,147 @@
 package ldbserver
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"sync"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
+// scanBatchSize is the maximum number of pairs sent in one scan response.
+const scanBatchSize = 100
+
+// scanBatchBytes bounds the encoded size of the pairs of one scan response,
+// well below maxProtobufMessageSize since other codecs encode binary data in
+// more bytes. A single larger pair is sent alone.
+const scanBatchBytes = maxProtobufMessageSize / 2
+
+// DefaultSnapshotIdleTimeout is the time after which an unused snapshot is
+// released.
+const DefaultSnapshotIdleTimeout = 5 * time.Minute
+
 type leveldbServer struct {
-	db *leveldb.DB
+	db           *database
+	snapshotIdle time.Duration
+	acl          *ACL
+	metrics      *Metrics
+
+	// databases holds the named databases; those created by DB_CREATE live
+	// in dbDir and are opened with dbOptions
+	mu        sync.Mutex
+	databases map[string]*database
+	dbDir     string
+	dbOptions *opt.Options
+	backupDir string
+
+	// replLog is set on primaries, replica on replicas
+	replLog *replicationLog
+	replica *Replica
+	cluster *Cluster
+
+	sweepStop chan struct{}
+	sweepDone chan struct{}
 }
 
 func NewLevelDbServer(dbname string) (s *leveldbServer, err error) {
-	s = new(leveldbServer)
-	s.db, err = leveldb.OpenFile(dbname, nil)
+	return NewLevelDbServerOptions(dbname, nil)
+}
+
+// NewLevelDbServerOptions opens the default database with o.
+func NewLevelDbServerOptions(dbname string, o *opt.Options) (s *leveldbServer, err error) {
+	st, err := OpenLevelDBStorage(dbname, o)
 	if err != nil {
-		return
+		return nil, err
 	}
-	return
+	return NewServer(st), nil
+}
+
+// NewServer returns a server of st. Closing the server closes st.
+func NewServer(st Storage) *leveldbServer {
+	s := new(leveldbServer)
+	s.snapshotIdle = DefaultSnapshotIdleTimeout
+	s.databases = make(map[string]*database)
+	s.db = &database{st: st}
+	s.sweepStop = make(chan struct{})
+	s.sweepDone = make(chan struct{})
+	go s.sweepExpired(s.sweepStop, s.sweepDone)
+	return s
+}
+
+// AddDatabase serves the leveldb at path under name. It is opened with o on
+// the first request naming it and can not be dropped.
+func (s *leveldbServer) AddDatabase(name, path string, o *opt.Options) error {
+	if !validDatabaseName(name) {
+		return errBadDatabaseName
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.databases[name]; ok {
+		return errDatabaseExists
+	}
+	s.databases[name] = &database{name: name, path: path, options: o, configured: true}
+	return nil
+}
+
+// SetDatabaseDir enables DB_CREATE and DB_DROP. Created databases are stored
+// in subdirectories of dir named after them and opened with o.
+func (s *leveldbServer) SetDatabaseDir(dir string, o *opt.Options) {
+	s.dbDir = dir
+	s.dbOptions = o
+}
+
+// SetSnapshotIdleTimeout sets the time after which an unused snapshot is
+// released. Zero keeps snapshots until they are released or the connection is
+// closed.
+func (s *leveldbServer) SetSnapshotIdleTimeout(d time.Duration) {
+	s.snapshotIdle = d
+}
+
+// SetACL makes the server deny requests not allowed by acl. A nil acl allows
+// everything.
+func (s *leveldbServer) SetACL(acl *ACL) {
+	s.acl = acl
+}
+
+// SetMetrics makes the server count requests and report database statistics
+// to m.
+func (s *leveldbServer) SetMetrics(m *Metrics) {
+	s.metrics = m
+	m.AddCollector(func(w io.Writer) {
+		if d := s.db; d != nil {
+			d.mu.RLock()
+			defer d.mu.RUnlock()
+			if p, ok := d.st.(interface {
+				GetProperty(string) (string, error)
+			}); ok {
+				leveldbProperties(w, p.GetProperty)
+			}
+		}
+		s.replicationMetrics(w)
+	})
 }
 
 func (s *leveldbServer) Close() {
 	if s != nil && s.db != nil {
-		s.db.Close()
+		close(s.sweepStop)
+		<-s.sweepDone
+		if s.replica != nil {
+			s.replica.Stop()
+		}
+		if s.cluster != nil {
+			s.cluster.close()
+		}
+		s.mu.Lock()
+		for _, d := range s.databases {
+			d.close()
+		}
+		s.mu.Unlock()
+		s.db.close()
 		s.db = nil
 	}
 }
@@ -31,55 +151,430 @@ func (s *leveldbServer) serve(tr Transporter) error {
 		return errors.New("ldbserver.Server.Serve: uninitialized server, please use ldbserver.NewServer to create server")
 	}
 	req, err := tr.GetRequest()
+	if err == ErrChecksumMismatch {
+		return tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_CHECKSUM_MISMATCH, err)))
+	}
 	if err != nil {
 		return err
 	}
+	return s.handle(nil, tr, req)
+}
+
+func (s *leveldbServer) handle(sess *session, tr Transporter, req *TransportRequest) error {
+	if s == nil || s.db == nil {
+		return errors.New("ldbserver.Server.Serve: uninitialized server, please use ldbserver.NewServer to create server")
+	}
+
+	if s.metrics != nil {
+		st := &statusTransporter{Transporter: tr}
+		tr = st
+		defer func(start time.Time) {
+			s.metrics.observeRequest(req.GetCommand(), st.status, time.Since(start))
+		}(time.Now())
+	}
 
+	if s.acl != nil {
+		if err := s.acl.allow(req); err != nil {
+			return tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_DENIED, err)))
+		}
+	}
+
+	if req.GetCommand() == TransportRequest_REPL_STATUS {
+		return tr.SendResponse(answer(req, s.replStatus()))
+	}
+	if s.replica != nil && isWrite(req.GetCommand()) {
+		return tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_READ_ONLY, errReadOnly)))
+	}
+	if resp := s.clusterRequest(req); resp != nil {
+		return tr.SendResponse(answer(req, resp))
+	}
+
+	if req.GetCommand() == TransportRequest_DB_CREATE || req.GetCommand() == TransportRequest_DB_DROP {
+		return tr.SendResponse(answer(req, s.admin(req)))
+	}
+
+	d, err := s.database(req.GetDatabase())
+	if err == nil && req.GetCommand() == TransportRequest_RESTORE {
+		// restores hold the database for themselves
+		return tr.SendResponse(answer(req, s.restore(d, req)))
+	}
+	if err == nil {
+		err = d.acquire()
+	}
+	if err != nil {
+		return tr.SendResponse(answer(req, makeDbErrorResponse(err)))
+	}
+	defer d.release()
+	if d == s.db && s.replica != nil && s.replica.resyncing() {
+		return tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_INTERNAL, errReplicaLoading)))
+	}
+
+	if req.GetCommand() == TransportRequest_SCAN {
+		return d.scan(sess, tr, req)
+	}
+	if req.GetCommand() == TransportRequest_BACKUP {
+		return s.backup(d, tr, req)
+	}
+	if req.GetCommand() == TransportRequest_REPL_SNAPSHOT {
+		return s.replSnapshot(d, tr, req)
+	}
+	if req.GetCommand() == TransportRequest_REPL_TAIL {
+		return s.replTail(sess, d, tr, req)
+	}
+
+	var resp *TransportResponse
+	if req.GetCommand() == TransportRequest_SNAPSHOT_OPEN || req.GetCommand() == TransportRequest_SNAPSHOT_RELEASE {
+		resp = d.snapshot(sess, req, s.snapshotIdle)
+
+	} else {
+		resp = d.execute(sess, req, time.Now())
+	}
+	if err := tr.SendResponse(answer(req, resp)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// execute answers a read or write of keys of d. Expiries are relative to now.
+func (d *database) execute(sess *session, req *TransportRequest, now time.Time) *TransportResponse {
 	var resp *TransportResponse
-	reqId := req.GetId()
-	if reqId == nil {
-		resp = MakeErrorResponse(TransportResponse_FAIL, errors.New("no id in request"))
+	key := requestKey(req)
+	if req.GetCommand() == TransportRequest_BATCH {
+		resp = d.batch(req, now)
+
+	} else if key == nil {
+		resp = MakeErrorResponse(TransportResponse_BAD_REQUEST, errors.New("no key in request"))
 
 	} else {
 
 		resp = &TransportResponse{}
 
-		switch *req.Command {
+		switch req.GetCommand() {
 
 		case TransportRequest_GET:
-			if val, err := s.db.Get(reqId, nil); err == nil {
-				resp.Status = TransportResponse_OK.Enum()
-				resp.Body = &TransportBody{Data: val}
+			if r, done, errResp := d.reader(sess, req); errResp != nil {
+				resp = errResp
 			} else {
-				resp = MakeErrorResponse(TransportResponse_FAIL, err)
+				resp = getResponse(r, key, now)
+				done()
 			}
 
 		case TransportRequest_PUT:
-			if req.Body != nil && CheckBody(req.Body) {
-				if err := s.db.Put(reqId, req.Body.Data, nil); err == nil {
-					resp.Status = TransportResponse_OK.Enum()
-				} else {
-					resp = MakeErrorResponse(TransportResponse_FAIL, err)
-				}
+			if req.Body == nil {
+				resp = MakeErrorResponse(TransportResponse_BAD_REQUEST, errors.New("no data in request"))
+			} else if !CheckBody(req.Body) {
+				resp = MakeErrorResponse(TransportResponse_CHECKSUM_MISMATCH, ErrChecksumMismatch)
+			} else if err := d.put(key, req.Body.Data, requestMeta(req, now), now, req.GetSync()); err == nil {
+				resp.Status = TransportResponse_OK.Enum()
 			} else {
-				resp = MakeErrorResponse(TransportResponse_FAIL, errors.New("Bad data in request"))
+				resp = makeDbErrorResponse(err)
 			}
 
 		case TransportRequest_DELETE:
-			if err := s.db.Delete(reqId, nil); err == nil {
+			if err := d.delete(key, req.GetSync()); err == nil {
 				resp.Status = TransportResponse_OK.Enum()
 			} else {
-				resp = MakeErrorResponse(TransportResponse_FAIL, err)
+				resp = makeDbErrorResponse(err)
+			}
+
+		case TransportRequest_CAS, TransportRequest_PUT_IF_ABSENT, TransportRequest_DELETE_IF_EQUAL,
+			TransportRequest_REPLACE:
+			resp = d.conditional(key, req, now)
+
+		case TransportRequest_TTL:
+			if r, done, errResp := d.reader(sess, req); errResp != nil {
+				resp = errResp
+			} else {
+				resp = d.ttl(r, key, now)
+				done()
 			}
 
+		case TransportRequest_PERSIST:
+			resp = d.persist(key, now, req.GetSync())
+
 		default:
-			resp = MakeErrorResponse(TransportResponse_FAIL, errors.New("unsupported command"))
+			resp = MakeErrorResponse(TransportResponse_BAD_REQUEST, errors.New("unsupported command"))
 		}
-		resp.Id = append([]byte(nil), reqId...)
 	}
-	if err := tr.SendResponse(resp); err != nil {
-		return err
+	return resp
+}
+
+func (d *database) put(key, value []byte, m meta, now time.Time, sync bool) error {
+	defer d.locks.lock(key)()
+	return d.write(key, value, false, m, now, sync)
+}
+
+func (d *database) delete(key []byte, sync bool) error {
+	defer d.locks.lock(key)()
+	return d.write(key, nil, true, meta{}, time.Time{}, sync)
+}
+
+// conditional writes the key only if its current value matches the request.
+// The key is locked between the check and the write. Expired keys are
+// missing.
+func (d *database) conditional(key []byte, req *TransportRequest, now time.Time) *TransportResponse {
+	var (
+		cmd      = req.GetCommand()
+		expected *TransportBody
+		version  *uint64
+	)
+	if cmd != TransportRequest_DELETE_IF_EQUAL && req.Body == nil {
+		return MakeErrorResponse(TransportResponse_BAD_REQUEST, errors.New("no data in request"))
+	}
+	if cmd != TransportRequest_PUT_IF_ABSENT && cmd != TransportRequest_REPLACE {
+		expected, version = req.Expected, req.ExpectedVersion
+	}
+	if cmd == TransportRequest_DELETE_IF_EQUAL && expected == nil && version == nil {
+		return MakeErrorResponse(TransportResponse_BAD_REQUEST, errors.New("no expected value in request"))
+	}
+	if !CheckBody(expected) {
+		return MakeErrorResponse(TransportResponse_CHECKSUM_MISMATCH, ErrChecksumMismatch)
 	}
 
-	return nil
+	defer d.locks.lock(key)()
+
+	m, cur, err := get(d.st, key, now)
+	if err != nil && err != ErrNotFound {
+		return makeDbErrorResponse(err)
+	}
+	found := err == nil
+	matches := !found
+	switch {
+	case version != nil:
+		matches = found && m.version == *version
+	case expected != nil:
+		matches = found && bytes.Equal(cur, expected.Data)
+	case cmd == TransportRequest_REPLACE:
+		matches = found
+	}
+	if !matches {
+		return MakeErrorResponse(TransportResponse_CONDITION_FAILED, errors.New("condition failed"))
+	}
+
+	if cmd == TransportRequest_DELETE_IF_EQUAL {
+		err = d.write(key, nil, true, meta{}, now, req.GetSync())
+	} else {
+		err = d.write(key, req.Body.Data, false, requestMeta(req, now), now, req.GetSync())
+	}
+	if err != nil {
+		return makeDbErrorResponse(err)
+	}
+	return &TransportResponse{Status: TransportResponse_OK.Enum()}
+}
+
+// requestKey returns the key of req. Old clients send the key as id.
+func requestKey(req *TransportRequest) []byte {
+	if key := req.GetKey(); key != nil {
+		return key
+	}
+	return req.GetId()
+}
+
+// answer correlates resp with req.
+func answer(req *TransportRequest, resp *TransportResponse) *TransportResponse {
+	resp.Id = append([]byte(nil), req.GetId()...)
+	resp.Seq = req.Seq
+	return resp
+}
+
+// getResponse answers a GET of key from r.
+func getResponse(r Reader, key []byte, now time.Time) *TransportResponse {
+	m, val, err := get(r, key, now)
+	if err != nil {
+		return makeDbErrorResponse(err)
+	}
+	resp := &TransportResponse{Status: TransportResponse_OK.Enum(), Body: &TransportBody{Data: val}}
+	if m.flags != 0 {
+		resp.Flags = proto.Uint32(m.flags)
+	}
+	if m.version != 0 {
+		resp.Version = proto.Uint64(m.version)
+	}
+	return resp
+}
+
+// reader returns the snapshot requested by req or the database itself, and
+// the function called once the request stops reading it.
+func (d *database) reader(sess *session, req *TransportRequest) (Reader, func(), *TransportResponse) {
+	if req.Snapshot == nil {
+		return d.st, func() {}, nil
+	}
+	if sess == nil {
+		return nil, nil, MakeErrorResponse(TransportResponse_BAD_REQUEST, errNoSession)
+	}
+	snap, done, err := sess.snapshot(req.GetSnapshot(), d)
+	if err != nil {
+		return nil, nil, MakeErrorResponse(TransportResponse_BAD_REQUEST, err)
+	}
+	return snap, done, nil
+}
+
+// snapshot opens or releases a snapshot of the session.
+func (d *database) snapshot(sess *session, req *TransportRequest, idle time.Duration) *TransportResponse {
+	if sess == nil {
+		return MakeErrorResponse(TransportResponse_BAD_REQUEST, errNoSession)
+	}
+	if req.GetCommand() == TransportRequest_SNAPSHOT_RELEASE {
+		if err := sess.releaseSnapshot(req.GetSnapshot()); err != nil {
+			return MakeErrorResponse(TransportResponse_BAD_REQUEST, err)
+		}
+		return &TransportResponse{Status: TransportResponse_OK.Enum()}
+	}
+
+	snap, err := d.st.Snapshot()
+	if err != nil {
+		return makeDbErrorResponse(err)
+	}
+	id, err := sess.addSnapshot(d, snap, idle)
+	if err != nil {
+		return MakeErrorResponse(TransportResponse_INTERNAL, err)
+	}
+	return &TransportResponse{Status: TransportResponse_OK.Enum(), Snapshot: proto.Uint64(id)}
+}
+
+// makeDbErrorResponse reports a database error with the matching status.
+func makeDbErrorResponse(err error) *TransportResponse {
+	switch err {
+	case ErrNotFound, errUnknownDatabase:
+		return MakeErrorResponse(TransportResponse_NOT_FOUND, err)
+	case leveldb.ErrReadOnly:
+		return MakeErrorResponse(TransportResponse_DENIED, err)
+	}
+	return MakeErrorResponse(TransportResponse_INTERNAL, err)
+}
+
+// batch applies all operations of the request atomically at now. The
+// written keys lose their expiry and flags.
+func (d *database) batch(req *TransportRequest, now time.Time) *TransportResponse {
+	keys := make([][]byte, 0, len(req.Batch))
+	for _, op := range req.Batch {
+		keys = append(keys, op.GetKey())
+		switch op.GetCommand() {
+		case TransportRequest_PUT:
+			if op.Body == nil {
+				return MakeErrorResponse(TransportResponse_BAD_REQUEST, errors.New("no data in batch operation"))
+			}
+			if !CheckBody(op.Body) {
+				return MakeErrorResponse(TransportResponse_CHECKSUM_MISMATCH, ErrChecksumMismatch)
+			}
+		case TransportRequest_DELETE:
+		default:
+			return MakeErrorResponse(TransportResponse_BAD_REQUEST, errors.New("unsupported command in batch"))
+		}
+	}
+	defer d.locks.lockAll(keys)()
+	// a key written again in the batch finds the metadata of its last write
+	var (
+		ops     = make([]Operation, 0, len(req.Batch))
+		written = make(map[string]meta, len(req.Batch))
+	)
+	for _, op := range req.Batch {
+		old, ok := written[string(op.GetKey())]
+		if !ok {
+			var err error
+			if old, _, err = read(d.st, op.GetKey()); err != nil && err != ErrNotFound {
+				return makeDbErrorResponse(err)
+			}
+		}
+		var m meta
+		del := op.GetCommand() == TransportRequest_DELETE
+		if !del {
+			m.version = nextVersion(old, now)
+		}
+		ops = writeOps(ops, op.GetKey(), op.GetBody().GetData(), del, old, m)
+		written[string(op.GetKey())] = m
+	}
+	if err := d.st.Batch(ops, req.GetSync()); err != nil {
+		return makeDbErrorResponse(err)
+	}
+	return &TransportResponse{Status: TransportResponse_OK.Enum()}
+}
+
+// scan streams the pairs of the requested range to tr.
+func (d *database) scan(sess *session, tr Transporter, req *TransportRequest) error {
+	r, done, errResp := d.reader(sess, req)
+	if errResp != nil {
+		return tr.SendResponse(answer(req, errResp))
+	}
+	defer done()
+	rng := req.GetRange()
+	ur := scanRange(rng)
+	it := iterateData(r, ur.Start, ur.Limit, time.Now())
+	defer it.Release()
+	return sendPairs(tr, req, it, int(rng.GetLimit()), rng.GetReverse())
+}
+
+// sendPairs streams up to limit pairs of it to tr; zero limit means all of
+// them. Every response but the last one has More set. The pairs of a
+// dataIterator carry their metadata.
+func sendPairs(tr Transporter, req *TransportRequest, it Iterator, limit int, reverse bool) error {
+	var (
+		count int
+		size  int
+		ok    bool
+	)
+	newResponse := func() *TransportResponse {
+		return &TransportResponse{Status: TransportResponse_OK.Enum()}
+	}
+	resp := newResponse()
+	flush := func() error {
+		resp.More = proto.Bool(true)
+		if err := tr.SendResponse(answer(req, resp)); err != nil {
+			return err
+		}
+		resp, size = newResponse(), 0
+		return nil
+	}
+
+	if reverse {
+		ok = it.Last()
+	} else {
+		ok = it.First()
+	}
+	for ; ok && (limit == 0 || count < limit); count++ {
+		var m meta
+		if di, ok := it.(*dataIterator); ok {
+			m = di.meta
+		}
+		pair := dataPair(append([]byte(nil), it.Key()...), append([]byte(nil), it.Value()...), m)
+		n := pair.Size()
+		if len(resp.Pairs) != 0 && size+n > scanBatchBytes {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		resp.Pairs = append(resp.Pairs, pair)
+		size += n
+		if len(resp.Pairs) == scanBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if reverse {
+			ok = it.Prev()
+		} else {
+			ok = it.Next()
+		}
+	}
+	if err := it.Error(); err != nil {
+		resp = makeDbErrorResponse(err)
+	}
+	return tr.SendResponse(answer(req, resp))
+}
+
+// scanRange converts the requested range to a leveldb range. Start and end
+// narrow the range covered by the prefix.
+func scanRange(rng *TransportRange) *util.Range {
+	ret := &util.Range{}
+	if prefix := rng.GetPrefix(); prefix != nil {
+		ret = util.BytesPrefix(prefix)
+	}
+	if start := rng.GetStart(); start != nil && bytes.Compare(start, ret.Start) > 0 {
+		ret.Start = start
+	}
+	if end := rng.GetEnd(); end != nil && (ret.Limit == nil || bytes.Compare(end, ret.Limit) < 0) {
+		ret.Limit = end
+	}
+	return ret
 }
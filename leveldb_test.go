@@ -3,25 +3,21 @@ package ldbserver
 import (
 	"bytes"
 	"encoding/binary"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	pio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 )
 
 func serveCommand(t *testing.T, db DBServer, command TransportRequest_Command, key, value []byte, mt MarshalingType, checkOk bool) *TransportResponse {
 
-	var (
-		out = bytes.NewBuffer(nil)
-		in  = bytes.NewBuffer(nil)
-
-		tr = JsonProtobufTransportFactory{mt}.NewTransporter(out, in)
-	)
-
 	req := &TransportRequest{
 		Id:      key,
 		Command: command.Enum(),
@@ -29,31 +25,30 @@ func serveCommand(t *testing.T, db DBServer, command TransportRequest_Command, k
 	}
 	SetBodyChecksum(req.Body)
 
-	switch mt {
-	case MarshalingTypeJson:
-		enc := json.NewEncoder(out)
-		assert.NoError(t, enc.Encode(req), "Json")
-	case MarshalingTypeProtobuf:
-		enc := pio.NewUint32DelimitedWriter(out, binary.LittleEndian)
-		assert.NoError(t, enc.WriteMsg(req), "Protobuf")
+	resp := serveRequest(t, db, req, mt)
+	if resp != nil && checkOk {
+		assert.Equal(t, resp.Id, key, "Response")
+		assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Response")
+		assert.True(t, CheckBody(resp.Body), "Check resp body")
 	}
+	return resp
+}
+
+func serveRequest(t *testing.T, db DBServer, req *TransportRequest, mt MarshalingType) *TransportResponse {
+
+	var (
+		out = bytes.NewBuffer(nil)
+		in  = bytes.NewBuffer(nil)
+
+		tr = JsonProtobufTransportFactory{mt}.NewTransporter(out, in)
+	)
+
+	assert.NoError(t, mt.Codec().NewEncoder(out).Encode(req), mt.String())
 
 	if assert.NoError(t, db.serve(tr), "db.serve") {
 
 		resp := &TransportResponse{}
-		switch mt {
-		case MarshalingTypeJson:
-			dec := json.NewDecoder(in)
-			assert.NoError(t, dec.Decode(resp), "Json")
-		case MarshalingTypeProtobuf:
-			dec := pio.NewUint32DelimitedReader(in, binary.LittleEndian, 1024)
-			assert.NoError(t, dec.ReadMsg(resp), "Protobuf")
-		}
-		if checkOk {
-			assert.Equal(t, resp.Id, key, "Response")
-			assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Response")
-			assert.True(t, CheckBody(resp.Body), "Check resp body")
-		}
+		assert.NoError(t, mt.Codec().NewDecoder(in).Decode(resp), mt.String())
 		return resp
 	}
 	return nil
@@ -85,6 +80,377 @@ func TestLevelDB(t *testing.T) {
 
 		if resp := serveCommand(t, db, TransportRequest_GET, key, nil, MarshalingTypeProtobuf, false); resp != nil {
 			assert.Equal(t, string(resp.Body.Data), "leveldb: not found", "Not found error")
+			assert.Equal(t, resp.GetStatus(), TransportResponse_NOT_FOUND, "Not found error")
+		}
+
+		req := &TransportRequest{
+			Key:     key,
+			Command: TransportRequest_PUT.Enum(),
+			Body:    &TransportBody{Data: value, Checksum: proto.Uint32(1)},
 		}
+		if resp := serveRequest(t, db, req, MarshalingTypeJson); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_CHECKSUM_MISMATCH, "Checksum mismatch")
+		}
+		req = &TransportRequest{Command: TransportRequest_GET.Enum()}
+		if resp := serveRequest(t, db, req, MarshalingTypeJson); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_BAD_REQUEST, "No key")
+		}
+
+		req = &TransportRequest{
+			Key:     key,
+			Seq:     proto.Uint64(7),
+			Command: TransportRequest_PUT.Enum(),
+			Body:    &TransportBody{Data: value},
+		}
+		SetBodyChecksum(req.Body)
+		for _, mt := range []MarshalingType{MarshalingTypeJson, MarshalingTypeProtobuf, MarshalingTypeMsgpack, MarshalingTypeCBOR} {
+			if resp := serveRequest(t, db, req, mt); resp != nil {
+				assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Key and seq")
+				assert.Equal(t, resp.GetSeq(), uint64(7), "Key and seq")
+				assert.Nil(t, resp.Id, "Key and seq")
+			}
+		}
+		if resp := serveRequest(t, db, &TransportRequest{Key: key}, MarshalingTypeProtobuf); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_BAD_REQUEST, "No command")
+		}
+		req = &TransportRequest{
+			Id:      key,
+			Key:     key,
+			Seq:     proto.Uint64(8),
+			Command: TransportRequest_GET.Enum(),
+		}
+		if resp := serveRequest(t, db, req, MarshalingTypeProtobuf); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Id, key and seq")
+			assert.Equal(t, resp.GetSeq(), uint64(8), "Id, key and seq")
+			assert.Equal(t, resp.Id, key, "Id, key and seq")
+			assert.Equal(t, resp.Body.Data, value, "Id, key and seq")
+		}
+	}
+}
+
+func scanCommand(t *testing.T, db DBServer, rng *TransportRange, mt MarshalingType) (keys []string) {
+
+	var (
+		out = bytes.NewBuffer(nil)
+		in  = bytes.NewBuffer(nil)
+
+		tr = JsonProtobufTransportFactory{mt}.NewTransporter(out, in)
+	)
+
+	req := &TransportRequest{
+		Command: TransportRequest_SCAN.Enum(),
+		Range:   rng,
+	}
+
+	assert.NoError(t, mt.Codec().NewEncoder(out).Encode(req), mt.String())
+
+	if assert.NoError(t, db.serve(tr), "db.serve") {
+		dec := mt.Codec().NewDecoder(in)
+		for {
+			resp := &TransportResponse{}
+			assert.NoError(t, dec.Decode(resp), mt.String())
+			assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Response")
+			for _, pair := range resp.Pairs {
+				assert.True(t, CheckBody(pair.Value), "Check pair value")
+				assert.Equal(t, pair.Value.Data, append([]byte("v"), pair.Key...), "Pair value")
+				keys = append(keys, string(pair.Key))
+			}
+			if !resp.GetMore() {
+				break
+			}
+		}
+	}
+	return
+}
+
+func TestLevelDBScan(t *testing.T) {
+
+	tempdir := os.TempDir()
+	path := filepath.Join(tempdir, fmt.Sprintf("goleveldb-test-scan%d0%d", os.Getuid(), os.Getpid()))
+	db, err := NewLevelDbServer(path)
+	if assert.NoError(t, err, "NewLevelDbServer") {
+		defer func() {
+			db.Close()
+			os.RemoveAll(path)
+		}()
+
+		var all []string
+		for i := 0; i < 250; i++ {
+			key := fmt.Sprintf("a%03d", i)
+			serveCommand(t, db, TransportRequest_PUT, []byte(key), []byte("v"+key), MarshalingTypeProtobuf, true)
+			all = append(all, key)
+		}
+		serveCommand(t, db, TransportRequest_PUT, []byte("b"), []byte("vb"), MarshalingTypeProtobuf, true)
+
+		for _, mt := range []MarshalingType{MarshalingTypeJson, MarshalingTypeProtobuf, MarshalingTypeMsgpack, MarshalingTypeCBOR} {
+			assert.Equal(t, scanCommand(t, db, &TransportRange{Prefix: []byte("a")}, mt), all, "Prefix")
+			assert.Equal(t, scanCommand(t, db, &TransportRange{Start: []byte("a248")}, mt), []string{"a248", "a249", "b"}, "Start")
+			assert.Equal(t, scanCommand(t, db, &TransportRange{Prefix: []byte("a"), Start: []byte("a010"), End: []byte("a013")}, mt), []string{"a010", "a011", "a012"}, "Start and end")
+			assert.Equal(t, scanCommand(t, db, &TransportRange{Limit: proto.Uint32(2), Reverse: proto.Bool(true)}, mt), []string{"b", "a249"}, "Reverse limit")
+			assert.Len(t, scanCommand(t, db, &TransportRange{Prefix: []byte("c")}, mt), 0, "Empty")
+		}
+	}
+}
+
+func TestLevelDBScanLargeValues(t *testing.T) {
+
+	tempdir := os.TempDir()
+	path := filepath.Join(tempdir, fmt.Sprintf("goleveldb-test-scan-large%d0%d", os.Getuid(), os.Getpid()))
+	db, err := NewLevelDbServer(path)
+	if assert.NoError(t, err, "NewLevelDbServer") {
+		defer func() {
+			db.Close()
+			os.RemoveAll(path)
+		}()
+
+		value := bytes.Repeat([]byte("v"), 20*1024)
+		for i := 0; i < scanBatchSize; i++ {
+			serveCommand(t, db, TransportRequest_PUT, []byte(fmt.Sprintf("k%03d", i)), value, MarshalingTypeProtobuf, true)
+		}
+
+		for _, mt := range []MarshalingType{MarshalingTypeJson, MarshalingTypeProtobuf, MarshalingTypeMsgpack, MarshalingTypeCBOR} {
+			var (
+				out = bytes.NewBuffer(nil)
+				in  = bytes.NewBuffer(nil)
+				tr  = JsonProtobufTransportFactory{mt}.NewTransporter(out, in)
+			)
+			assert.NoError(t, mt.Codec().NewEncoder(out).Encode(&TransportRequest{Command: TransportRequest_SCAN.Enum()}), mt.String())
+			if assert.NoError(t, db.serve(tr), "db.serve") {
+				var (
+					dec       = mt.Codec().NewDecoder(in)
+					pairs     int
+					responses int
+				)
+				for {
+					resp := &TransportResponse{}
+					if !assert.NoError(t, dec.Decode(resp), mt.String()) {
+						break
+					}
+					responses++
+					for _, pair := range resp.Pairs {
+						assert.Equal(t, pair.Value.Data, value, "Pair value")
+						pairs++
+					}
+					if !resp.GetMore() {
+						break
+					}
+				}
+				assert.Equal(t, pairs, scanBatchSize, mt.String())
+				assert.True(t, responses > 1, "Responses split by size")
+			}
+		}
+	}
+}
+
+func TestLevelDBBatch(t *testing.T) {
+
+	tempdir := os.TempDir()
+	path := filepath.Join(tempdir, fmt.Sprintf("goleveldb-test-batch%d0%d", os.Getuid(), os.Getpid()))
+	db, err := NewLevelDbServer(path)
+	if assert.NoError(t, err, "NewLevelDbServer") {
+		defer func() {
+			db.Close()
+			os.RemoveAll(path)
+		}()
+
+		put := func(key, value string) *TransportOperation {
+			op := &TransportOperation{
+				Command: TransportRequest_PUT.Enum(),
+				Key:     []byte(key),
+				Body:    &TransportBody{Data: []byte(value)},
+			}
+			SetBodyChecksum(op.Body)
+			return op
+		}
+		del := func(key string) *TransportOperation {
+			return &TransportOperation{Command: TransportRequest_DELETE.Enum(), Key: []byte(key)}
+		}
+
+		req := &TransportRequest{
+			Command: TransportRequest_BATCH.Enum(),
+			Batch:   []*TransportOperation{put("a", "va"), put("b", "vb"), put("c", "vc"), del("b")},
+			Sync:    proto.Bool(true),
+		}
+		if resp := serveRequest(t, db, req, MarshalingTypeProtobuf); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Batch")
+		}
+		assert.Equal(t, scanCommand(t, db, nil, MarshalingTypeJson), []string{"a", "c"}, "Batch result")
+
+		bad := put("d", "vd")
+		bad.Body.Checksum = proto.Uint32(bad.Body.GetChecksum() + 1)
+		req = &TransportRequest{
+			Command: TransportRequest_BATCH.Enum(),
+			Batch:   []*TransportOperation{del("a"), bad},
+		}
+		if resp := serveRequest(t, db, req, MarshalingTypeJson); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_CHECKSUM_MISMATCH, "Bad batch")
+		}
+		assert.Equal(t, scanCommand(t, db, nil, MarshalingTypeJson), []string{"a", "c"}, "Bad batch result")
+	}
+}
+
+func handleRequest(t *testing.T, db DBServer, sess *session, req *TransportRequest) *TransportResponse {
+
+	var (
+		in = bytes.NewBuffer(nil)
+		tr = JsonProtobufTransportFactory{MarshalingTypeProtobuf}.NewTransporter(nil, in)
+	)
+
+	if assert.NoError(t, db.handle(sess, tr, req), "db.handle") {
+		resp := &TransportResponse{}
+		dec := pio.NewUint32DelimitedReader(in, binary.LittleEndian, 1024)
+		assert.NoError(t, dec.ReadMsg(resp), "Protobuf")
+		return resp
+	}
+	return nil
+}
+
+func TestSessionSnapshotInUse(t *testing.T) {
+	var (
+		sess = newSession()
+		d    = &database{st: NewMemStorage()}
+	)
+	defer sess.close()
+	snap, err := d.st.Snapshot()
+	if !assert.NoError(t, err, "Snapshot") {
+		return
+	}
+	// the shortest idle timeout must not stop the session
+	id, err := sess.addSnapshot(d, snap, time.Nanosecond)
+	if !assert.NoError(t, err, "addSnapshot") {
+		return
+	}
+	_, done, err := sess.snapshot(id, d)
+	if !assert.NoError(t, err, "snapshot") {
+		return
+	}
+	time.Sleep(5 * minSnapshotExpiryInterval)
+	_, again, err := sess.snapshot(id, d)
+	if assert.NoError(t, err, "snapshot in use kept") {
+		again()
+	}
+	done()
+	time.Sleep(5 * minSnapshotExpiryInterval)
+	_, _, err = sess.snapshot(id, d)
+	assert.Equal(t, err, errUnknownSnapshot, "snapshot expired once unused")
+}
+
+func TestLevelDBSnapshot(t *testing.T) {
+
+	tempdir := os.TempDir()
+	path := filepath.Join(tempdir, fmt.Sprintf("goleveldb-test-snapshot%d0%d", os.Getuid(), os.Getpid()))
+	db, err := NewLevelDbServer(path)
+	if assert.NoError(t, err, "NewLevelDbServer") {
+		defer func() {
+			db.Close()
+			os.RemoveAll(path)
+		}()
+		db.SetSnapshotIdleTimeout(100 * time.Millisecond)
+
+		var (
+			sess = newSession()
+			key  = []byte("hello")
+		)
+		defer sess.close()
+
+		serveCommand(t, db, TransportRequest_PUT, key, []byte("old"), MarshalingTypeProtobuf, true)
+
+		resp := handleRequest(t, db, sess, &TransportRequest{Command: TransportRequest_SNAPSHOT_OPEN.Enum()})
+		if !assert.NotNil(t, resp, "Open snapshot") || !assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Open snapshot") {
+			return
+		}
+		snapshot := resp.Snapshot
+
+		serveCommand(t, db, TransportRequest_PUT, key, []byte("new"), MarshalingTypeProtobuf, true)
+
+		get := &TransportRequest{Key: key, Command: TransportRequest_GET.Enum(), Snapshot: snapshot}
+		if resp := handleRequest(t, db, sess, get); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Snapshot get")
+			assert.Equal(t, resp.Body.Data, []byte("old"), "Snapshot get")
+		}
+		if resp := handleRequest(t, db, newSession(), get); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_BAD_REQUEST, "Snapshot of another session")
+		}
+		if resp := serveRequest(t, db, get, MarshalingTypeJson); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_BAD_REQUEST, "Snapshot without session")
+		}
+
+		release := &TransportRequest{Command: TransportRequest_SNAPSHOT_RELEASE.Enum(), Snapshot: snapshot}
+		if resp := handleRequest(t, db, sess, release); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Release snapshot")
+		}
+		if resp := handleRequest(t, db, sess, get); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_BAD_REQUEST, "Released snapshot")
+		}
+
+		resp = handleRequest(t, db, sess, &TransportRequest{Command: TransportRequest_SNAPSHOT_OPEN.Enum()})
+		if assert.NotNil(t, resp, "Open snapshot") {
+			time.Sleep(300 * time.Millisecond)
+			get.Snapshot = resp.Snapshot
+			if resp := handleRequest(t, db, sess, get); resp != nil {
+				assert.Equal(t, resp.GetStatus(), TransportResponse_BAD_REQUEST, "Idle snapshot")
+			}
+		}
+	}
+}
+
+func TestLevelDBConditional(t *testing.T) {
+
+	tempdir := os.TempDir()
+	path := filepath.Join(tempdir, fmt.Sprintf("goleveldb-test-cas%d0%d", os.Getuid(), os.Getpid()))
+	db, err := NewLevelDbServer(path)
+	if assert.NoError(t, err, "NewLevelDbServer") {
+		defer func() {
+			db.Close()
+			os.RemoveAll(path)
+		}()
+
+		key := []byte("counter")
+		body := func(data string) *TransportBody {
+			b := &TransportBody{Data: []byte(data)}
+			SetBodyChecksum(b)
+			return b
+		}
+		status := func(req *TransportRequest) TransportResponse_Status {
+			return handleRequest(t, db, nil, req).GetStatus()
+		}
+
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_PUT_IF_ABSENT.Enum(), Body: body("0")}), TransportResponse_OK, "Put if absent")
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_PUT_IF_ABSENT.Enum(), Body: body("1")}), TransportResponse_CONDITION_FAILED, "Put if absent")
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_CAS.Enum(), Body: body("1")}), TransportResponse_CONDITION_FAILED, "CAS absent")
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_CAS.Enum(), Body: body("1"), Expected: body("2")}), TransportResponse_CONDITION_FAILED, "CAS mismatch")
+		version := handleRequest(t, db, nil, &TransportRequest{Key: key, Command: TransportRequest_GET.Enum()}).GetVersion()
+		assert.NotEqual(t, version, uint64(0), "GET version")
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_CAS.Enum(), Body: body("0"), ExpectedVersion: proto.Uint64(version + 1)}), TransportResponse_CONDITION_FAILED, "CAS version mismatch")
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_CAS.Enum(), Body: body("0"), ExpectedVersion: proto.Uint64(version)}), TransportResponse_OK, "CAS version")
+		// the same value written again gets a new version
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_CAS.Enum(), Body: body("0"), ExpectedVersion: proto.Uint64(version)}), TransportResponse_CONDITION_FAILED, "CAS old version")
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_REPLACE.Enum(), Body: body("0")}), TransportResponse_OK, "Replace")
+		assert.Equal(t, status(&TransportRequest{Key: []byte("missing"), Command: TransportRequest_REPLACE.Enum(), Body: body("0")}), TransportResponse_CONDITION_FAILED, "Replace missing")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for n := 0; n < 10; {
+					cur := handleRequest(t, db, nil, &TransportRequest{Key: key, Command: TransportRequest_GET.Enum()})
+					v, _ := strconv.Atoi(string(cur.Body.Data))
+					req := &TransportRequest{Key: key, Command: TransportRequest_CAS.Enum(), Body: body(strconv.Itoa(v + 1)), Expected: cur.Body}
+					if status(req) == TransportResponse_OK {
+						n++
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		if resp := handleRequest(t, db, nil, &TransportRequest{Key: key, Command: TransportRequest_GET.Enum()}); resp != nil {
+			assert.Equal(t, string(resp.Body.Data), "80", "Concurrent CAS")
+		}
+
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_DELETE_IF_EQUAL.Enum()}), TransportResponse_BAD_REQUEST, "Delete if equal without value")
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_DELETE_IF_EQUAL.Enum(), Expected: body("79")}), TransportResponse_CONDITION_FAILED, "Delete if equal mismatch")
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_DELETE_IF_EQUAL.Enum(), Expected: body("80")}), TransportResponse_OK, "Delete if equal")
+		assert.Equal(t, status(&TransportRequest{Key: key, Command: TransportRequest_GET.Enum()}), TransportResponse_NOT_FOUND, "Delete if equal")
 	}
 }
@@ -0,0 +1,297 @@
+package ldbserver
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+const (
+	// maxMemcacheKeySize and maxMemcacheValueSize bound the items written by
+	// memcached clients.
+	maxMemcacheKeySize   = 250
+	maxMemcacheValueSize = 64 * 1024 * 1024
+	// memcacheRelativeExptime is the largest exptime counted from now;
+	// larger ones are unix times.
+	memcacheRelativeExptime = 30 * 24 * 60 * 60
+)
+
+var errMemcacheCommand = errors.New("bad command line format")
+
+// MemcacheTransportFactory speaks the text protocol of memcached. get, gets,
+// set, add, replace, delete and cas are translated to requests of the server;
+// version and quit are answered by the transporter. The flags and the cas
+// unique of an item are the flags and the version of its value.
+type MemcacheTransportFactory struct{}
+
+func (MemcacheTransportFactory) NewTransporter(r io.Reader, w io.Writer) Transporter {
+	t := &memcacheTransporter{textTransporter: newTextTransporter(r, w)}
+	t.read = t.readCommand
+	return t
+}
+
+type memcacheTransporter struct {
+	textTransporter
+}
+
+// memcacheItem is the command line of a storage command.
+type memcacheItem struct {
+	cmd     string
+	key     []byte
+	flags   uint32
+	exptime int64
+	size    int
+	cas     uint64
+	noreply bool
+}
+
+func (t *memcacheTransporter) readCommand() (func(), error) {
+	line, err := t.readLine()
+	if err == errTextLineTooLong {
+		return func() { t.local(memcacheError("CLIENT_ERROR", err.Error())) }, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(string(line))
+	if len(fields) == 0 {
+		return func() { t.local([]byte("ERROR\r\n")) }, nil
+	}
+	switch fields[0] {
+	case "set", "add", "replace", "cas":
+		item, err := parseMemcacheItem(fields)
+		if err != nil {
+			// the data block can not be skipped without its size
+			return func() { t.local(memcacheError("CLIENT_ERROR", err.Error())) }, err
+		}
+		data, ok, err := t.readBlock(item.size)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			err = errors.New("bad data chunk")
+			return func() { t.local(memcacheError("CLIENT_ERROR", err.Error())) }, err
+		}
+		return func() { t.store(item, data) }, nil
+	default:
+		return func() { t.command(fields) }, nil
+	}
+}
+
+// parseMemcacheItem parses <command> <key> <flags> <exptime> <bytes>
+// [<cas unique>] [noreply].
+func parseMemcacheItem(fields []string) (*memcacheItem, error) {
+	item := &memcacheItem{cmd: fields[0]}
+	n := 5
+	if item.cmd == "cas" {
+		n = 6
+	}
+	if len(fields) == n+1 && fields[n] == "noreply" {
+		item.noreply = true
+	} else if len(fields) != n {
+		return nil, errMemcacheCommand
+	}
+	item.key = []byte(fields[1])
+	flags, err1 := strconv.ParseUint(fields[2], 10, 32)
+	exptime, err2 := strconv.ParseInt(fields[3], 10, 64)
+	size, err3 := strconv.Atoi(fields[4])
+	if err1 != nil || err2 != nil || err3 != nil || size < 0 || size > maxMemcacheValueSize || len(item.key) > maxMemcacheKeySize {
+		return nil, errMemcacheCommand
+	}
+	item.flags, item.exptime, item.size = uint32(flags), exptime, size
+	if item.cmd == "cas" {
+		if item.cas, err1 = strconv.ParseUint(fields[5], 10, 64); err1 != nil {
+			return nil, errMemcacheCommand
+		}
+	}
+	return item, nil
+}
+
+// store queues the request of a storage command.
+func (t *memcacheTransporter) store(item *memcacheItem, data []byte) {
+	ttl, expired := memcacheTTL(item.exptime, time.Now())
+	if expired {
+		t.storeExpired(item)
+		return
+	}
+	req := textRequest(TransportRequest_PUT, item.key)
+	req.Body = &TransportBody{Data: data}
+	SetBodyChecksum(req.Body)
+	if item.flags != 0 {
+		req.Flags = proto.Uint32(item.flags)
+	}
+	if ttl != 0 {
+		req.Ttl = proto.Uint64(ttl)
+	}
+	reqs := []*TransportRequest{req}
+	switch item.cmd {
+	case "add":
+		req.Command = TransportRequest_PUT_IF_ABSENT.Enum()
+	case "replace":
+		req.Command = TransportRequest_REPLACE.Enum()
+	case "cas":
+		req.Command = TransportRequest_CAS.Enum()
+		req.ExpectedVersion = proto.Uint64(item.cas)
+		// TTL tells a changed item from a missing one
+		reqs = []*TransportRequest{textRequest(TransportRequest_TTL, item.key), req}
+	}
+	t.queue(func(resps []*TransportResponse) []byte {
+		resp := resps[len(resps)-1]
+		reply := []byte("STORED\r\n")
+		switch resp.GetStatus() {
+		case TransportResponse_OK:
+		case TransportResponse_CONDITION_FAILED:
+			if item.cmd != "cas" {
+				reply = []byte("NOT_STORED\r\n")
+			} else if resps[0].GetStatus() == TransportResponse_OK {
+				reply = []byte("EXISTS\r\n")
+			} else {
+				reply = []byte("NOT_FOUND\r\n")
+			}
+		default:
+			reply = memcacheStatusError(resp)
+		}
+		if item.noreply {
+			return []byte{}
+		}
+		return reply
+	}, reqs...)
+}
+
+// storeExpired queues the requests of a storage command whose item expired
+// already. Like memcached, nothing is stored but the item it replaces is
+// dropped.
+func (t *memcacheTransporter) storeExpired(item *memcacheItem) {
+	// TTL tells whether the item exists
+	reqs := []*TransportRequest{textRequest(TransportRequest_TTL, item.key)}
+	switch item.cmd {
+	case "set", "replace":
+		reqs = append(reqs, textRequest(TransportRequest_DELETE, item.key))
+	case "cas":
+		req := textRequest(TransportRequest_DELETE_IF_EQUAL, item.key)
+		req.ExpectedVersion = proto.Uint64(item.cas)
+		reqs = append(reqs, req)
+	}
+	t.queue(func(resps []*TransportResponse) []byte {
+		found := resps[0].GetStatus() == TransportResponse_OK
+		resp := resps[len(resps)-1]
+		reply := []byte("STORED\r\n")
+		switch {
+		case resp.GetStatus() == TransportResponse_CONDITION_FAILED && found:
+			reply = []byte("EXISTS\r\n")
+		case resp.GetStatus() == TransportResponse_CONDITION_FAILED:
+			reply = []byte("NOT_FOUND\r\n")
+		case resp.GetStatus() != TransportResponse_OK && resp.GetStatus() != TransportResponse_NOT_FOUND:
+			reply = memcacheStatusError(resp)
+		case item.cmd == "add" && found, item.cmd == "replace" && !found:
+			reply = []byte("NOT_STORED\r\n")
+		}
+		if item.noreply {
+			return []byte{}
+		}
+		return reply
+	}, reqs...)
+}
+
+func (t *memcacheTransporter) command(fields []string) {
+	switch fields[0] {
+	case "get", "gets":
+		if len(fields) == 1 {
+			t.local([]byte("ERROR\r\n"))
+			return
+		}
+		withCas := fields[0] == "gets"
+		keys := fields[1:]
+		reqs := make([]*TransportRequest, 0, len(keys))
+		for _, key := range keys {
+			reqs = append(reqs, textRequest(TransportRequest_GET, []byte(key)))
+		}
+		t.queue(func(resps []*TransportResponse) []byte {
+			var buf []byte
+			for i, resp := range resps {
+				switch resp.GetStatus() {
+				case TransportResponse_OK:
+				case TransportResponse_NOT_FOUND:
+					continue
+				default:
+					return memcacheStatusError(resp)
+				}
+				data := resp.Body.GetData()
+				buf = append(buf, "VALUE "+keys[i]+" "+strconv.FormatUint(uint64(resp.GetFlags()), 10)+" "+strconv.Itoa(len(data))...)
+				if withCas {
+					buf = append(buf, ' ')
+					buf = strconv.AppendUint(buf, resp.GetVersion(), 10)
+				}
+				buf = append(buf, "\r\n"...)
+				buf = append(buf, data...)
+				buf = append(buf, "\r\n"...)
+			}
+			return append(buf, "END\r\n"...)
+		}, reqs...)
+	case "delete":
+		// delete <key> [0] [noreply]; the time of old clients must be zero
+		noreply := fields[len(fields)-1] == "noreply"
+		args := fields[1:]
+		if noreply {
+			args = args[:len(args)-1]
+		}
+		if len(args) == 0 || len(args) > 2 || (len(args) == 2 && args[1] != "0") {
+			t.local(memcacheError("CLIENT_ERROR", errMemcacheCommand.Error()))
+			return
+		}
+		key := []byte(args[0])
+		t.queue(func(resps []*TransportResponse) []byte {
+			reply := []byte("DELETED\r\n")
+			switch {
+			case resps[1].GetStatus() != TransportResponse_OK:
+				reply = memcacheStatusError(resps[1])
+			case resps[0].GetStatus() == TransportResponse_NOT_FOUND:
+				reply = []byte("NOT_FOUND\r\n")
+			case resps[0].GetStatus() != TransportResponse_OK:
+				reply = memcacheStatusError(resps[0])
+			}
+			if noreply {
+				return []byte{}
+			}
+			return reply
+		}, textRequest(TransportRequest_TTL, key), textRequest(TransportRequest_DELETE, key))
+	case "version":
+		t.local([]byte("VERSION ldbserver\r\n"))
+	case "quit":
+		t.quit = true
+	default:
+		t.local([]byte("ERROR\r\n"))
+	}
+}
+
+// memcacheTTL converts an exptime to a ttl in milliseconds; zero means none.
+// A negative or past exptime means the item expired already.
+func memcacheTTL(exptime int64, now time.Time) (ttl uint64, expired bool) {
+	if exptime == 0 {
+		return 0, false
+	}
+	if exptime > memcacheRelativeExptime {
+		exptime -= now.Unix()
+	}
+	if exptime <= 0 {
+		return 0, true
+	}
+	return uint64(exptime) * 1000, false
+}
+
+func memcacheStatusError(resp *TransportResponse) []byte {
+	kind := "SERVER_ERROR"
+	if resp.GetStatus() == TransportResponse_BAD_REQUEST {
+		kind = "CLIENT_ERROR"
+	}
+	return memcacheError(kind, resp.GetStatus().String()+" "+string(resp.Body.GetData()))
+}
+
+func memcacheError(kind, msg string) []byte {
+	msg = strings.NewReplacer("\r", " ", "\n", " ").Replace(msg)
+	return []byte(kind + " " + msg + "\r\n")
+}
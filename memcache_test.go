@@ -0,0 +1,104 @@
+package ldbserver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemcache(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	db := NewServer(NewMemStorage())
+	defer db.Close()
+	ns := NewNetworkServer("memcache", addr)
+	done := make(chan error, 1)
+	go func() {
+		done <- ns.ListenAndServe(db, JsonProtobufTransportFactory{Mt: MarshalingTypeJson})
+	}()
+	defer func() {
+		ns.Stop()
+		<-done
+	}()
+
+	var conn net.Conn
+	for i := 0; i < 50; i++ {
+		if conn, err = net.Dial("tcp", addr); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !assert.NoError(t, err, "net.Dial") {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	// send writes the lines pipelined and reads n reply lines
+	send := func(n int, lines ...string) string {
+		if len(lines) != 0 {
+			_, err := io.WriteString(conn, strings.Join(lines, "\r\n")+"\r\n")
+			assert.NoError(t, err, "write")
+		}
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		var out strings.Builder
+		for i := 0; i < n; i++ {
+			line, err := r.ReadString('\n')
+			if !assert.NoError(t, err, "read") {
+				break
+			}
+			out.WriteString(line)
+		}
+		return out.String()
+	}
+
+	assert.Equal(t, send(4,
+		"set a 5 0 3", "one",
+		"add a 0 0 3", "two",
+		"replace b 0 0 3", "two",
+		"add b 0 0 3", "two",
+	), "STORED\r\nNOT_STORED\r\nNOT_STORED\r\nSTORED\r\n", "storage commands")
+	assert.Equal(t, send(5, "get a b c"), "VALUE a 5 3\r\none\r\nVALUE b 0 3\r\ntwo\r\nEND\r\n", "get")
+	// values of other clients are read whole
+	serveCommand(t, db, TransportRequest_PUT, []byte("plain"), []byte("\x00\x00\x00\x07value"), MarshalingTypeJson, true)
+	assert.Equal(t, send(3, "get plain"), "VALUE plain 0 9\r\n\x00\x00\x00\x07value\r\nEND\r\n", "get without flags")
+
+	reply := send(3, "gets a")
+	var (
+		flags, size int
+		cas         uint64
+	)
+	_, err = fmt.Sscanf(reply, "VALUE a %d %d %d", &flags, &size, &cas)
+	assert.NoError(t, err, "gets")
+	assert.Equal(t, send(3,
+		fmt.Sprintf("cas a 0 0 3 %d", cas+1), "new",
+		fmt.Sprintf("cas a 0 0 3 %d", cas), "new",
+		fmt.Sprintf("cas c 0 0 3 %d", cas), "new",
+	), "EXISTS\r\nSTORED\r\nNOT_FOUND\r\n", "cas")
+
+	assert.Equal(t, send(3, "delete a", "delete a", "set c 0 0 1 noreply", "x", "get c"), "DELETED\r\nNOT_FOUND\r\nVALUE c 0 1\r\n", "delete and noreply")
+	assert.Equal(t, send(2), "x\r\nEND\r\n", "get after noreply")
+
+	// items expired already are not stored and drop the items they replace
+	assert.Equal(t, send(1, "set e 0 0 1", "x"), "STORED\r\n", "set")
+	assert.Equal(t, send(4,
+		"set e 0 -1 1", "x",
+		"add f 0 -1 1", "x",
+		"replace f 0 -1 1", "x",
+		fmt.Sprintf("cas c 0 %d 1 %d", time.Now().Unix()-10, cas),
+		"x",
+	), "STORED\r\nSTORED\r\nNOT_STORED\r\nEXISTS\r\n", "expired storage commands")
+	assert.Equal(t, send(2, "get e f", "bogus"), "END\r\nERROR\r\n", "expired and unknown")
+	assert.Equal(t, send(1, "version"), "VERSION ldbserver\r\n", "version")
+}
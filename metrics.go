@@ -0,0 +1,285 @@
+package ldbserver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// latencyBuckets are the upper bounds in seconds of the request latency
+// histogram buckets.
+var latencyBuckets = []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1, 5}
+
+// Metrics collects server counters and serves them in the Prometheus text
+// exposition format. One Metrics may be shared by a DBServer and several
+// NetworkServers.
+type Metrics struct {
+	mu         sync.Mutex
+	requests   map[requestLabels]uint64
+	latency    map[TransportRequest_Command]*histogram
+	networks   map[string]*networkMetrics
+	collectors []func(io.Writer)
+}
+
+type requestLabels struct {
+	command TransportRequest_Command
+	status  TransportResponse_Status
+}
+
+type histogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+type networkMetrics struct {
+	active   int64
+	received uint64
+	sent     uint64
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests: make(map[requestLabels]uint64),
+		latency:  make(map[TransportRequest_Command]*histogram),
+		networks: make(map[string]*networkMetrics),
+	}
+}
+
+// AddCollector registers a function writing additional metrics on every
+// scrape.
+func (m *Metrics) AddCollector(f func(io.Writer)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.collectors = append(m.collectors, f)
+}
+
+func (m *Metrics) observeRequest(cmd TransportRequest_Command, status TransportResponse_Status, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestLabels{cmd, status}]++
+	h, ok := m.latency[cmd]
+	if !ok {
+		h = &histogram{buckets: make([]uint64, len(latencyBuckets))}
+		m.latency[cmd] = h
+	}
+	sec := d.Seconds()
+	for i, le := range latencyBuckets {
+		if sec <= le {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += sec
+}
+
+func (m *Metrics) network(name string) *networkMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	nm, ok := m.networks[name]
+	if !ok {
+		nm = new(networkMetrics)
+		m.networks[name] = nm
+	}
+	return nm
+}
+
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.WriteText(w)
+}
+
+// WriteText writes all metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	m.mu.Lock()
+
+	fmt.Fprintln(bw, "# HELP ldbserver_requests_total Requests by command and response status.")
+	fmt.Fprintln(bw, "# TYPE ldbserver_requests_total counter")
+	labels := make([]requestLabels, 0, len(m.requests))
+	for l := range m.requests {
+		labels = append(labels, l)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].command != labels[j].command {
+			return labels[i].command < labels[j].command
+		}
+		return labels[i].status < labels[j].status
+	})
+	for _, l := range labels {
+		fmt.Fprintf(bw, "ldbserver_requests_total{command=%q,status=%q} %d\n", l.command.String(), l.status.String(), m.requests[l])
+	}
+
+	fmt.Fprintln(bw, "# HELP ldbserver_request_duration_seconds Request latency by command.")
+	fmt.Fprintln(bw, "# TYPE ldbserver_request_duration_seconds histogram")
+	cmds := make([]TransportRequest_Command, 0, len(m.latency))
+	for cmd := range m.latency {
+		cmds = append(cmds, cmd)
+	}
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i] < cmds[j] })
+	for _, cmd := range cmds {
+		h := m.latency[cmd]
+		for i, le := range latencyBuckets {
+			fmt.Fprintf(bw, "ldbserver_request_duration_seconds_bucket{command=%q,le=%q} %d\n", cmd.String(), strconv.FormatFloat(le, 'g', -1, 64), h.buckets[i])
+		}
+		fmt.Fprintf(bw, "ldbserver_request_duration_seconds_bucket{command=%q,le=\"+Inf\"} %d\n", cmd.String(), h.count)
+		fmt.Fprintf(bw, "ldbserver_request_duration_seconds_sum{command=%q} %g\n", cmd.String(), h.sum)
+		fmt.Fprintf(bw, "ldbserver_request_duration_seconds_count{command=%q} %d\n", cmd.String(), h.count)
+	}
+
+	names := make([]string, 0, len(m.networks))
+	for name := range m.networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, metric := range []struct {
+		name, help, typ string
+		value           func(*networkMetrics) int64
+	}{
+		{"ldbserver_connections_active", "Open client connections by network.", "gauge",
+			func(nm *networkMetrics) int64 { return atomic.LoadInt64(&nm.active) }},
+		{"ldbserver_received_bytes_total", "Bytes read from clients by network.", "counter",
+			func(nm *networkMetrics) int64 { return int64(atomic.LoadUint64(&nm.received)) }},
+		{"ldbserver_sent_bytes_total", "Bytes written to clients by network.", "counter",
+			func(nm *networkMetrics) int64 { return int64(atomic.LoadUint64(&nm.sent)) }},
+	} {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.typ)
+		for _, name := range names {
+			fmt.Fprintf(bw, "%s{network=%q} %d\n", metric.name, name, metric.value(m.networks[name]))
+		}
+	}
+
+	collectors := m.collectors
+	m.mu.Unlock()
+
+	for _, f := range collectors {
+		f(bw)
+	}
+	return bw.Flush()
+}
+
+// statusTransporter remembers the status of the last response sent.
+type statusTransporter struct {
+	Transporter
+	status TransportResponse_Status
+}
+
+func (st *statusTransporter) SendResponse(resp *TransportResponse) error {
+	st.status = resp.GetStatus()
+	return st.Transporter.SendResponse(resp)
+}
+
+// metricsListener counts the connections and the traffic of a listener.
+type metricsListener struct {
+	net.Listener
+	nm *networkMetrics
+}
+
+func (ml metricsListener) Accept() (net.Conn, error) {
+	c, err := ml.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddInt64(&ml.nm.active, 1)
+	return &metricsConn{Conn: c, nm: ml.nm}, nil
+}
+
+type metricsConn struct {
+	net.Conn
+	nm   *networkMetrics
+	once sync.Once
+}
+
+func (mc *metricsConn) Read(b []byte) (int, error) {
+	n, err := mc.Conn.Read(b)
+	atomic.AddUint64(&mc.nm.received, uint64(n))
+	return n, err
+}
+
+func (mc *metricsConn) Write(b []byte) (int, error) {
+	n, err := mc.Conn.Write(b)
+	atomic.AddUint64(&mc.nm.sent, uint64(n))
+	return n, err
+}
+
+func (mc *metricsConn) Close() error {
+	mc.once.Do(func() {
+		atomic.AddInt64(&mc.nm.active, -1)
+	})
+	return mc.Conn.Close()
+}
+
+// leveldbProperties writes the statistics reported by DB.GetProperty.
+func leveldbProperties(w io.Writer, getProperty func(string) (string, error)) {
+	if stats, err := getProperty("leveldb.stats"); err == nil {
+		type level struct {
+			level                     int
+			tables                    int64
+			size, time, read, written float64
+		}
+		var levels []level
+		for _, line := range strings.Split(stats, "\n") {
+			var l level
+			if n, _ := fmt.Sscanf(strings.Replace(line, "|", " ", -1), "%d %d %f %f %f %f", &l.level, &l.tables, &l.size, &l.time, &l.read, &l.written); n == 6 {
+				levels = append(levels, l)
+			}
+		}
+		for _, metric := range []struct {
+			name, help, typ string
+			value           func(level) string
+		}{
+			{"leveldb_level_tables", "Tables by level.", "gauge",
+				func(l level) string { return strconv.FormatInt(l.tables, 10) }},
+			{"leveldb_level_size_bytes", "Size of tables by level.", "gauge",
+				func(l level) string { return fmt.Sprintf("%g", l.size*1048576) }},
+			{"leveldb_level_compaction_seconds_total", "Time spent compacting by level.", "counter",
+				func(l level) string { return fmt.Sprintf("%g", l.time) }},
+			{"leveldb_level_compaction_read_bytes_total", "Bytes read by compactions by level.", "counter",
+				func(l level) string { return fmt.Sprintf("%g", l.read*1048576) }},
+			{"leveldb_level_compaction_written_bytes_total", "Bytes written by compactions by level.", "counter",
+				func(l level) string { return fmt.Sprintf("%g", l.written*1048576) }},
+		} {
+			fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.typ)
+			for _, l := range levels {
+				fmt.Fprintf(w, "%s{level=\"%d\"} %s\n", metric.name, l.level, metric.value(l))
+			}
+		}
+	}
+
+	if iostats, err := getProperty("leveldb.iostats"); err == nil {
+		var read, written float64
+		if n, _ := fmt.Sscanf(iostats, "Read(MB):%f Write(MB):%f", &read, &written); n == 2 {
+			fmt.Fprintf(w, "# HELP leveldb_io_read_bytes_total Bytes read from storage.\n# TYPE leveldb_io_read_bytes_total counter\nleveldb_io_read_bytes_total %g\n", read*1048576)
+			fmt.Fprintf(w, "# HELP leveldb_io_written_bytes_total Bytes written to storage.\n# TYPE leveldb_io_written_bytes_total counter\nleveldb_io_written_bytes_total %g\n", written*1048576)
+		}
+	}
+
+	// compcount is reported by newer goleveldb versions only
+	if compcount, err := getProperty("leveldb.compcount"); err == nil {
+		fmt.Fprintln(w, "# HELP leveldb_compactions_total Compactions by type.")
+		fmt.Fprintln(w, "# TYPE leveldb_compactions_total counter")
+		for _, field := range strings.Fields(compcount) {
+			if kv := strings.SplitN(field, ":", 2); len(kv) == 2 {
+				fmt.Fprintf(w, "leveldb_compactions_total{type=%q} %s\n", strings.ToLower(strings.TrimSuffix(kv[0], "Comp")), kv[1])
+			}
+		}
+	}
+
+	for _, gauge := range []struct{ name, property, help string }{
+		{"leveldb_alive_snapshots", "leveldb.alivesnaps", "Snapshots not released."},
+		{"leveldb_alive_iterators", "leveldb.aliveiters", "Iterators not released."},
+		{"leveldb_opened_tables", "leveldb.openedtables", "Tables in the open files cache."},
+	} {
+		if value, err := getProperty(gauge.property); err == nil {
+			fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n", gauge.name, gauge.help, gauge.name, gauge.name, value)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package ldbserver
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+
+	tempdir := os.TempDir()
+	path := filepath.Join(tempdir, fmt.Sprintf("goleveldb-test-metrics%d0%d", os.Getuid(), os.Getpid()))
+	db, err := NewLevelDbServer(path)
+	if assert.NoError(t, err, "NewLevelDbServer") {
+		defer func() {
+			db.Close()
+			os.RemoveAll(path)
+		}()
+
+		m := NewMetrics()
+		db.SetMetrics(m)
+		m.network("tcp").active = 2
+
+		key := []byte("hello")
+		serveCommand(t, db, TransportRequest_PUT, key, []byte("world"), MarshalingTypeJson, true)
+		serveCommand(t, db, TransportRequest_GET, key, nil, MarshalingTypeJson, true)
+		serveCommand(t, db, TransportRequest_DELETE, key, nil, MarshalingTypeJson, true)
+		serveCommand(t, db, TransportRequest_GET, key, nil, MarshalingTypeJson, false)
+
+		buf := bytes.NewBuffer(nil)
+		assert.NoError(t, m.WriteText(buf), "WriteText")
+		text := buf.String()
+
+		for _, line := range []string{
+			`ldbserver_requests_total{command="GET",status="OK"} 1`,
+			`ldbserver_requests_total{command="GET",status="NOT_FOUND"} 1`,
+			`ldbserver_requests_total{command="PUT",status="OK"} 1`,
+			`ldbserver_request_duration_seconds_bucket{command="GET",le="+Inf"} 2`,
+			`ldbserver_request_duration_seconds_count{command="DELETE"} 1`,
+			`ldbserver_connections_active{network="tcp"} 2`,
+			`# TYPE leveldb_io_read_bytes_total counter`,
+			`# TYPE leveldb_alive_snapshots gauge`,
+		} {
+			assert.Contains(t, text, line+"\n", "Metrics")
+		}
+	}
+}
+
+func TestLeveldbProperties(t *testing.T) {
+	props := map[string]string{
+		"leveldb.stats": "Compactions\n" +
+			" Level |   Tables   |    Size(MB)   |    Time(sec)  |    Read(MB)   |   Write(MB)\n" +
+			"-------+------------+---------------+---------------+---------------+---------------\n" +
+			"   0   |          3 |       0.50000 |       0.25000 |       0.00000 |       1.00000\n",
+		"leveldb.iostats":   "Read(MB):2.00000 Write(MB):0.50000",
+		"leveldb.compcount": "MemComp:4 Level0Comp:1 NonLevel0Comp:0 SeekComp:0",
+	}
+	buf := bytes.NewBuffer(nil)
+	leveldbProperties(buf, func(name string) (string, error) {
+		if v, ok := props[name]; ok {
+			return v, nil
+		}
+		return "", fmt.Errorf("no property %s", name)
+	})
+	text := buf.String()
+
+	for _, line := range []string{
+		`leveldb_level_tables{level="0"} 3`,
+		`leveldb_level_size_bytes{level="0"} 524288`,
+		`leveldb_level_compaction_written_bytes_total{level="0"} 1.048576e+06`,
+		`leveldb_io_read_bytes_total 2.097152e+06`,
+		`leveldb_compactions_total{type="mem"} 4`,
+		`leveldb_compactions_total{type="level0"} 1`,
+	} {
+		assert.Contains(t, text, line+"\n", "Properties")
+	}
+}
@@ -2,61 +2,234 @@ package ldbserver
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"errors"
 	"io"
 	"net"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/govlas/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
+// DefaultConnWorkers is the default number of requests served concurrently
+// on one stream connection.
+const DefaultConnWorkers = 16
+
+// DefaultShutdownTimeout is the default time Stop lets in-flight requests
+// finish before connections are closed.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// Listener is one address a NetworkServer listens on. Requests read from it
+// are decoded by Transport, or by the factory given to ListenAndServe if
+// Transport is nil. Net is unix, tcp, http, grpc, which serves the Ldb
+// service, or resp and memcache, which are tcp sockets speaking the protocols
+// of Redis and memcached by default.
+type Listener struct {
+	Net       string
+	Host      string
+	Transport TransporterFactory
+}
+
 type NetworkServer struct {
-	netName string
-	host    string
-	stop    chan int
+	listeners []Listener
+	stop      chan int
+	stopOnce  sync.Once
+	workers   int
+	tlsConfig *tls.Config
+	metrics   *Metrics
+	grpcOpts  []grpc.ServerOption
+
+	shutdownTimeout time.Duration
+	mu              sync.Mutex
+	conns           map[net.Conn]struct{}
+	draining        bool
+	connWg          sync.WaitGroup
 }
 
 func checkNetworkName(n string) bool {
 	switch n {
-	case "unix", "tcp", "http":
+	case "unix", "tcp", "http", "grpc", "resp", "memcache":
 		return true
 	default:
 		return false
 	}
 }
 
+// foreignProtocol returns the transporter factory of the foreign protocol
+// spoken on network n, or nil.
+func foreignProtocol(n string) TransporterFactory {
+	switch n {
+	case "resp":
+		return RespTransportFactory{}
+	case "memcache":
+		return MemcacheTransportFactory{}
+	default:
+		return nil
+	}
+}
+
 func NewNetworkServer(nName string, host string) *NetworkServer {
+	return NewMultiNetworkServer(Listener{Net: nName, Host: host})
+}
+
+// NewMultiNetworkServer returns a server listening on all of listeners at once.
+func NewMultiNetworkServer(listeners ...Listener) *NetworkServer {
 	ret := new(NetworkServer)
-	ret.netName = nName
-	ret.host = host
+	ret.listeners = listeners
 	ret.stop = make(chan int)
+	ret.workers = DefaultConnWorkers
+	ret.shutdownTimeout = DefaultShutdownTimeout
+	ret.conns = make(map[net.Conn]struct{})
 	return ret
 }
 
+// SetConnWorkers sets the number of requests served concurrently on one
+// stream connection. Responses to pipelined requests may be sent out of order.
+func (serv *NetworkServer) SetConnWorkers(n int) {
+	if n < 1 {
+		n = 1
+	}
+	serv.workers = n
+}
+
+// SetTLSConfig makes the server accept only TLS connections.
+func (serv *NetworkServer) SetTLSConfig(cfg *tls.Config) {
+	serv.tlsConfig = cfg
+}
+
+// SetGrpcOptions adds opts, such as interceptors, to the options of the gRPC
+// servers of grpc listeners.
+func (serv *NetworkServer) SetGrpcOptions(opts ...grpc.ServerOption) {
+	serv.grpcOpts = opts
+}
+
+// SetMetrics makes the server count its connections and traffic in m.
+func (serv *NetworkServer) SetMetrics(m *Metrics) {
+	serv.metrics = m
+}
+
+// SetShutdownTimeout sets the time Stop lets in-flight requests finish before
+// connections are closed.
+func (serv *NetworkServer) SetShutdownTimeout(d time.Duration) {
+	serv.shutdownTimeout = d
+}
+
+// Stop makes ListenAndServe stop accepting connections and stop reading new
+// requests. ListenAndServe returns when the requests in flight are answered,
+// or when the shutdown timeout expires and the connections are closed.
 func (serv *NetworkServer) Stop() {
-	close(serv.stop)
+	serv.stopOnce.Do(func() {
+		close(serv.stop)
+	})
 }
 
+func (serv *NetworkServer) stopping() bool {
+	select {
+	case <-serv.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// ListenAndServe serves db on every listener of the server until Stop is
+// called or one of the listeners fails; then the others are stopped too.
 func (serv *NetworkServer) ListenAndServe(db DBServer, tf TransporterFactory) error {
-	var network string
-	if serv.netName == "http" {
-		network = "tcp"
-	} else {
-		network = serv.netName
+	var (
+		lns  = make([]net.Listener, 0, len(serv.listeners))
+		slns = make([]*stoppableListener, 0, len(serv.listeners))
+	)
+	defer func() {
+		for _, ln := range lns {
+			ln.Close()
+		}
+	}()
+	for _, l := range serv.listeners {
+		sln, ln, err := serv.listen(l)
+		if err != nil {
+			return err
+		}
+		slns = append(slns, sln)
+		lns = append(lns, ln)
 	}
-	oln, err := net.Listen(network, serv.host)
-	if err != nil {
-		return err
+	if len(lns) == 0 {
+		return errors.New("no listeners")
 	}
-	ln := newStoppableListener(oln)
-	defer ln.Close()
+	// waits for Stop only once every socket is open, so that a failed listen
+	// leaves no goroutine behind
 	go func() {
 		<-serv.stop
-		close(ln.stop)
+		for _, sln := range slns {
+			close(sln.stop)
+		}
 	}()
 
-	switch serv.netName {
-	case "unix", "tcp":
+	errs := make(chan error, len(lns))
+	for i, l := range serv.listeners {
+		ltf := l.Transport
+		if ltf == nil {
+			ltf = foreignProtocol(l.Net)
+		}
+		if ltf == nil {
+			ltf = tf
+		}
+		go func(ln net.Listener, netName string, tf TransporterFactory) {
+			errs <- serv.serve(ln, netName, db, tf)
+		}(lns[i], l.Net, ltf)
+	}
+
+	ret := ErrStopped
+	for range lns {
+		if err := <-errs; err != ErrStopped && ret == ErrStopped {
+			ret = err
+			serv.Stop()
+		}
+	}
+	serv.drain()
+	return ret
+}
+
+// listen opens the socket of l. Accept on the returned listener fails with
+// ErrStopped once the stop channel of the stoppable listener is closed.
+func (serv *NetworkServer) listen(l Listener) (*stoppableListener, net.Listener, error) {
+	if !checkNetworkName(l.Net) {
+		return nil, nil, errors.New("unsupported network " + l.Net)
+	}
+	network := l.Net
+	if network == "http" || network == "grpc" || foreignProtocol(network) != nil {
+		network = "tcp"
+	}
+	oln, err := net.Listen(network, l.Host)
+	if err != nil {
+		return nil, nil, err
+	}
+	sln := newStoppableListener(oln)
+
+	var ln net.Listener = sln
+	if serv.metrics != nil {
+		ln = metricsListener{ln, serv.metrics.network(l.Net)}
+	}
+	// gRPC servers do their own handshakes to negotiate http/2
+	if serv.tlsConfig != nil && l.Net != "grpc" {
+		ln = tls.NewListener(ln, serv.tlsConfig)
+	}
+	return sln, ln, nil
+}
+
+func (serv *NetworkServer) serve(ln net.Listener, netName string, db DBServer, tf TransporterFactory) error {
+	switch netName {
+	case "unix", "tcp", "resp", "memcache":
+		// clients of foreign protocols expect the commands of a connection
+		// to run in order
+		workers := serv.workers
+		if foreignProtocol(netName) != nil {
+			workers = 1
+		}
 		for {
 			conn, err := ln.Accept()
 			if err != nil {
@@ -67,24 +240,18 @@ func (serv *NetworkServer) ListenAndServe(db DBServer, tf TransporterFactory) er
 				continue
 			}
 
-			go func(conn net.Conn) {
-				defer conn.Close()
-				tr := tf.NewTransporter(conn, conn)
-				for {
-					err := db.serve(tr)
-					if err != nil {
-						if err != io.EOF {
-							logger.Warning("warning on read/write stream socket: %v", err)
-						}
-						break
-					}
-				}
-			}(conn)
+			serv.trackConn(conn)
+			serv.connWg.Add(1)
+			go func() {
+				defer serv.connWg.Done()
+				defer serv.untrackConn(conn)
+				serv.serveConn(conn, db, tf, workers)
+			}()
 		}
 	case "http":
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		envelope := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			buf := bytes.NewBuffer(nil)
-			tr := tf.NewTransporter(r.Body, buf)
+			tr := tokenTransporter{tf.NewTransporter(r.Body, buf), bearerToken(r)}
 			err := db.serve(tr)
 
 			if err != nil {
@@ -95,9 +262,161 @@ func (serv *NetworkServer) ListenAndServe(db DBServer, tf TransporterFactory) er
 			}
 
 		})
-		s := http.Server{Handler: handler}
-		return s.Serve(ln)
+		mux := http.NewServeMux()
+		mux.Handle(RestKeysPath, NewRestHandler(db))
+		mux.Handle(EnvelopePath, envelope)
+		// clients predating EnvelopePath post to the root
+		mux.Handle("/", envelope)
+		s := http.Server{Handler: mux}
+
+		shutdown := make(chan struct{})
+		go func() {
+			defer close(shutdown)
+			<-serv.stop
+			ctx, cancel := context.WithTimeout(context.Background(), serv.shutdownTimeout)
+			defer cancel()
+			if err := s.Shutdown(ctx); err != nil {
+				logger.Warning("closing http connections: %v", err)
+				s.Close()
+			}
+		}()
+
+		err := s.Serve(ln)
+		if serv.stopping() {
+			<-shutdown
+			return ErrStopped
+		}
+		return err
+	case "grpc":
+		opts := append([]grpc.ServerOption(nil), serv.grpcOpts...)
+		if serv.tlsConfig != nil {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(serv.tlsConfig)))
+		}
+		s := NewGrpcServer(db, opts...)
+
+		shutdown := make(chan struct{})
+		go func() {
+			defer close(shutdown)
+			<-serv.stop
+			stopped := make(chan struct{})
+			go func() {
+				s.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-time.After(serv.shutdownTimeout):
+				logger.Warning("closing grpc connections after shutdown timeout")
+				s.Stop()
+			}
+		}()
+
+		err := s.Serve(ln)
+		if serv.stopping() {
+			<-shutdown
+			return ErrStopped
+		}
+		return err
 	default:
 		return errors.New("unsupported network")
 	}
 }
+
+func (serv *NetworkServer) trackConn(conn net.Conn) {
+	serv.mu.Lock()
+	defer serv.mu.Unlock()
+	serv.conns[conn] = struct{}{}
+	if serv.draining {
+		conn.SetReadDeadline(time.Now())
+	}
+}
+
+func (serv *NetworkServer) untrackConn(conn net.Conn) {
+	serv.mu.Lock()
+	defer serv.mu.Unlock()
+	delete(serv.conns, conn)
+}
+
+// drain stops reading requests from the stream connections and waits for the
+// requests in flight. Connections still open after the shutdown timeout are
+// closed.
+func (serv *NetworkServer) drain() {
+	serv.mu.Lock()
+	serv.draining = true
+	if len(serv.conns) != 0 {
+		logger.Info("draining %d connections", len(serv.conns))
+	}
+	for conn := range serv.conns {
+		conn.SetReadDeadline(time.Now())
+	}
+	serv.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		serv.connWg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return
+	case <-time.After(serv.shutdownTimeout):
+	}
+
+	serv.mu.Lock()
+	logger.Warning("closing %d connections after shutdown timeout", len(serv.conns))
+	for conn := range serv.conns {
+		conn.Close()
+	}
+	serv.mu.Unlock()
+	<-done
+}
+
+// serveConn reads pipelined requests from conn and answers them from a pool of
+// workers goroutines.
+func (serv *NetworkServer) serveConn(conn net.Conn, db DBServer, tf TransporterFactory, workers int) {
+	defer conn.Close()
+
+	var (
+		sess = newSession()
+		tr   = &syncTransporter{Transporter: tf.NewTransporter(conn, conn)}
+		sem  = make(chan struct{}, workers)
+		wg   sync.WaitGroup
+		once sync.Once
+	)
+	warn := func(err error) {
+		once.Do(func() {
+			if err != io.EOF && !serv.stopping() {
+				logger.Warning("warning on read/write stream socket: %v", err)
+			}
+		})
+	}
+
+	for {
+		req, err := tr.GetRequest()
+		if err == ErrChecksumMismatch {
+			if err = tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_CHECKSUM_MISMATCH, err))); err == nil {
+				continue
+			}
+		}
+		if err != nil {
+			warn(err)
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(req *TransportRequest) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := db.handle(sess, tr, req); err != nil {
+				warn(err)
+				conn.Close()
+			}
+		}(req)
+	}
+	sess.finish()
+	wg.Wait()
+	sess.close()
+}
@@ -0,0 +1,174 @@
+package ldbserver
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+// slowServer answers every request once release is closed.
+type slowServer struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *slowServer) serve(tr Transporter) error {
+	return nil
+}
+
+func (s *slowServer) handle(sess *session, tr Transporter, req *TransportRequest) error {
+	s.started <- struct{}{}
+	<-s.release
+	return tr.SendResponse(answer(req, &TransportResponse{Status: TransportResponse_OK.Enum()}))
+}
+
+func (s *slowServer) Close() {}
+
+func TestNetworkServerShutdown(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ldbserver-shutdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	start := func(name string, timeout time.Duration) (*NetworkServer, *slowServer, net.Conn, chan error) {
+		sock := filepath.Join(dir, name)
+		db := &slowServer{make(chan struct{}, 1), make(chan struct{})}
+		ns := NewNetworkServer("unix", sock)
+		ns.SetShutdownTimeout(timeout)
+		done := make(chan error, 1)
+		go func() {
+			done <- ns.ListenAndServe(db, JsonProtobufTransportFactory{Mt: MarshalingTypeProtobuf})
+		}()
+
+		var conn net.Conn
+		for i := 0; i < 50; i++ {
+			if conn, err = net.Dial("unix", sock); err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := &TransportRequest{Command: TransportRequest_GET.Enum(), Key: []byte("key"), Seq: proto.Uint64(1)}
+		if err := pio.NewUint32DelimitedWriter(conn, binary.LittleEndian).WriteMsg(req); err != nil {
+			t.Fatal(err)
+		}
+		<-db.started
+		return ns, db, conn, done
+	}
+
+	ns, db, conn, done := start("drain.sock", time.Minute)
+	defer conn.Close()
+	ns.Stop()
+	select {
+	case <-done:
+		t.Fatal("ListenAndServe returned with a request in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(db.release)
+
+	resp := &TransportResponse{}
+	err = pio.NewUint32DelimitedReader(conn, binary.LittleEndian, 1024).ReadMsg(resp)
+	if assert.NoError(t, err, "in-flight response") {
+		assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "in-flight response")
+		assert.Equal(t, resp.GetSeq(), uint64(1), "in-flight response")
+	}
+	select {
+	case err := <-done:
+		assert.Equal(t, err, ErrStopped, "ListenAndServe")
+	case <-time.After(3 * time.Second):
+		t.Fatal("ListenAndServe did not return after drain")
+	}
+
+	ns, db, conn, done = start("timeout.sock", 100*time.Millisecond)
+	defer conn.Close()
+	ns.Stop()
+	// the listener notices Stop within a second
+	conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Equal(t, err, io.EOF, "connection closed after shutdown timeout")
+	close(db.release)
+	select {
+	case err := <-done:
+		assert.Equal(t, err, ErrStopped, "ListenAndServe")
+	case <-time.After(3 * time.Second):
+		t.Fatal("ListenAndServe did not return after shutdown timeout")
+	}
+}
+
+func TestNetworkServerListeners(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ldbserver-listeners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db := &slowServer{make(chan struct{}, 2), make(chan struct{})}
+	close(db.release)
+	pbSock, jsonSock := filepath.Join(dir, "pb.sock"), filepath.Join(dir, "json.sock")
+	ns := NewMultiNetworkServer(
+		Listener{Net: "unix", Host: pbSock},
+		Listener{Net: "unix", Host: jsonSock, Transport: JsonProtobufTransportFactory{Mt: MarshalingTypeJson}},
+	)
+	done := make(chan error, 1)
+	go func() {
+		done <- ns.ListenAndServe(db, JsonProtobufTransportFactory{Mt: MarshalingTypeProtobuf})
+	}()
+
+	dial := func(sock string) net.Conn {
+		for i := 0; i < 50; i++ {
+			if conn, err := net.Dial("unix", sock); err == nil {
+				return conn
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatal("dial " + sock)
+		return nil
+	}
+	req := &TransportRequest{Command: TransportRequest_GET.Enum(), Key: []byte("key"), Seq: proto.Uint64(1)}
+
+	conn := dial(pbSock)
+	defer conn.Close()
+	resp := &TransportResponse{}
+	err = pio.NewUint32DelimitedWriter(conn, binary.LittleEndian).WriteMsg(req)
+	if assert.NoError(t, err, "protobuf request") {
+		err = pio.NewUint32DelimitedReader(conn, binary.LittleEndian, 1024).ReadMsg(resp)
+		if assert.NoError(t, err, "protobuf response") {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "protobuf response")
+		}
+	}
+
+	conn = dial(jsonSock)
+	defer conn.Close()
+	resp = &TransportResponse{}
+	err = json.NewEncoder(conn).Encode(req)
+	if assert.NoError(t, err, "json request") {
+		err = json.NewDecoder(conn).Decode(resp)
+		if assert.NoError(t, err, "json response") {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "json response")
+		}
+	}
+
+	ns.Stop()
+	select {
+	case err := <-done:
+		assert.Equal(t, err, ErrStopped, "ListenAndServe")
+	case <-time.After(3 * time.Second):
+		t.Fatal("ListenAndServe did not return after Stop")
+	}
+
+	ns = NewMultiNetworkServer(Listener{Net: "unix", Host: pbSock}, Listener{Net: "udp", Host: "127.0.0.1:0"})
+	assert.Error(t, ns.ListenAndServe(db, JsonProtobufTransportFactory{Mt: MarshalingTypeProtobuf}), "unsupported network")
+}
@@ -0,0 +1,87 @@
+package ldbserver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb/filter"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// LevelDBOptions tunes the goleveldb databases of a server. Zero values keep
+// the goleveldb defaults.
+type LevelDBOptions struct {
+	// BlockCacheMB is the size of the cache of uncompressed blocks.
+	BlockCacheMB int
+	// WriteBufferMB is the size of the memtable written to disk at once.
+	WriteBufferMB int
+	// BloomFilterBits enables bloom filters with that many bits per key.
+	BloomFilterBits int
+	// Compression of tables: "snappy" or "none".
+	Compression string
+	// MaxOpenFiles is the number of tables kept open.
+	MaxOpenFiles int
+}
+
+// maxBloomFilterBits bounds BloomFilterBits; more bits hardly lower the false
+// positive rate.
+const maxBloomFilterBits = 64
+
+// Validate reports the first invalid option.
+func (o LevelDBOptions) Validate() error {
+	switch {
+	case o.BlockCacheMB < 0:
+		return errors.New("block cache size must not be negative")
+	case o.WriteBufferMB < 0:
+		return errors.New("write buffer size must not be negative")
+	case o.BloomFilterBits < 0 || o.BloomFilterBits > maxBloomFilterBits:
+		return fmt.Errorf("bloom filter bits must be between 0 and %d", maxBloomFilterBits)
+	case o.MaxOpenFiles < 0:
+		return errors.New("max open files must not be negative")
+	}
+	switch o.Compression {
+	case "", "snappy", "none":
+	default:
+		return fmt.Errorf("unknown compression %q", o.Compression)
+	}
+	return nil
+}
+
+// Merge returns o with its zero values taken from defaults.
+func (o LevelDBOptions) Merge(defaults LevelDBOptions) LevelDBOptions {
+	if o.BlockCacheMB == 0 {
+		o.BlockCacheMB = defaults.BlockCacheMB
+	}
+	if o.WriteBufferMB == 0 {
+		o.WriteBufferMB = defaults.WriteBufferMB
+	}
+	if o.BloomFilterBits == 0 {
+		o.BloomFilterBits = defaults.BloomFilterBits
+	}
+	if len(o.Compression) == 0 {
+		o.Compression = defaults.Compression
+	}
+	if o.MaxOpenFiles == 0 {
+		o.MaxOpenFiles = defaults.MaxOpenFiles
+	}
+	return o
+}
+
+// Options converts o to goleveldb options. It does not validate o.
+func (o LevelDBOptions) Options() *opt.Options {
+	ret := &opt.Options{
+		BlockCacheCapacity:     o.BlockCacheMB * opt.MiB,
+		WriteBuffer:            o.WriteBufferMB * opt.MiB,
+		OpenFilesCacheCapacity: o.MaxOpenFiles,
+	}
+	if o.BloomFilterBits > 0 {
+		ret.Filter = filter.NewBloomFilter(o.BloomFilterBits)
+	}
+	switch o.Compression {
+	case "snappy":
+		ret.Compression = opt.SnappyCompression
+	case "none":
+		ret.Compression = opt.NoCompression
+	}
+	return ret
+}
@@ -0,0 +1,48 @@
+package ldbserver
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+func TestLevelDBOptions(t *testing.T) {
+	assert.NoError(t, LevelDBOptions{}.Validate(), "Zero options")
+	for _, o := range []LevelDBOptions{
+		{BlockCacheMB: -1},
+		{WriteBufferMB: -1},
+		{BloomFilterBits: -1},
+		{BloomFilterBits: maxBloomFilterBits + 1},
+		{MaxOpenFiles: -1},
+		{Compression: "zstd"},
+	} {
+		assert.Error(t, o.Validate(), fmt.Sprintf("%+v", o))
+	}
+
+	o := LevelDBOptions{BlockCacheMB: 64, BloomFilterBits: 10, Compression: "none"}
+	assert.NoError(t, o.Validate(), "Validate")
+	lo := o.Options()
+	assert.Equal(t, lo.BlockCacheCapacity, 64*opt.MiB, "Block cache")
+	assert.Equal(t, lo.GetWriteBuffer(), opt.DefaultWriteBuffer, "Default write buffer")
+	assert.Equal(t, lo.Compression, opt.NoCompression, "Compression")
+	assert.NotNil(t, lo.Filter, "Bloom filter")
+
+	merged := LevelDBOptions{WriteBufferMB: 16, Compression: "snappy"}.Merge(o)
+	assert.Equal(t, merged, LevelDBOptions{BlockCacheMB: 64, WriteBufferMB: 16, BloomFilterBits: 10, Compression: "snappy"}, "Merge")
+
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("goleveldb-test-options%d0%d", os.Getuid(), os.Getpid()))
+	db, err := NewLevelDbServerOptions(path, merged.Options())
+	if assert.NoError(t, err, "NewLevelDbServerOptions") {
+		defer func() {
+			db.Close()
+			os.RemoveAll(path)
+		}()
+		if resp := serveCommand(t, db, TransportRequest_PUT, []byte("k"), []byte("v"), MarshalingTypeProtobuf, true); resp != nil {
+			assert.Equal(t, resp.GetStatus(), TransportResponse_OK, "Put")
+		}
+	}
+}
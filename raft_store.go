@@ -0,0 +1,174 @@
+package ldbserver
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var (
+	raftLogPrefix    = []byte("l")
+	raftStablePrefix = []byte("s")
+
+	// errRaftKeyNotFound has the message raft expects from a StableStore
+	// missing a key
+	errRaftKeyNotFound = errors.New("not found")
+	errBadRaftLog      = errors.New("corrupt raft log entry")
+)
+
+// raftStore keeps the raft log and the raft state of a node in a leveldb.
+// Log entries are stored under their big-endian index, so that iteration
+// follows the log.
+type raftStore struct {
+	db *leveldb.DB
+}
+
+func openRaftStore(path string) (*raftStore, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &raftStore{db: db}, nil
+}
+
+func (rs *raftStore) Close() error {
+	return rs.db.Close()
+}
+
+func raftLogKey(index uint64) []byte {
+	key := make([]byte, len(raftLogPrefix)+8)
+	copy(key, raftLogPrefix)
+	binary.BigEndian.PutUint64(key[len(raftLogPrefix):], index)
+	return key
+}
+
+func (rs *raftStore) FirstIndex() (uint64, error) {
+	it := rs.db.NewIterator(util.BytesPrefix(raftLogPrefix), nil)
+	defer it.Release()
+	if !it.First() {
+		return 0, it.Error()
+	}
+	return binary.BigEndian.Uint64(it.Key()[len(raftLogPrefix):]), nil
+}
+
+func (rs *raftStore) LastIndex() (uint64, error) {
+	it := rs.db.NewIterator(util.BytesPrefix(raftLogPrefix), nil)
+	defer it.Release()
+	if !it.Last() {
+		return 0, it.Error()
+	}
+	return binary.BigEndian.Uint64(it.Key()[len(raftLogPrefix):]), nil
+}
+
+func (rs *raftStore) GetLog(index uint64, log *raft.Log) error {
+	data, err := rs.db.Get(raftLogKey(index), nil)
+	if err == leveldb.ErrNotFound {
+		return raft.ErrLogNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return decodeRaftLog(data, log)
+}
+
+func (rs *raftStore) StoreLog(log *raft.Log) error {
+	return rs.StoreLogs([]*raft.Log{log})
+}
+
+func (rs *raftStore) StoreLogs(logs []*raft.Log) error {
+	batch := new(leveldb.Batch)
+	for _, log := range logs {
+		batch.Put(raftLogKey(log.Index), encodeRaftLog(log))
+	}
+	return rs.db.Write(batch, &opt.WriteOptions{Sync: true})
+}
+
+func (rs *raftStore) DeleteRange(min, max uint64) error {
+	batch := new(leveldb.Batch)
+	it := rs.db.NewIterator(&util.Range{Start: raftLogKey(min), Limit: raftLogKey(max + 1)}, nil)
+	for it.Next() {
+		batch.Delete(append([]byte(nil), it.Key()...))
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return rs.db.Write(batch, &opt.WriteOptions{Sync: true})
+}
+
+func (rs *raftStore) Set(key, value []byte) error {
+	return rs.db.Put(append(append([]byte(nil), raftStablePrefix...), key...), value, &opt.WriteOptions{Sync: true})
+}
+
+func (rs *raftStore) Get(key []byte) ([]byte, error) {
+	value, err := rs.db.Get(append(append([]byte(nil), raftStablePrefix...), key...), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, errRaftKeyNotFound
+	}
+	return value, err
+}
+
+func (rs *raftStore) SetUint64(key []byte, value uint64) error {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, value)
+	return rs.Set(key, b)
+}
+
+func (rs *raftStore) GetUint64(key []byte) (uint64, error) {
+	b, err := rs.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(b) != 8 {
+		return 0, errBadRaftLog
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// A log entry is encoded as index, term and append time as big-endian
+// uint64, the type byte, the length of the data as big-endian uint32, the
+// data and the extensions.
+const raftLogHeaderSize = 8 + 8 + 8 + 1 + 4
+
+func encodeRaftLog(log *raft.Log) []byte {
+	b := make([]byte, raftLogHeaderSize, raftLogHeaderSize+len(log.Data)+len(log.Extensions))
+	binary.BigEndian.PutUint64(b[0:], log.Index)
+	binary.BigEndian.PutUint64(b[8:], log.Term)
+	var appended int64
+	if !log.AppendedAt.IsZero() {
+		appended = log.AppendedAt.UnixNano()
+	}
+	binary.BigEndian.PutUint64(b[16:], uint64(appended))
+	b[24] = byte(log.Type)
+	binary.BigEndian.PutUint32(b[25:], uint32(len(log.Data)))
+	b = append(b, log.Data...)
+	return append(b, log.Extensions...)
+}
+
+func decodeRaftLog(b []byte, log *raft.Log) error {
+	if len(b) < raftLogHeaderSize {
+		return errBadRaftLog
+	}
+	n := int(binary.BigEndian.Uint32(b[25:]))
+	if len(b) < raftLogHeaderSize+n {
+		return errBadRaftLog
+	}
+	log.Index = binary.BigEndian.Uint64(b[0:])
+	log.Term = binary.BigEndian.Uint64(b[8:])
+	log.AppendedAt = time.Time{}
+	if appended := int64(binary.BigEndian.Uint64(b[16:])); appended != 0 {
+		log.AppendedAt = time.Unix(0, appended)
+	}
+	log.Type = raft.LogType(b[24])
+	log.Data = b[raftLogHeaderSize : raftLogHeaderSize+n]
+	log.Extensions = nil
+	if ext := b[raftLogHeaderSize+n:]; len(ext) != 0 {
+		log.Extensions = ext
+	}
+	return nil
+}
@@ -0,0 +1,403 @@
+package ldbserver
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/govlas/logger"
+)
+
+const (
+	// DefaultReplicaRetry is the time a replica waits before reconnecting.
+	DefaultReplicaRetry = time.Second
+	// replicaReadTimeout bounds the wait for the next response of the
+	// primary, which sends heartbeats while idle.
+	replicaReadTimeout = 10 * tailHeartbeat
+	// maxReplicationMessageSize bounds the responses read from the primary.
+	maxReplicationMessageSize = 64 * 1024 * 1024
+)
+
+var (
+	errTailEnded      = errors.New("primary ended the log stream")
+	errReplicaLoading = errors.New("replica is loading a snapshot of its primary")
+)
+
+// Replica copies the default database of a primary. It fetches a snapshot,
+// then applies the log of the primary as it grows. After a reconnect it
+// resumes from the last applied entry, unless the primary restarted or no
+// longer has that entry; it then fetches a new snapshot.
+type Replica struct {
+	network, host string
+	codec         Codec
+	tlsConfig     *tls.Config
+	token         string
+	retry         time.Duration
+
+	db   *database
+	st   Storage
+	stop chan struct{}
+	done chan struct{}
+
+	mu         sync.Mutex
+	conn       net.Conn
+	stopped    bool
+	connected  bool
+	logId      string
+	applied    uint64
+	primarySeq uint64
+	// caughtUp is the last time the replica had applied the whole log
+	caughtUp time.Time
+	// loading is set from the start of a resync until a snapshot is loaded
+	loading bool
+}
+
+// NewReplica returns a replica of the primary listening at host with the
+// marshaling type mt.
+func NewReplica(network, host string, mt MarshalingType) *Replica {
+	return &Replica{
+		network: network,
+		host:    host,
+		codec:   mt.Codec(),
+		retry:   DefaultReplicaRetry,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// SetCodec makes the replica talk to the primary in the format of c instead
+// of its marshaling type.
+func (r *Replica) SetCodec(c Codec) {
+	r.codec = c
+}
+
+// SetTLSConfig makes the replica connect to the primary over TLS.
+func (r *Replica) SetTLSConfig(cfg *tls.Config) {
+	r.tlsConfig = cfg
+}
+
+// SetToken sets the token sent to a primary with an ACL.
+func (r *Replica) SetToken(token string) {
+	r.token = token
+}
+
+// SetRetry sets the time the replica waits before reconnecting.
+func (r *Replica) SetRetry(d time.Duration) {
+	r.retry = d
+}
+
+// ReplicateFrom makes the default database a copy of the primary of r. The
+// server rejects writes from then on. It must be called before serving.
+func (s *leveldbServer) ReplicateFrom(r *Replica) {
+	s.mu.Lock()
+	s.replica = r
+	s.mu.Unlock()
+	r.db = s.db
+	r.st = s.db.st
+	r.caughtUp = time.Now()
+	go r.run()
+}
+
+// Stop disconnects the replica from its primary.
+func (r *Replica) Stop() {
+	r.mu.Lock()
+	if !r.stopped {
+		r.stopped = true
+		close(r.stop)
+		if r.conn != nil {
+			r.conn.Close()
+		}
+	}
+	r.mu.Unlock()
+	<-r.done
+}
+
+// Status reports the position and lag of the replica.
+func (r *Replica) Status() *ReplicationStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lag := 0.0
+	if !r.connected || r.applied < r.primarySeq || len(r.logId) == 0 {
+		lag = time.Since(r.caughtUp).Seconds()
+	}
+	return &ReplicationStatus{
+		Role:       proto.String("replica"),
+		Primary:    proto.String(r.host),
+		Connected:  proto.Bool(r.connected),
+		LogId:      proto.String(r.logId),
+		LogSeq:     proto.Uint64(r.applied),
+		PrimarySeq: proto.Uint64(r.primarySeq),
+		LagSeconds: proto.Float64(lag),
+	}
+}
+
+func (r *Replica) run() {
+	defer close(r.done)
+	for {
+		err := r.replicate()
+		r.mu.Lock()
+		r.connected = false
+		r.conn = nil
+		r.mu.Unlock()
+		select {
+		case <-r.stop:
+			return
+		default:
+		}
+		logger.Warning("replication from %s: %v", r.host, err)
+		select {
+		case <-r.stop:
+			return
+		case <-time.After(r.retry):
+		}
+	}
+}
+
+// replicate follows the primary until the connection fails.
+func (r *Replica) replicate() error {
+	if r.codec == nil {
+		return errUnsupportedCodec
+	}
+	var (
+		conn net.Conn
+		err  error
+	)
+	dialer := &net.Dialer{Timeout: replicaReadTimeout}
+	if r.tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, r.network, r.host, r.tlsConfig)
+	} else {
+		conn, err = dialer.Dial(r.network, r.host)
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	r.mu.Lock()
+	if r.stopped {
+		r.mu.Unlock()
+		return nil
+	}
+	r.conn = conn
+	r.connected = true
+	logId := r.logId
+	r.mu.Unlock()
+
+	codec := r.codec
+	if _, ok := codec.(protobufCodec); ok {
+		// log entries of large batches outgrow the usual bound
+		codec = protobufCodec{maxSize: maxReplicationMessageSize}
+	}
+	c := &replicaConn{conn: conn, enc: codec.NewEncoder(conn), dec: codec.NewDecoder(conn)}
+	if len(logId) != 0 {
+		if err := r.tail(c); err != errLogPosition {
+			return err
+		}
+		logger.Info("replica of %s lost its log position, fetching a snapshot", r.host)
+	}
+	if err := r.resync(c); err != nil {
+		return err
+	}
+	return r.tail(c)
+}
+
+// resync replaces the storage with a snapshot of the primary. Requests to
+// the default database fail while it is loaded.
+func (r *Replica) resync(c *replicaConn) error {
+	r.mu.Lock()
+	r.logId = ""
+	r.applied = 0
+	r.mu.Unlock()
+
+	err := c.send(&TransportRequest{
+		Command: TransportRequest_REPL_SNAPSHOT.Enum(),
+		Token:   r.optionalToken(),
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := c.receive()
+	if err != nil {
+		return err
+	}
+	if !resp.GetMore() || resp.LogId == nil {
+		return errors.New("bad snapshot response")
+	}
+	logId, seq := resp.GetLogId(), resp.GetLogSeq()
+
+	// the requests reading the old keys finish first; later ones fail until
+	// a snapshot is loaded, even if this one is not
+	r.db.mu.Lock()
+	r.mu.Lock()
+	r.loading = true
+	r.mu.Unlock()
+	r.db.mu.Unlock()
+	if err := clearStorage(r.st); err != nil {
+		return err
+	}
+	for more := true; more; {
+		if resp, err = c.receive(); err != nil {
+			return err
+		}
+		more = resp.GetMore()
+		ops := make([]Operation, 0, len(resp.Pairs))
+		for _, pair := range resp.Pairs {
+			if !CheckBody(pair.Value) {
+				return ErrChecksumMismatch
+			}
+			ops = append(ops, Operation{Key: pair.Key, Value: pair.Value.GetData()})
+		}
+		if err := r.st.Batch(ops, !more); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	r.logId = logId
+	r.applied = seq
+	r.loading = false
+	r.mu.Unlock()
+	return nil
+}
+
+// resyncing reports whether the storage holds a partial snapshot.
+func (r *Replica) resyncing() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.loading
+}
+
+// tail applies the log of the primary following the last applied entry.
+func (r *Replica) tail(c *replicaConn) error {
+	r.mu.Lock()
+	logId, seq := r.logId, r.applied
+	r.mu.Unlock()
+
+	err := c.send(&TransportRequest{
+		Command: TransportRequest_REPL_TAIL.Enum(),
+		Token:   r.optionalToken(),
+		LogId:   proto.String(logId),
+		LogSeq:  proto.Uint64(seq),
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := c.receive()
+		if err != nil {
+			return err
+		}
+		for _, entry := range resp.Entries {
+			if entry.GetSeq() != seq+1 {
+				return fmt.Errorf("log entry %d follows %d", entry.GetSeq(), seq)
+			}
+			ops, err := entryOperations(entry)
+			if err != nil {
+				return err
+			}
+			if err := r.st.Batch(ops, false); err != nil {
+				return err
+			}
+			seq = entry.GetSeq()
+		}
+
+		r.mu.Lock()
+		r.applied = seq
+		r.primarySeq = resp.GetLogSeq()
+		if r.applied >= r.primarySeq {
+			r.caughtUp = time.Now()
+		}
+		r.mu.Unlock()
+
+		if !resp.GetMore() {
+			return errTailEnded
+		}
+	}
+}
+
+func (r *Replica) optionalToken() *string {
+	if len(r.token) == 0 {
+		return nil
+	}
+	return proto.String(r.token)
+}
+
+func entryOperations(entry *LogEntry) ([]Operation, error) {
+	ops := make([]Operation, 0, len(entry.Ops))
+	for _, op := range entry.Ops {
+		if !CheckBody(op.Body) {
+			return nil, ErrChecksumMismatch
+		}
+		ops = append(ops, Operation{
+			Key:    op.GetKey(),
+			Value:  op.Body.GetData(),
+			Delete: op.GetCommand() == TransportRequest_DELETE,
+		})
+	}
+	return ops, nil
+}
+
+// storageEmpty reports whether st holds no keys but its layout version.
+func storageEmpty(st Storage) (bool, error) {
+	it := st.Iterate(layoutEnd, nil)
+	defer it.Release()
+	empty := !it.First()
+	return empty, it.Error()
+}
+
+// clearStorage deletes all keys of st but its layout version.
+func clearStorage(st Storage) error {
+	it := st.Iterate(layoutEnd, nil)
+	defer it.Release()
+	ops := make([]Operation, 0, restoreBatchSize)
+	for ok := it.First(); ok; ok = it.Next() {
+		ops = append(ops, Operation{Key: append([]byte(nil), it.Key()...), Delete: true})
+		if len(ops) == restoreBatchSize {
+			if err := st.Batch(ops, false); err != nil {
+				return err
+			}
+			ops = ops[:0]
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return st.Batch(ops, false)
+}
+
+// replicaConn exchanges messages with the primary.
+type replicaConn struct {
+	conn net.Conn
+	enc  Encoder
+	dec  Decoder
+}
+
+func (c *replicaConn) send(req *TransportRequest) error {
+	return c.enc.Encode(req)
+}
+
+// receive reads the next response and fails unless it is OK. A
+// CONDITION_FAILED response means the log position is gone.
+func (c *replicaConn) receive() (*TransportResponse, error) {
+	c.conn.SetReadDeadline(time.Now().Add(replicaReadTimeout))
+	resp := &TransportResponse{}
+	if err := c.dec.Decode(resp); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	switch resp.GetStatus() {
+	case TransportResponse_OK:
+		return resp, nil
+	case TransportResponse_CONDITION_FAILED:
+		return nil, errLogPosition
+	default:
+		return nil, fmt.Errorf("primary answered %s: %s", resp.GetStatus(), resp.Body.GetData())
+	}
+}
@@ -0,0 +1,283 @@
+package ldbserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// DefaultReplicationLogSize is the default number of entries kept by the
+// replication log of a primary. Replicas further behind fetch a new snapshot.
+const DefaultReplicationLogSize = 100000
+
+const (
+	// tailBatchSize is the maximum number of log entries sent in one response.
+	tailBatchSize = 100
+	// tailHeartbeat is the interval of empty responses sent to idle replicas,
+	// so that they can tell their lag.
+	tailHeartbeat = time.Second
+)
+
+var (
+	errNoReplicationLog = errors.New("replication log disabled")
+	errLogPosition      = errors.New("log position not available, fetch a snapshot")
+	errReadOnly         = errors.New("writes are not accepted by replicas")
+)
+
+// replicationLog keeps the last mutations of the default database in memory.
+// Its id changes on every start, so replicas of an earlier run fetch a new
+// snapshot.
+type replicationLog struct {
+	id string
+
+	mu      sync.Mutex
+	entries []*LogEntry
+	size    int
+	// entries[start] is the oldest entry; last is the sequence of the newest
+	last    uint64
+	start   int
+	changed chan struct{}
+}
+
+func newReplicationLog(size int) *replicationLog {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return &replicationLog{
+		id:      hex.EncodeToString(b),
+		size:    size,
+		changed: make(chan struct{}),
+	}
+}
+
+func (l *replicationLog) append(ops []Operation) {
+	entry := &LogEntry{Ops: make([]*TransportOperation, 0, len(ops))}
+	for _, op := range ops {
+		top := &TransportOperation{Key: op.Key}
+		if op.Delete {
+			top.Command = TransportRequest_DELETE.Enum()
+		} else {
+			top.Command = TransportRequest_PUT.Enum()
+			top.Body = &TransportBody{Data: op.Value}
+			SetBodyChecksum(top.Body)
+		}
+		entry.Ops = append(entry.Ops, top)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.last++
+	entry.Seq = proto.Uint64(l.last)
+	if len(l.entries) < l.size {
+		l.entries = append(l.entries, entry)
+	} else {
+		l.entries[l.start] = entry
+		l.start = (l.start + 1) % l.size
+	}
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// position returns the sequence of the newest entry.
+func (l *replicationLog) position() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.last
+}
+
+// since returns up to max entries following seq and a channel closed by the
+// next append. It fails if the entries following seq were dropped.
+func (l *replicationLog) since(seq uint64, max int) ([]*LogEntry, uint64, <-chan struct{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	first := l.last - uint64(len(l.entries)) + 1
+	if seq > l.last || seq+1 < first {
+		return nil, l.last, nil, errLogPosition
+	}
+	n := int(l.last - seq)
+	if n > max {
+		n = max
+	}
+	ret := make([]*LogEntry, 0, n)
+	for i := int(seq + 1 - first); len(ret) < n; i++ {
+		ret = append(ret, l.entries[(l.start+i)%len(l.entries)])
+	}
+	return ret, l.last, l.changed, nil
+}
+
+// loggedStorage appends every write to a replication log. The server holds
+// the key locks around each write, so writes to the same key are logged in the
+// order they are applied.
+type loggedStorage struct {
+	Storage
+	log *replicationLog
+}
+
+func (s *loggedStorage) Put(key, value []byte, sync bool) error {
+	if err := s.Storage.Put(key, value, sync); err != nil {
+		return err
+	}
+	s.log.append([]Operation{{Key: key, Value: value}})
+	return nil
+}
+
+func (s *loggedStorage) Delete(key []byte, sync bool) error {
+	if err := s.Storage.Delete(key, sync); err != nil {
+		return err
+	}
+	s.log.append([]Operation{{Key: key, Delete: true}})
+	return nil
+}
+
+func (s *loggedStorage) Batch(ops []Operation, sync bool) error {
+	if err := s.Storage.Batch(ops, sync); err != nil {
+		return err
+	}
+	if len(ops) != 0 {
+		s.log.append(ops)
+	}
+	return nil
+}
+
+// EnableReplicationLog makes the server a primary keeping the last size
+// mutations of its default database for replicas. It must be called before
+// serving.
+func (s *leveldbServer) EnableReplicationLog(size int) {
+	if size < 1 {
+		size = DefaultReplicationLogSize
+	}
+	s.replLog = newReplicationLog(size)
+	// the expiry sweeper may be using the storage
+	s.db.mu.Lock()
+	s.db.st = &loggedStorage{Storage: s.db.st, log: s.replLog}
+	s.db.mu.Unlock()
+}
+
+// isWrite reports whether cmd changes the database.
+func isWrite(cmd TransportRequest_Command) bool {
+	switch cmd {
+	case TransportRequest_PUT, TransportRequest_DELETE, TransportRequest_BATCH,
+		TransportRequest_CAS, TransportRequest_PUT_IF_ABSENT, TransportRequest_DELETE_IF_EQUAL,
+		TransportRequest_PERSIST, TransportRequest_REPLACE, TransportRequest_RESTORE, TransportRequest_DB_CREATE, TransportRequest_DB_DROP:
+		return true
+	default:
+		return false
+	}
+}
+
+// replSnapshot streams a snapshot of the default database preceded by its
+// log position.
+func (s *leveldbServer) replSnapshot(d *database, tr Transporter, req *TransportRequest) error {
+	if s.replLog == nil || d != s.db {
+		return tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_BAD_REQUEST, errNoReplicationLog)))
+	}
+	// writes logged up to seq are applied before it is read, so the snapshot
+	// holds them; later entries may be in it too and are applied again
+	seq := s.replLog.position()
+	snap, err := d.st.Snapshot()
+	if err != nil {
+		return tr.SendResponse(answer(req, makeDbErrorResponse(err)))
+	}
+	defer snap.Release()
+
+	err = tr.SendResponse(answer(req, &TransportResponse{
+		Status: TransportResponse_OK.Enum(),
+		More:   proto.Bool(true),
+		LogId:  proto.String(s.replLog.id),
+		LogSeq: proto.Uint64(seq),
+	}))
+	if err != nil {
+		return err
+	}
+	it := snap.Iterate(nil, nil)
+	defer it.Release()
+	return sendPairs(tr, req, it, 0, false)
+}
+
+// replTail streams the log entries following the position of req until the
+// connection is closed. Every response has More set.
+func (s *leveldbServer) replTail(sess *session, d *database, tr Transporter, req *TransportRequest) error {
+	if s.replLog == nil || d != s.db {
+		return tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_BAD_REQUEST, errNoReplicationLog)))
+	}
+	if sess == nil {
+		return tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_BAD_REQUEST, errNoSession)))
+	}
+	if req.GetLogId() != s.replLog.id {
+		return tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_CONDITION_FAILED, errLogPosition)))
+	}
+
+	seq := req.GetLogSeq()
+	heartbeat := time.NewTicker(tailHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		entries, last, changed, err := s.replLog.since(seq, tailBatchSize)
+		if err != nil {
+			return tr.SendResponse(answer(req, MakeErrorResponse(TransportResponse_CONDITION_FAILED, err)))
+		}
+		if len(entries) != 0 {
+			seq = entries[len(entries)-1].GetSeq()
+			resp := &TransportResponse{
+				Status:  TransportResponse_OK.Enum(),
+				More:    proto.Bool(true),
+				LogSeq:  proto.Uint64(last),
+				Entries: entries,
+			}
+			if err := tr.SendResponse(answer(req, resp)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		select {
+		case <-changed:
+		case <-heartbeat.C:
+			resp := &TransportResponse{Status: TransportResponse_OK.Enum(), More: proto.Bool(true), LogSeq: proto.Uint64(last)}
+			if err := tr.SendResponse(answer(req, resp)); err != nil {
+				return err
+			}
+		case <-sess.done():
+			return nil
+		}
+	}
+}
+
+// replStatus reports the replication state of the server.
+func (s *leveldbServer) replStatus() *TransportResponse {
+	status := &ReplicationStatus{}
+	if s.replica != nil {
+		status = s.replica.Status()
+	} else if s.replLog != nil {
+		seq := s.replLog.position()
+		status.Role = proto.String("primary")
+		status.LogId = proto.String(s.replLog.id)
+		status.LogSeq = proto.Uint64(seq)
+		status.PrimarySeq = proto.Uint64(seq)
+	}
+	return &TransportResponse{Status: TransportResponse_OK.Enum(), Replication: status}
+}
+
+// replicationMetrics writes the position of a primary or the lag of a replica.
+func (s *leveldbServer) replicationMetrics(w io.Writer) {
+	if s.replica != nil {
+		status := s.replica.Status()
+		connected, behind := 0, uint64(0)
+		if status.GetConnected() {
+			connected = 1
+		}
+		if status.GetPrimarySeq() > status.GetLogSeq() {
+			behind = status.GetPrimarySeq() - status.GetLogSeq()
+		}
+		fmt.Fprintf(w, "# HELP ldbserver_replica_connected Whether the replica is connected to its primary.\n# TYPE ldbserver_replica_connected gauge\nldbserver_replica_connected %d\n", connected)
+		fmt.Fprintf(w, "# HELP ldbserver_replica_applied_seq Last log entry applied by the replica.\n# TYPE ldbserver_replica_applied_seq gauge\nldbserver_replica_applied_seq %d\n", status.GetLogSeq())
+		fmt.Fprintf(w, "# HELP ldbserver_replica_lag_entries Log entries of the primary not applied yet.\n# TYPE ldbserver_replica_lag_entries gauge\nldbserver_replica_lag_entries %d\n", behind)
+		fmt.Fprintf(w, "# HELP ldbserver_replica_lag_seconds Time since the replica had applied the whole log.\n# TYPE ldbserver_replica_lag_seconds gauge\nldbserver_replica_lag_seconds %g\n", status.GetLagSeconds())
+	} else if s.replLog != nil {
+		fmt.Fprintf(w, "# HELP ldbserver_replication_log_seq Last entry of the replication log.\n# TYPE ldbserver_replication_log_seq gauge\nldbserver_replication_log_seq %d\n", s.replLog.position())
+	}
+}
@@ -0,0 +1,177 @@
+package ldbserver
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicationLog(t *testing.T) {
+	l := newReplicationLog(3)
+	for i := 0; i < 5; i++ {
+		l.append([]Operation{{Key: []byte(fmt.Sprint(i)), Value: []byte("v")}})
+	}
+	assert.Equal(t, l.position(), uint64(5), "position")
+
+	_, _, _, err := l.since(1, 10)
+	assert.Equal(t, err, errLogPosition, "dropped entries")
+	_, _, _, err = l.since(6, 10)
+	assert.Equal(t, err, errLogPosition, "future entries")
+
+	entries, last, _, err := l.since(2, 10)
+	if assert.NoError(t, err, "since") && assert.Len(t, entries, 3, "since") {
+		assert.Equal(t, entries[0].GetSeq(), uint64(3), "first entry")
+		assert.Equal(t, entries[0].Ops[0].Key, []byte("2"), "first entry")
+		assert.Equal(t, last, uint64(5), "last")
+	}
+	entries, _, _, _ = l.since(2, 2)
+	assert.Len(t, entries, 2, "since with max")
+
+	entries, _, changed, err := l.since(5, 10)
+	assert.NoError(t, err, "since last")
+	assert.Len(t, entries, 0, "since last")
+	l.append([]Operation{{Key: []byte("x"), Delete: true}})
+	select {
+	case <-changed:
+	default:
+		t.Error("append did not signal the change")
+	}
+}
+
+func TestReplication(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	serve := func(db DBServer) (*NetworkServer, chan error) {
+		ns := NewNetworkServer("tcp", addr)
+		done := make(chan error, 1)
+		go func() {
+			done <- ns.ListenAndServe(db, JsonProtobufTransportFactory{Mt: MarshalingTypeProtobuf})
+		}()
+		return ns, done
+	}
+	stop := func(ns *NetworkServer, done chan error) {
+		ns.Stop()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("network server did not stop")
+		}
+	}
+	wait := func(r *Replica, seq uint64) {
+		for i := 0; i < 500; i++ {
+			if st := r.Status(); st.GetConnected() && st.GetLogSeq() == seq {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("replica did not reach %d: %v", seq, r.Status())
+	}
+	get := func(db DBServer, key string) TransportResponse_Status {
+		return serveCommand(t, db, TransportRequest_GET, []byte(key), nil, MarshalingTypeJson, false).GetStatus()
+	}
+
+	primary := NewServer(NewMemStorage())
+	defer primary.Close()
+	primary.EnableReplicationLog(100)
+	serveCommand(t, primary, TransportRequest_PUT, []byte("a"), []byte("1"), MarshalingTypeJson, true)
+	serveCommand(t, primary, TransportRequest_PUT, []byte("b"), []byte("2"), MarshalingTypeJson, true)
+
+	ns, done := serve(primary)
+	replicaDb := NewServer(NewMemStorage())
+	defer replicaDb.Close()
+	// stale keys are removed by the snapshot
+	replicaDb.db.st.Put([]byte("stale"), []byte("x"), false)
+	r := NewReplica("tcp", addr, MarshalingTypeProtobuf)
+	r.SetRetry(50 * time.Millisecond)
+	replicaDb.ReplicateFrom(r)
+
+	wait(r, 2)
+	assert.Equal(t, get(replicaDb, "a"), TransportResponse_OK, "snapshot")
+	assert.Equal(t, get(replicaDb, "stale"), TransportResponse_NOT_FOUND, "snapshot")
+	resp := serveCommand(t, replicaDb, TransportRequest_PUT, []byte("c"), []byte("3"), MarshalingTypeJson, false)
+	assert.Equal(t, resp.GetStatus(), TransportResponse_READ_ONLY, "write to replica")
+
+	serveCommand(t, primary, TransportRequest_PUT, []byte("c"), []byte("3"), MarshalingTypeJson, true)
+	serveCommand(t, primary, TransportRequest_DELETE, []byte("a"), nil, MarshalingTypeJson, true)
+	wait(r, 4)
+	assert.Equal(t, get(replicaDb, "a"), TransportResponse_NOT_FOUND, "tail delete")
+	assert.Equal(t, get(replicaDb, "c"), TransportResponse_OK, "tail put")
+	logId := r.Status().GetLogId()
+
+	status := handleRequest(t, primary, nil, &TransportRequest{Command: TransportRequest_REPL_STATUS.Enum()})
+	assert.Equal(t, status.Replication.GetRole(), "primary", "primary status")
+	assert.Equal(t, status.Replication.GetLogId(), logId, "primary status")
+	status = handleRequest(t, replicaDb, nil, &TransportRequest{Command: TransportRequest_REPL_STATUS.Enum()})
+	assert.Equal(t, status.Replication.GetRole(), "replica", "replica status")
+	assert.Equal(t, status.Replication.GetPrimarySeq(), uint64(4), "replica status")
+
+	// a short disconnect resumes from the last applied entry
+	stop(ns, done)
+	serveCommand(t, primary, TransportRequest_PUT, []byte("d"), []byte("4"), MarshalingTypeJson, true)
+	ns, done = serve(primary)
+	wait(r, 5)
+	assert.Equal(t, get(replicaDb, "d"), TransportResponse_OK, "resumed")
+	assert.Equal(t, r.Status().GetLogId(), logId, "resumed")
+
+	// a replica further behind than the log fetches a new snapshot
+	stop(ns, done)
+	for i := 0; i < 150; i++ {
+		serveCommand(t, primary, TransportRequest_PUT, []byte(fmt.Sprint("e", i)), []byte("5"), MarshalingTypeJson, true)
+	}
+	ns, done = serve(primary)
+	defer stop(ns, done)
+	wait(r, 155)
+	assert.Equal(t, get(replicaDb, "e149"), TransportResponse_OK, "resynced")
+	assert.Equal(t, get(replicaDb, "a"), TransportResponse_NOT_FOUND, "resynced")
+}
+
+func TestReplicaResyncing(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	// the primary sends the position of its snapshot but none of its pairs
+	release := make(chan struct{})
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		codec := MarshalingTypeProtobuf.Codec()
+		if err := codec.NewDecoder(conn).Decode(&TransportRequest{}); err != nil {
+			return
+		}
+		codec.NewEncoder(conn).Encode(&TransportResponse{Status: TransportResponse_OK.Enum(), More: proto.Bool(true), LogId: proto.String("log"), LogSeq: proto.Uint64(1)})
+		<-release
+	}()
+
+	replicaDb := NewServer(NewMemStorage())
+	defer replicaDb.Close()
+	serveCommand(t, replicaDb, TransportRequest_PUT, []byte("a"), []byte("1"), MarshalingTypeJson, true)
+	r := NewReplica("tcp", ln.Addr().String(), MarshalingTypeProtobuf)
+	r.SetRetry(time.Minute)
+	replicaDb.ReplicateFrom(r)
+
+	get := func() TransportResponse_Status {
+		return serveCommand(t, replicaDb, TransportRequest_GET, []byte("a"), nil, MarshalingTypeJson, false).GetStatus()
+	}
+	for i := 0; i < 500 && get() == TransportResponse_OK; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, get(), TransportResponse_INTERNAL, "read during resync")
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, get(), TransportResponse_INTERNAL, "read after a failed resync")
+}
@@ -0,0 +1,462 @@
+package ldbserver
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+const (
+	// maxRespArgs and maxRespBulkSize bound the commands read from redis
+	// clients.
+	maxRespArgs     = 1024 * 1024
+	maxRespBulkSize = 64 * 1024 * 1024
+	// respScanCount is the number of keys returned by SCAN without COUNT.
+	respScanCount = 10
+	// maxRespCursors bounds the SCAN cursors kept by a connection; the
+	// oldest ones are forgotten first.
+	maxRespCursors = 1024
+)
+
+var (
+	errRespProtocol = errors.New("Protocol error")
+	errRespCursor   = errors.New("invalid cursor")
+)
+
+// RespTransportFactory speaks the Redis protocol, RESP. GET, SET, DEL,
+// EXISTS, MGET and SCAN are translated to requests of the server; PING, AUTH
+// and QUIT are answered by the transporter.
+type RespTransportFactory struct{}
+
+func (RespTransportFactory) NewTransporter(r io.Reader, w io.Writer) Transporter {
+	t := &respTransporter{
+		textTransporter: newTextTransporter(r, w),
+		cursors:         make(map[uint64][]byte),
+	}
+	t.read = t.readCommand
+	return t
+}
+
+type respTransporter struct {
+	textTransporter
+	// cursors maps the SCAN cursors of the connection to the next key
+	cursors    map[uint64][]byte
+	nextCursor uint64
+}
+
+func (t *respTransporter) command(args [][]byte) {
+	name := strings.ToUpper(string(args[0]))
+	args = args[1:]
+	switch name {
+	case "PING":
+		if len(args) == 0 {
+			t.local(respSimple("PONG"))
+		} else {
+			t.local(respBulk(args[0]))
+		}
+	case "AUTH":
+		// the token is checked by the server on every request
+		if len(args) == 0 || len(args) > 2 {
+			t.local(respArgsError(name))
+			return
+		}
+		t.token = proto.String(string(args[len(args)-1]))
+		t.local(respSimple("OK"))
+	case "QUIT":
+		t.quit = true
+		t.local(respSimple("OK"))
+	case "GET":
+		if len(args) != 1 {
+			t.local(respArgsError(name))
+			return
+		}
+		t.queue(func(resps []*TransportResponse) []byte {
+			return respValue(resps[0])
+		}, textRequest(TransportRequest_GET, args[0]))
+	case "MGET":
+		if len(args) == 0 {
+			t.local(respArgsError(name))
+			return
+		}
+		reqs := make([]*TransportRequest, 0, len(args))
+		for _, key := range args {
+			reqs = append(reqs, textRequest(TransportRequest_GET, key))
+		}
+		t.queue(func(resps []*TransportResponse) []byte {
+			buf := respArray(len(resps))
+			for _, resp := range resps {
+				if resp.GetStatus() != TransportResponse_OK {
+					// missing keys and errors are both nil in MGET
+					buf = append(buf, respNull()...)
+				} else {
+					buf = append(buf, respBulk(resp.Body.GetData())...)
+				}
+			}
+			return buf
+		}, reqs...)
+	case "SET":
+		t.set(args)
+	case "DEL", "EXISTS":
+		if len(args) == 0 {
+			t.local(respArgsError(name))
+			return
+		}
+		// TTL finds the live keys without reading their values
+		var reqs []*TransportRequest
+		for _, key := range args {
+			reqs = append(reqs, textRequest(TransportRequest_TTL, key))
+			if name == "DEL" {
+				reqs = append(reqs, textRequest(TransportRequest_DELETE, key))
+			}
+		}
+		step := len(reqs) / len(args)
+		t.queue(func(resps []*TransportResponse) []byte {
+			n := 0
+			for i, resp := range resps {
+				switch {
+				case resp.GetStatus() == TransportResponse_OK:
+					if i%step == 0 {
+						n++
+					}
+				case resp.GetStatus() != TransportResponse_NOT_FOUND || i%step != 0:
+					return respStatusError(resp)
+				}
+			}
+			return respInt(int64(n))
+		}, reqs...)
+	case "SCAN":
+		t.scan(args)
+	default:
+		t.local(respError("ERR", "unknown command '"+name+"'"))
+	}
+}
+
+// set handles SET key value [EX seconds|PX milliseconds] [NX|XX].
+func (t *respTransporter) set(args [][]byte) {
+	if len(args) < 2 {
+		t.local(respArgsError("SET"))
+		return
+	}
+	req := textRequest(TransportRequest_PUT, args[0])
+	req.Body = &TransportBody{Data: args[1]}
+	SetBodyChecksum(req.Body)
+	for i := 2; i < len(args); i++ {
+		opt := strings.ToUpper(string(args[i]))
+		switch {
+		case opt == "NX" && req.GetCommand() == TransportRequest_PUT:
+			req.Command = TransportRequest_PUT_IF_ABSENT.Enum()
+		case opt == "XX" && req.GetCommand() == TransportRequest_PUT:
+			req.Command = TransportRequest_REPLACE.Enum()
+		case (opt == "EX" || opt == "PX") && i+1 < len(args) && req.Ttl == nil:
+			i++
+			n, err := strconv.ParseUint(string(args[i]), 10, 64)
+			if err != nil || n == 0 {
+				t.local(respError("ERR", "invalid expire time in 'set' command"))
+				return
+			}
+			if opt == "EX" {
+				n *= 1000
+			}
+			req.Ttl = proto.Uint64(n)
+		default:
+			t.local(respError("ERR", "syntax error"))
+			return
+		}
+	}
+	t.queue(func(resps []*TransportResponse) []byte {
+		switch resps[0].GetStatus() {
+		case TransportResponse_OK:
+			return respSimple("OK")
+		case TransportResponse_CONDITION_FAILED:
+			return respNull()
+		}
+		return respStatusError(resps[0])
+	}, req)
+}
+
+// scan handles SCAN cursor [MATCH pattern] [COUNT count]. Cursors stand for
+// the next key to scan and are only valid on the connection that got them.
+func (t *respTransporter) scan(args [][]byte) {
+	if len(args) == 0 {
+		t.local(respArgsError("SCAN"))
+		return
+	}
+	var (
+		start   []byte
+		pattern []byte
+		count   = respScanCount
+	)
+	if c, err := strconv.ParseUint(string(args[0]), 10, 64); err != nil {
+		t.local(respError("ERR", errRespCursor.Error()))
+		return
+	} else if c != 0 {
+		key, ok := t.cursors[c]
+		if !ok {
+			t.local(respError("ERR", errRespCursor.Error()))
+			return
+		}
+		delete(t.cursors, c)
+		start = key
+	}
+	for i := 1; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			t.local(respError("ERR", "syntax error"))
+			return
+		}
+		switch strings.ToUpper(string(args[i])) {
+		case "MATCH":
+			pattern = args[i+1]
+		case "COUNT":
+			n, err := strconv.Atoi(string(args[i+1]))
+			if err != nil || n < 1 {
+				t.local(respError("ERR", "syntax error"))
+				return
+			}
+			if n > maxRespArgs {
+				n = maxRespArgs
+			}
+			count = n
+		default:
+			t.local(respError("ERR", "syntax error"))
+			return
+		}
+	}
+
+	// the extra pair is the key the next cursor starts at
+	rng := &TransportRange{Start: start, Limit: proto.Uint32(uint32(count + 1))}
+	if prefix := globPrefix(pattern); len(prefix) != 0 {
+		rng.Prefix = prefix
+	}
+	req := &TransportRequest{Command: TransportRequest_SCAN.Enum(), Range: rng}
+	t.queue(func(resps []*TransportResponse) []byte {
+		if resps[0].GetStatus() != TransportResponse_OK {
+			return respStatusError(resps[0])
+		}
+		pairs := resps[0].Pairs
+		next := []byte("0")
+		if len(pairs) > count {
+			t.nextCursor++
+			t.cursors[t.nextCursor] = pairs[count].Key
+			delete(t.cursors, t.nextCursor-maxRespCursors)
+			next = []byte(strconv.FormatUint(t.nextCursor, 10))
+			pairs = pairs[:count]
+		}
+		keys := make([][]byte, 0, len(pairs))
+		for _, pair := range pairs {
+			if pattern == nil || globMatch(pattern, pair.Key) {
+				keys = append(keys, pair.Key)
+			}
+		}
+		buf := append(respArray(2), respBulk(next)...)
+		buf = append(buf, respArray(len(keys))...)
+		for _, key := range keys {
+			buf = append(buf, respBulk(key)...)
+		}
+		return buf
+	}, req)
+}
+
+// readCommand reads an array of bulk strings, or an inline command split at
+// spaces.
+func (t *respTransporter) readCommand() (func(), error) {
+	args, err := t.readArgs()
+	if err == errRespProtocol || err == errTextLineTooLong {
+		return func() { t.local(respError("ERR", errRespProtocol.Error())) }, err
+	}
+	if err != nil || len(args) == 0 {
+		return nil, err
+	}
+	return func() { t.command(args) }, nil
+}
+
+func (t *respTransporter) readArgs() ([][]byte, error) {
+	line, err := t.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		var args [][]byte
+		for _, f := range strings.Fields(string(line)) {
+			args = append(args, []byte(f))
+		}
+		return args, nil
+	}
+	// like redis, an empty or null array is no command; args grow with the
+	// arguments actually read rather than the count announced
+	n, err := strconv.Atoi(string(line[1:]))
+	if err != nil || n > maxRespArgs {
+		return nil, errRespProtocol
+	}
+	var args [][]byte
+	for i := 0; i < n; i++ {
+		line, err := t.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, errRespProtocol
+		}
+		size, err := strconv.Atoi(string(line[1:]))
+		if err != nil || size < 0 || size > maxRespBulkSize {
+			return nil, errRespProtocol
+		}
+		arg, ok, err := t.readBlock(size)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errRespProtocol
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// respValue replies with the value of a GET, nil if the key is missing.
+func respValue(resp *TransportResponse) []byte {
+	switch resp.GetStatus() {
+	case TransportResponse_OK:
+		return respBulk(resp.Body.GetData())
+	case TransportResponse_NOT_FOUND:
+		return respNull()
+	}
+	return respStatusError(resp)
+}
+
+// respStatusError replies with the error of resp, using the prefixes of redis
+// where they exist.
+func respStatusError(resp *TransportResponse) []byte {
+	msg := string(resp.Body.GetData())
+	switch resp.GetStatus() {
+	case TransportResponse_DENIED:
+		return respError("NOPERM", msg)
+	case TransportResponse_READ_ONLY:
+		return respError("READONLY", msg)
+	}
+	return respError("ERR", resp.GetStatus().String()+" "+msg)
+}
+
+func respArgsError(name string) []byte {
+	return respError("ERR", "wrong number of arguments for '"+strings.ToLower(name)+"' command")
+}
+
+func respSimple(s string) []byte {
+	return []byte("+" + s + "\r\n")
+}
+
+func respError(kind, msg string) []byte {
+	msg = strings.NewReplacer("\r", " ", "\n", " ").Replace(msg)
+	return []byte("-" + kind + " " + msg + "\r\n")
+}
+
+func respInt(n int64) []byte {
+	return []byte(":" + strconv.FormatInt(n, 10) + "\r\n")
+}
+
+func respBulk(b []byte) []byte {
+	buf := make([]byte, 0, len(b)+16)
+	buf = append(buf, '$')
+	buf = strconv.AppendInt(buf, int64(len(b)), 10)
+	buf = append(buf, "\r\n"...)
+	buf = append(buf, b...)
+	return append(buf, "\r\n"...)
+}
+
+func respNull() []byte {
+	return []byte("$-1\r\n")
+}
+
+func respArray(n int) []byte {
+	return []byte("*" + strconv.Itoa(n) + "\r\n")
+}
+
+// globPrefix returns the literal start of a redis glob pattern.
+func globPrefix(pattern []byte) []byte {
+	if i := bytes.IndexAny(pattern, `*?[\`); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
+
+// globMatch matches s against a redis glob pattern: * and ? match any
+// characters and one character, [abc], [^abc] and [a-z] match a set and \
+// escapes the next character. On a mismatch only the last * takes one more
+// character, so matching takes at most len(pattern)*len(s) steps.
+func globMatch(pattern, s []byte) bool {
+	var (
+		star         bool
+		starP, starS []byte
+	)
+	for {
+		if len(pattern) != 0 && pattern[0] == '*' {
+			pattern = pattern[1:]
+			star, starP, starS = true, pattern, s
+			continue
+		}
+		if len(pattern) != 0 && len(s) != 0 {
+			if rest, ok := globOne(pattern, s[0]); ok {
+				pattern, s = rest, s[1:]
+				continue
+			}
+		} else if len(pattern) == 0 && len(s) == 0 {
+			return true
+		}
+		if !star || len(starS) == 0 {
+			return false
+		}
+		starS = starS[1:]
+		pattern, s = starP, starS
+	}
+}
+
+// globOne matches c against the first element of pattern, which is not *,
+// and returns the rest of pattern.
+func globOne(pattern []byte, c byte) ([]byte, bool) {
+	switch pattern[0] {
+	case '?':
+		return pattern[1:], true
+	case '[':
+		ok, rest := globSet(pattern[1:], c)
+		return rest, ok
+	case '\\':
+		if len(pattern) > 1 {
+			pattern = pattern[1:]
+		}
+	}
+	return pattern[1:], pattern[0] == c
+}
+
+// globSet matches c against the set at the start of pattern, following its
+// opening bracket, and returns the rest of the pattern.
+func globSet(pattern []byte, c byte) (bool, []byte) {
+	not := len(pattern) != 0 && pattern[0] == '^'
+	if not {
+		pattern = pattern[1:]
+	}
+	match := false
+	for len(pattern) != 0 && pattern[0] != ']' {
+		switch {
+		case pattern[0] == '\\' && len(pattern) > 1:
+			match = match || pattern[1] == c
+			pattern = pattern[2:]
+		case len(pattern) > 2 && pattern[1] == '-' && pattern[2] != ']':
+			lo, hi := pattern[0], pattern[2]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			match = match || (c >= lo && c <= hi)
+			pattern = pattern[3:]
+		default:
+			match = match || pattern[0] == c
+			pattern = pattern[1:]
+		}
+	}
+	if len(pattern) != 0 {
+		pattern = pattern[1:]
+	}
+	return match != not, pattern
+}
@@ -0,0 +1,135 @@
+package ldbserver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobMatch(t *testing.T) {
+	for _, c := range []struct {
+		pattern, s string
+		match      bool
+	}{
+		{"*", "", true},
+		{"a*", "abc", true},
+		{"a*c", "abbbc", true},
+		{"a*c", "abcd", false},
+		{"a?c", "abc", true},
+		{"a?c", "ac", false},
+		{"[ab]x", "bx", true},
+		{"[^ab]x", "bx", false},
+		{"[a-c]x", "cx", true},
+		{`a\*`, "a*", true},
+		{`a\*`, "ab", false},
+		{"*b", "abab", true},
+		{"a*b*c", "aXbYbc", true},
+		{"a*b?", "abab", false},
+		{"a*b?", "abxbc", true},
+		{"*[0-9]", "key1x", false},
+		{strings.Repeat("a*", 30) + "b", strings.Repeat("a", 100), false},
+	} {
+		assert.Equal(t, globMatch([]byte(c.pattern), []byte(c.s)), c.match, fmt.Sprintf("%q %q", c.pattern, c.s))
+	}
+	assert.Equal(t, globPrefix([]byte("user:*")), []byte("user:"), "globPrefix")
+}
+
+func TestResp(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	db := NewServer(NewMemStorage())
+	defer db.Close()
+	ns := NewNetworkServer("resp", addr)
+	done := make(chan error, 1)
+	go func() {
+		done <- ns.ListenAndServe(db, JsonProtobufTransportFactory{Mt: MarshalingTypeJson})
+	}()
+	defer func() {
+		ns.Stop()
+		<-done
+	}()
+
+	var conn net.Conn
+	for i := 0; i < 50; i++ {
+		if conn, err = net.Dial("tcp", addr); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !assert.NoError(t, err, "net.Dial") {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	// send writes the commands pipelined and reads n reply lines
+	send := func(n int, cmds ...[]string) string {
+		var b strings.Builder
+		for _, args := range cmds {
+			fmt.Fprintf(&b, "*%d\r\n", len(args))
+			for _, arg := range args {
+				fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+			}
+		}
+		_, err := io.WriteString(conn, b.String())
+		assert.NoError(t, err, "write")
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		var out strings.Builder
+		for i := 0; i < n; i++ {
+			line, err := r.ReadString('\n')
+			if !assert.NoError(t, err, "read") {
+				break
+			}
+			out.WriteString(line)
+		}
+		return out.String()
+	}
+
+	assert.Equal(t, send(1, []string{"PING"}), "+PONG\r\n", "PING")
+	assert.Equal(t, send(5,
+		[]string{"SET", "a", "1"},
+		[]string{"SET", "b", "2"},
+		[]string{"SET", "a", "x", "NX"},
+		[]string{"GET", "a"},
+	), "+OK\r\n+OK\r\n$-1\r\n$1\r\n1\r\n", "SET and GET")
+	assert.Equal(t, send(6, []string{"MGET", "a", "c", "b"}), "*3\r\n$1\r\n1\r\n$-1\r\n$1\r\n2\r\n", "MGET")
+	assert.Equal(t, send(1, []string{"EXISTS", "a", "b", "c"}), ":2\r\n", "EXISTS")
+	assert.Equal(t, send(2, []string{"DEL", "a", "c"}, []string{"EXISTS", "a"}), ":1\r\n:0\r\n", "DEL")
+	assert.Equal(t, send(1, []string{"SET", "e", "1", "PX", "1"}), "+OK\r\n", "SET PX")
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, send(1, []string{"GET", "e"}), "$-1\r\n", "GET expired")
+	assert.Equal(t, send(1, []string{"NOPE"}), "-ERR unknown command 'NOPE'\r\n", "unknown command")
+
+	for i := 0; i < 5; i++ {
+		send(1, []string{"SET", fmt.Sprint("k", i), "v"})
+	}
+	assert.Equal(t, send(10, []string{"SCAN", "0", "MATCH", "k*", "COUNT", "3"}), "*2\r\n$1\r\n1\r\n*3\r\n$2\r\nk0\r\n$2\r\nk1\r\n$2\r\nk2\r\n", "SCAN")
+	assert.Equal(t, send(8, []string{"SCAN", "1", "MATCH", "k*", "COUNT", "3"}), "*2\r\n$1\r\n0\r\n*2\r\n$2\r\nk3\r\n$2\r\nk4\r\n", "SCAN next")
+	assert.Equal(t, send(1, []string{"SCAN", "1"}), "-ERR invalid cursor\r\n", "SCAN used cursor")
+	scans := make([][]string, maxRespCursors+1)
+	for i := range scans {
+		scans[i] = []string{"SCAN", "0", "MATCH", "k*", "COUNT", "1"}
+	}
+	send(6*len(scans), scans...)
+	assert.Equal(t, send(1, []string{"SCAN", "2"}), "-ERR invalid cursor\r\n", "SCAN forgotten cursor")
+	assert.Equal(t, send(6, []string{"SCAN", "3", "MATCH", "k*", "COUNT", "1"}), "*2\r\n$4\r\n1027\r\n*1\r\n$2\r\nk1\r\n", "SCAN kept cursor")
+
+	// null arrays are no commands
+	io.WriteString(conn, "*-1\r\n*-9223372036854775808\r\n")
+	assert.Equal(t, send(1, []string{"PING"}), "+PONG\r\n", "null array")
+
+	// inline commands come from telnet
+	io.WriteString(conn, "GET b\r\n")
+	assert.Equal(t, send(2), "$1\r\n2\r\n", "inline")
+}
@@ -0,0 +1,158 @@
+package ldbserver
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// RestKeysPath is the http path of the REST api: GET, HEAD, PUT and
+	// DELETE on RestKeysPath + url-escaped key. The db query parameter names
+	// the database.
+	RestKeysPath = "/keys/"
+	// EnvelopePath is the http path accepting TransportRequest envelopes.
+	EnvelopePath = "/rpc"
+)
+
+type restHandler struct {
+	db DBServer
+}
+
+// NewRestHandler returns the handler of the REST api. Values are sent and
+// returned as raw bodies.
+func NewRestHandler(db DBServer) http.Handler {
+	return &restHandler{db}
+}
+
+func (h *restHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), RestKeysPath))
+	if err != nil || len(key) == 0 {
+		http.Error(w, "bad key in path", http.StatusBadRequest)
+		return
+	}
+
+	req := &TransportRequest{Key: []byte(key), Token: bearerToken(r)}
+	if db := r.URL.Query().Get("db"); len(db) != 0 {
+		req.Database = &db
+	}
+	switch r.Method {
+	case "GET", "HEAD":
+		req.Command = TransportRequest_GET.Enum()
+	case "PUT":
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.Command = TransportRequest_PUT.Enum()
+		req.Body = &TransportBody{Data: data}
+		SetBodyChecksum(req.Body)
+	case "DELETE":
+		req.Command = TransportRequest_DELETE.Enum()
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tr := new(responseRecorder)
+	if err := h.db.handle(nil, tr, req); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp := tr.resp
+
+	if code := restStatus(resp.GetStatus()); code != http.StatusOK {
+		switch resp.GetStatus() {
+		case TransportResponse_NOT_LEADER:
+			// the leader id is the address of its protocol listener, which
+			// may not serve http
+			if leader := resp.GetLeader(); len(leader) != 0 {
+				w.Header().Set("Leader", leader)
+			}
+		case TransportResponse_READ_ONLY:
+			w.Header().Set("Allow", "GET, HEAD")
+		}
+		http.Error(w, string(resp.GetBody().GetData()), code)
+		return
+	}
+	if req.GetCommand() != TransportRequest_GET {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	data := resp.GetBody().GetData()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	if r.Method != "HEAD" {
+		w.Write(data)
+	}
+}
+
+func restStatus(status TransportResponse_Status) int {
+	switch status {
+	case TransportResponse_OK:
+		return http.StatusOK
+	case TransportResponse_NOT_FOUND:
+		return http.StatusNotFound
+	case TransportResponse_BAD_REQUEST, TransportResponse_CHECKSUM_MISMATCH:
+		return http.StatusBadRequest
+	case TransportResponse_CONDITION_FAILED:
+		return http.StatusPreconditionFailed
+	case TransportResponse_DENIED:
+		return http.StatusForbidden
+	case TransportResponse_READ_ONLY:
+		return http.StatusMethodNotAllowed
+	case TransportResponse_NOT_LEADER:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// bearerToken returns the token of the Authorization header, or nil.
+func bearerToken(r *http.Request) *string {
+	return parseBearer(r.Header.Get("Authorization"))
+}
+
+// parseBearer returns the token of a Bearer authorization, or nil.
+func parseBearer(auth string) *string {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(auth, prefix) {
+		return nil
+	}
+	token := strings.TrimPrefix(auth, prefix)
+	return &token
+}
+
+// tokenTransporter sets the token of requests that do not carry their own.
+type tokenTransporter struct {
+	Transporter
+	token *string
+}
+
+func (tt tokenTransporter) GetRequest() (*TransportRequest, error) {
+	req, err := tt.Transporter.GetRequest()
+	if req != nil && req.Token == nil {
+		req.Token = tt.token
+	}
+	return req, err
+}
+
+// responseRecorder is a Transporter keeping the last response of a request
+// handled in process.
+type responseRecorder struct {
+	resp *TransportResponse
+}
+
+func (rr *responseRecorder) GetRequest() (*TransportRequest, error) {
+	return nil, errors.New("responseRecorder: no request to read")
+}
+
+func (rr *responseRecorder) SendResponse(resp *TransportResponse) error {
+	rr.resp = resp
+	return nil
+}
@@ -0,0 +1,76 @@
+package ldbserver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestHandler(t *testing.T) {
+
+	tempdir := os.TempDir()
+	path := filepath.Join(tempdir, fmt.Sprintf("goleveldb-test-rest%d0%d", os.Getuid(), os.Getpid()))
+	db, err := NewLevelDbServer(path)
+	if assert.NoError(t, err, "NewLevelDbServer") {
+		defer func() {
+			db.Close()
+			os.RemoveAll(path)
+		}()
+
+		h := NewRestHandler(db)
+		do := func(method, target, body string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+			return w
+		}
+
+		assert.Equal(t, do("GET", "/keys/a%2Fb", "").Code, http.StatusNotFound, "GET missing")
+		assert.Equal(t, do("HEAD", "/keys/a%2Fb", "").Code, http.StatusNotFound, "HEAD missing")
+		assert.Equal(t, do("PUT", "/keys/a%2Fb", "value").Code, http.StatusNoContent, "PUT")
+
+		w := do("GET", "/keys/a%2Fb", "")
+		assert.Equal(t, w.Code, http.StatusOK, "GET")
+		assert.Equal(t, w.Body.String(), "value", "GET")
+		if resp := serveCommand(t, db, TransportRequest_GET, []byte("a/b"), nil, MarshalingTypeJson, true); resp != nil {
+			assert.Equal(t, resp.Body.Data, []byte("value"), "Escaped key")
+		}
+
+		w = do("HEAD", "/keys/a%2Fb", "")
+		assert.Equal(t, w.Code, http.StatusOK, "HEAD")
+		assert.Equal(t, w.Header().Get("Content-Length"), "5", "HEAD")
+
+		assert.Equal(t, do("POST", "/keys/a%2Fb", "").Code, http.StatusMethodNotAllowed, "POST")
+		assert.Equal(t, do("GET", "/keys/", "").Code, http.StatusBadRequest, "No key")
+		assert.Equal(t, do("DELETE", "/keys/a%2Fb", "").Code, http.StatusNoContent, "DELETE")
+		assert.Equal(t, do("GET", "/keys/a%2Fb", "").Code, http.StatusNotFound, "GET deleted")
+	}
+}
+
+func TestRestHandlerRejectedWrites(t *testing.T) {
+	do := func(h http.Handler, method string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, "/keys/a", strings.NewReader("value")))
+		return w
+	}
+
+	replica := NewServer(NewMemStorage())
+	defer replica.Close()
+	replica.ReplicateFrom(NewReplica("tcp", "127.0.0.1:1", MarshalingTypeJson))
+	w := do(NewRestHandler(replica), "PUT")
+	assert.Equal(t, w.Code, http.StatusMethodNotAllowed, "PUT to a replica")
+	assert.Equal(t, w.Header().Get("Allow"), "GET, HEAD", "PUT to a replica")
+	assert.Equal(t, do(NewRestHandler(replica), "GET").Code, http.StatusNotFound, "GET from a replica")
+
+	// a node without a cluster to lead stays a follower
+	follower := NewServer(NewMemStorage())
+	defer follower.Close()
+	if _, err := follower.EnableCluster(ClusterConfig{NodeID: "follower", Bind: "127.0.0.1:0"}); assert.NoError(t, err, "EnableCluster") {
+		assert.Equal(t, do(NewRestHandler(follower), "PUT").Code, http.StatusServiceUnavailable, "PUT to a follower")
+	}
+}
@@ -0,0 +1,988 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: service.proto
+
+package ldbserver
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() { proto.RegisterFile("service.proto", fileDescriptor_a0b84a42fa06f626) }
+
+var fileDescriptor_a0b84a42fa06f626 = []byte{
+	// 311 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xcf, 0x4e, 0x02, 0x31,
+	0x10, 0x87, 0x43, 0x40, 0x8c, 0x23, 0x48, 0xdc, 0x23, 0xea, 0xdd, 0x13, 0x31, 0x3e, 0x81, 0xb2,
+	0x4b, 0x74, 0x71, 0x23, 0x04, 0x78, 0x81, 0xd9, 0x65, 0x08, 0x1b, 0x9b, 0xb6, 0x74, 0xa6, 0xbc,
+	0x98, 0x2f, 0x68, 0xf8, 0xe7, 0x89, 0x78, 0x19, 0xaf, 0x9d, 0xc9, 0x97, 0xf9, 0x7d, 0xed, 0x14,
+	0xba, 0x4c, 0x61, 0x5b, 0x57, 0x34, 0xf0, 0xc1, 0x89, 0x4b, 0xae, 0xcc, 0xb2, 0xdc, 0x9d, 0x50,
+	0xe8, 0xf7, 0x24, 0xa0, 0x65, 0xef, 0x82, 0x1c, 0x6a, 0xcf, 0xdf, 0x1d, 0x68, 0x16, 0xcb, 0x32,
+	0x79, 0x81, 0xe6, 0x1b, 0x49, 0x72, 0x37, 0xf8, 0xed, 0x1d, 0x2c, 0x4e, 0xad, 0x33, 0xda, 0x44,
+	0x62, 0xe9, 0xdf, 0x9f, 0x2f, 0xb2, 0x77, 0x96, 0x69, 0x47, 0x98, 0x46, 0x15, 0x21, 0x85, 0x76,
+	0x46, 0x86, 0x84, 0x34, 0x90, 0x21, 0x5c, 0x0c, 0x51, 0xaa, 0xb5, 0x32, 0x4a, 0x8a, 0xac, 0x21,
+	0xbc, 0xc3, 0xf5, 0x34, 0x4a, 0xbe, 0x7a, 0x2d, 0x99, 0xac, 0x4a, 0xca, 0x18, 0xba, 0x07, 0x29,
+	0xf9, 0x6a, 0xb4, 0x89, 0x68, 0x34, 0xac, 0x0c, 0x2e, 0x67, 0xe4, 0x0d, 0x56, 0xa4, 0xb4, 0xb3,
+	0x10, 0xed, 0x1c, 0x53, 0x0a, 0x5c, 0xb3, 0xca, 0x4c, 0x0e, 0x9d, 0xb9, 0x45, 0xcf, 0x6b, 0x27,
+	0x13, 0x4f, 0x56, 0x83, 0x2a, 0xa0, 0x77, 0x42, 0xcd, 0xc8, 0x10, 0x32, 0xe9, 0xde, 0x71, 0x6b,
+	0x5e, 0xa1, 0x66, 0xa0, 0xa7, 0x46, 0x32, 0x82, 0xf6, 0x10, 0xab, 0xaf, 0xe8, 0x75, 0x98, 0xfd,
+	0x95, 0xb3, 0xb8, 0xa0, 0x4a, 0x34, 0x82, 0x56, 0xe1, 0x70, 0x99, 0x3c, 0x9c, 0xeb, 0x9a, 0x78,
+	0x0a, 0x28, 0xb5, 0xb3, 0x7f, 0x43, 0x1e, 0x1b, 0xc9, 0x07, 0xdc, 0xa4, 0x81, 0x50, 0x28, 0x43,
+	0xc1, 0x52, 0x69, 0x39, 0x87, 0x4e, 0x16, 0x9c, 0xff, 0x0f, 0xd4, 0x27, 0xdc, 0xee, 0xf6, 0xa2,
+	0xae, 0xf6, 0x31, 0xe6, 0x82, 0x12, 0xb5, 0xdb, 0x9f, 0x9a, 0xc8, 0x42, 0x61, 0xec, 0x6a, 0xab,
+	0x0c, 0x79, 0x24, 0x15, 0x84, 0x5b, 0x52, 0x7e, 0x24, 0x47, 0x94, 0x3a, 0xe0, 0xcf, 0x00, 0x28,
+	0xd4, 0x2f, 0xae, 0x61, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// LdbClient is the client API for Ldb service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type LdbClient interface {
+	Get(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	Put(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	Delete(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	Batch(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	Cas(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	PutIfAbsent(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	DeleteIfEqual(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	Replace(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	Ttl(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	Persist(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	// Snapshots live as long as the gRPC connection that opened them.
+	SnapshotOpen(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	SnapshotRelease(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	// Scan and Backup stream the pairs in responses of up to 100 pairs and
+	// 512 KiB.
+	Scan(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (Ldb_ScanClient, error)
+	Backup(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (Ldb_BackupClient, error)
+	Restore(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	// Load writes the streamed PUT and DELETE operations in batches; each
+	// batch is atomic, the whole load is not. The database and the token are
+	// taken from the metadata.
+	Load(ctx context.Context, opts ...grpc.CallOption) (Ldb_LoadClient, error)
+	CreateDatabase(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	DropDatabase(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	ReplicationStatus(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	ClusterJoin(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	ClusterLeave(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+	ClusterStatus(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error)
+}
+
+type ldbClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLdbClient(cc *grpc.ClientConn) LdbClient {
+	return &ldbClient{cc}
+}
+
+func (c *ldbClient) Get(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) Put(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/Put", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) Delete(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) Batch(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/Batch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) Cas(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/Cas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) PutIfAbsent(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/PutIfAbsent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) DeleteIfEqual(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/DeleteIfEqual", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) Replace(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/Replace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) Ttl(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/Ttl", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) Persist(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/Persist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) SnapshotOpen(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/SnapshotOpen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) SnapshotRelease(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/SnapshotRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) Scan(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (Ldb_ScanClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ldb_serviceDesc.Streams[0], "/ldbserver.Ldb/Scan", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ldbScanClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Ldb_ScanClient interface {
+	Recv() (*TransportResponse, error)
+	grpc.ClientStream
+}
+
+type ldbScanClient struct {
+	grpc.ClientStream
+}
+
+func (x *ldbScanClient) Recv() (*TransportResponse, error) {
+	m := new(TransportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ldbClient) Backup(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (Ldb_BackupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ldb_serviceDesc.Streams[1], "/ldbserver.Ldb/Backup", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ldbBackupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Ldb_BackupClient interface {
+	Recv() (*TransportResponse, error)
+	grpc.ClientStream
+}
+
+type ldbBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *ldbBackupClient) Recv() (*TransportResponse, error) {
+	m := new(TransportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ldbClient) Restore(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) Load(ctx context.Context, opts ...grpc.CallOption) (Ldb_LoadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ldb_serviceDesc.Streams[2], "/ldbserver.Ldb/Load", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ldbLoadClient{stream}
+	return x, nil
+}
+
+type Ldb_LoadClient interface {
+	Send(*TransportOperation) error
+	CloseAndRecv() (*TransportResponse, error)
+	grpc.ClientStream
+}
+
+type ldbLoadClient struct {
+	grpc.ClientStream
+}
+
+func (x *ldbLoadClient) Send(m *TransportOperation) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *ldbLoadClient) CloseAndRecv() (*TransportResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(TransportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ldbClient) CreateDatabase(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/CreateDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) DropDatabase(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/DropDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) ReplicationStatus(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/ReplicationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) ClusterJoin(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/ClusterJoin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) ClusterLeave(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/ClusterLeave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ldbClient) ClusterStatus(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportResponse, error) {
+	out := new(TransportResponse)
+	err := c.cc.Invoke(ctx, "/ldbserver.Ldb/ClusterStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LdbServer is the server API for Ldb service.
+type LdbServer interface {
+	Get(context.Context, *TransportRequest) (*TransportResponse, error)
+	Put(context.Context, *TransportRequest) (*TransportResponse, error)
+	Delete(context.Context, *TransportRequest) (*TransportResponse, error)
+	Batch(context.Context, *TransportRequest) (*TransportResponse, error)
+	Cas(context.Context, *TransportRequest) (*TransportResponse, error)
+	PutIfAbsent(context.Context, *TransportRequest) (*TransportResponse, error)
+	DeleteIfEqual(context.Context, *TransportRequest) (*TransportResponse, error)
+	Replace(context.Context, *TransportRequest) (*TransportResponse, error)
+	Ttl(context.Context, *TransportRequest) (*TransportResponse, error)
+	Persist(context.Context, *TransportRequest) (*TransportResponse, error)
+	// Snapshots live as long as the gRPC connection that opened them.
+	SnapshotOpen(context.Context, *TransportRequest) (*TransportResponse, error)
+	SnapshotRelease(context.Context, *TransportRequest) (*TransportResponse, error)
+	// Scan and Backup stream the pairs in responses of up to 100 pairs and
+	// 512 KiB.
+	Scan(*TransportRequest, Ldb_ScanServer) error
+	Backup(*TransportRequest, Ldb_BackupServer) error
+	Restore(context.Context, *TransportRequest) (*TransportResponse, error)
+	// Load writes the streamed PUT and DELETE operations in batches; each
+	// batch is atomic, the whole load is not. The database and the token are
+	// taken from the metadata.
+	Load(Ldb_LoadServer) error
+	CreateDatabase(context.Context, *TransportRequest) (*TransportResponse, error)
+	DropDatabase(context.Context, *TransportRequest) (*TransportResponse, error)
+	ReplicationStatus(context.Context, *TransportRequest) (*TransportResponse, error)
+	ClusterJoin(context.Context, *TransportRequest) (*TransportResponse, error)
+	ClusterLeave(context.Context, *TransportRequest) (*TransportResponse, error)
+	ClusterStatus(context.Context, *TransportRequest) (*TransportResponse, error)
+}
+
+// UnimplementedLdbServer can be embedded to have forward compatible implementations.
+type UnimplementedLdbServer struct {
+}
+
+func (*UnimplementedLdbServer) Get(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedLdbServer) Put(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Put not implemented")
+}
+func (*UnimplementedLdbServer) Delete(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedLdbServer) Batch(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Batch not implemented")
+}
+func (*UnimplementedLdbServer) Cas(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cas not implemented")
+}
+func (*UnimplementedLdbServer) PutIfAbsent(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutIfAbsent not implemented")
+}
+func (*UnimplementedLdbServer) DeleteIfEqual(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteIfEqual not implemented")
+}
+func (*UnimplementedLdbServer) Replace(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replace not implemented")
+}
+func (*UnimplementedLdbServer) Ttl(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ttl not implemented")
+}
+func (*UnimplementedLdbServer) Persist(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Persist not implemented")
+}
+func (*UnimplementedLdbServer) SnapshotOpen(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotOpen not implemented")
+}
+func (*UnimplementedLdbServer) SnapshotRelease(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotRelease not implemented")
+}
+func (*UnimplementedLdbServer) Scan(req *TransportRequest, srv Ldb_ScanServer) error {
+	return status.Errorf(codes.Unimplemented, "method Scan not implemented")
+}
+func (*UnimplementedLdbServer) Backup(req *TransportRequest, srv Ldb_BackupServer) error {
+	return status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
+func (*UnimplementedLdbServer) Restore(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (*UnimplementedLdbServer) Load(srv Ldb_LoadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Load not implemented")
+}
+func (*UnimplementedLdbServer) CreateDatabase(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDatabase not implemented")
+}
+func (*UnimplementedLdbServer) DropDatabase(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropDatabase not implemented")
+}
+func (*UnimplementedLdbServer) ReplicationStatus(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplicationStatus not implemented")
+}
+func (*UnimplementedLdbServer) ClusterJoin(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClusterJoin not implemented")
+}
+func (*UnimplementedLdbServer) ClusterLeave(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClusterLeave not implemented")
+}
+func (*UnimplementedLdbServer) ClusterStatus(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClusterStatus not implemented")
+}
+
+func RegisterLdbServer(s *grpc.Server, srv LdbServer) {
+	s.RegisterService(&_Ldb_serviceDesc, srv)
+}
+
+func _Ldb_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).Get(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).Put(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/Put",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).Put(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).Delete(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_Batch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).Batch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/Batch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).Batch(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_Cas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).Cas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/Cas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).Cas(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_PutIfAbsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).PutIfAbsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/PutIfAbsent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).PutIfAbsent(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_DeleteIfEqual_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).DeleteIfEqual(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/DeleteIfEqual",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).DeleteIfEqual(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_Replace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).Replace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/Replace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).Replace(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_Ttl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).Ttl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/Ttl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).Ttl(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_Persist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).Persist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/Persist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).Persist(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_SnapshotOpen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).SnapshotOpen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/SnapshotOpen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).SnapshotOpen(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_SnapshotRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).SnapshotRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/SnapshotRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).SnapshotRelease(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_Scan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TransportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LdbServer).Scan(m, &ldbScanServer{stream})
+}
+
+type Ldb_ScanServer interface {
+	Send(*TransportResponse) error
+	grpc.ServerStream
+}
+
+type ldbScanServer struct {
+	grpc.ServerStream
+}
+
+func (x *ldbScanServer) Send(m *TransportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Ldb_Backup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TransportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LdbServer).Backup(m, &ldbBackupServer{stream})
+}
+
+type Ldb_BackupServer interface {
+	Send(*TransportResponse) error
+	grpc.ServerStream
+}
+
+type ldbBackupServer struct {
+	grpc.ServerStream
+}
+
+func (x *ldbBackupServer) Send(m *TransportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Ldb_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).Restore(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_Load_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LdbServer).Load(&ldbLoadServer{stream})
+}
+
+type Ldb_LoadServer interface {
+	SendAndClose(*TransportResponse) error
+	Recv() (*TransportOperation, error)
+	grpc.ServerStream
+}
+
+type ldbLoadServer struct {
+	grpc.ServerStream
+}
+
+func (x *ldbLoadServer) SendAndClose(m *TransportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *ldbLoadServer) Recv() (*TransportOperation, error) {
+	m := new(TransportOperation)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Ldb_CreateDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).CreateDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/CreateDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).CreateDatabase(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_DropDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).DropDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/DropDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).DropDatabase(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_ReplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).ReplicationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/ReplicationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).ReplicationStatus(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_ClusterJoin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).ClusterJoin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/ClusterJoin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).ClusterJoin(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_ClusterLeave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).ClusterLeave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/ClusterLeave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).ClusterLeave(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ldb_ClusterStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LdbServer).ClusterStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldbserver.Ldb/ClusterStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LdbServer).ClusterStatus(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Ldb_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ldbserver.Ldb",
+	HandlerType: (*LdbServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _Ldb_Get_Handler,
+		},
+		{
+			MethodName: "Put",
+			Handler:    _Ldb_Put_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Ldb_Delete_Handler,
+		},
+		{
+			MethodName: "Batch",
+			Handler:    _Ldb_Batch_Handler,
+		},
+		{
+			MethodName: "Cas",
+			Handler:    _Ldb_Cas_Handler,
+		},
+		{
+			MethodName: "PutIfAbsent",
+			Handler:    _Ldb_PutIfAbsent_Handler,
+		},
+		{
+			MethodName: "DeleteIfEqual",
+			Handler:    _Ldb_DeleteIfEqual_Handler,
+		},
+		{
+			MethodName: "Replace",
+			Handler:    _Ldb_Replace_Handler,
+		},
+		{
+			MethodName: "Ttl",
+			Handler:    _Ldb_Ttl_Handler,
+		},
+		{
+			MethodName: "Persist",
+			Handler:    _Ldb_Persist_Handler,
+		},
+		{
+			MethodName: "SnapshotOpen",
+			Handler:    _Ldb_SnapshotOpen_Handler,
+		},
+		{
+			MethodName: "SnapshotRelease",
+			Handler:    _Ldb_SnapshotRelease_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _Ldb_Restore_Handler,
+		},
+		{
+			MethodName: "CreateDatabase",
+			Handler:    _Ldb_CreateDatabase_Handler,
+		},
+		{
+			MethodName: "DropDatabase",
+			Handler:    _Ldb_DropDatabase_Handler,
+		},
+		{
+			MethodName: "ReplicationStatus",
+			Handler:    _Ldb_ReplicationStatus_Handler,
+		},
+		{
+			MethodName: "ClusterJoin",
+			Handler:    _Ldb_ClusterJoin_Handler,
+		},
+		{
+			MethodName: "ClusterLeave",
+			Handler:    _Ldb_ClusterLeave_Handler,
+		},
+		{
+			MethodName: "ClusterStatus",
+			Handler:    _Ldb_ClusterStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Scan",
+			Handler:       _Ldb_Scan_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Backup",
+			Handler:       _Ldb_Backup_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Load",
+			Handler:       _Ldb_Load_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "service.proto",
+}
@@ -0,0 +1,152 @@
+package ldbserver
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	errNoSession       = errors.New("snapshots require a stream connection")
+	errUnknownSnapshot = errors.New("unknown snapshot")
+)
+
+// minSnapshotExpiryInterval bounds how often idle snapshots are looked for.
+const minSnapshotExpiryInterval = 10 * time.Millisecond
+
+// session holds the state of one client connection. The snapshots opened by
+// the client are released when the connection is closed or when they are not
+// used for the idle timeout, but only once no request reads them.
+type session struct {
+	mu        sync.Mutex
+	snapshots map[uint64]*sessionSnapshot
+	lastId    uint64
+	closed    bool
+	stop      chan struct{}
+	// quit is closed when the client stops sending requests
+	quit     chan struct{}
+	quitOnce sync.Once
+}
+
+type sessionSnapshot struct {
+	db   *database
+	snap Snapshot
+	used time.Time
+	// users counts the requests reading snap; a released snapshot is only
+	// released from the storage by the last of them
+	users    int
+	released bool
+}
+
+// release releases ss from the storage once it is not in use. The session
+// must be locked.
+func (ss *sessionSnapshot) release() {
+	ss.released = true
+	if ss.users == 0 {
+		ss.snap.Release()
+	}
+}
+
+func newSession() *session {
+	return &session{snapshots: make(map[uint64]*sessionSnapshot), quit: make(chan struct{})}
+}
+
+// done returns a channel closed when the client stops sending requests, so
+// that long running requests can give up.
+func (sess *session) done() <-chan struct{} {
+	return sess.quit
+}
+
+func (sess *session) finish() {
+	sess.quitOnce.Do(func() { close(sess.quit) })
+}
+
+func (sess *session) addSnapshot(db *database, snap Snapshot, idle time.Duration) (uint64, error) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.closed {
+		snap.Release()
+		return 0, errors.New("session closed")
+	}
+	sess.lastId++
+	sess.snapshots[sess.lastId] = &sessionSnapshot{db: db, snap: snap, used: time.Now()}
+	if idle > 0 && sess.stop == nil {
+		sess.stop = make(chan struct{})
+		go sess.expire(idle, sess.stop)
+	}
+	return sess.lastId, nil
+}
+
+// snapshot returns the snapshot id of db and the function the request
+// calls when it stops reading it.
+func (sess *session) snapshot(id uint64, db *database) (Snapshot, func(), error) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	ss, ok := sess.snapshots[id]
+	if !ok || ss.db != db {
+		return nil, nil, errUnknownSnapshot
+	}
+	ss.users++
+	return ss.snap, func() {
+		sess.mu.Lock()
+		defer sess.mu.Unlock()
+		ss.users--
+		ss.used = time.Now()
+		if ss.released && ss.users == 0 {
+			ss.snap.Release()
+		}
+	}, nil
+}
+
+func (sess *session) releaseSnapshot(id uint64) error {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	ss, ok := sess.snapshots[id]
+	if !ok {
+		return errUnknownSnapshot
+	}
+	ss.release()
+	delete(sess.snapshots, id)
+	return nil
+}
+
+// expire releases the snapshots that are idle for longer than idle.
+func (sess *session) expire(idle time.Duration, stop chan struct{}) {
+	interval := idle / 2
+	if interval < minSnapshotExpiryInterval {
+		interval = minSnapshotExpiryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			sess.mu.Lock()
+			for id, ss := range sess.snapshots {
+				if ss.users == 0 && now.Sub(ss.used) > idle {
+					ss.release()
+					delete(sess.snapshots, id)
+				}
+			}
+			sess.mu.Unlock()
+		}
+	}
+}
+
+func (sess *session) close() {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.closed {
+		return
+	}
+	sess.closed = true
+	for id, ss := range sess.snapshots {
+		ss.release()
+		delete(sess.snapshots, id)
+	}
+	if sess.stop != nil {
+		close(sess.stop)
+	}
+}
@@ -0,0 +1,57 @@
+package ldbserver
+
+import (
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// ErrNotFound is returned by Reader.Get for missing keys.
+var ErrNotFound = leveldb.ErrNotFound
+
+// Storage is the key-value engine behind a server. The server serializes
+// writes to the same key itself; implementations must only be safe for
+// concurrent use.
+type Storage interface {
+	Reader
+	Put(key, value []byte, sync bool) error
+	Delete(key []byte, sync bool) error
+	// Batch applies all operations atomically.
+	Batch(ops []Operation, sync bool) error
+	Snapshot() (Snapshot, error)
+	Close() error
+}
+
+// Reader is implemented by a Storage and its snapshots.
+type Reader interface {
+	// Get returns ErrNotFound if key is missing. The returned slice must not
+	// be modified.
+	Get(key []byte) ([]byte, error)
+	// Iterate walks over the keys in [start, limit) in ascending order; nil
+	// bounds are open.
+	Iterate(start, limit []byte) Iterator
+}
+
+// Snapshot is a consistent view of a Storage.
+type Snapshot interface {
+	Reader
+	Release()
+}
+
+// Iterator walks over the keys of a Reader. Key and Value are only valid
+// until the next move. It must be released after use.
+type Iterator interface {
+	First() bool
+	Last() bool
+	Next() bool
+	Prev() bool
+	Key() []byte
+	Value() []byte
+	Error() error
+	Release()
+}
+
+// Operation is one write of a batch.
+type Operation struct {
+	Key    []byte
+	Value  []byte
+	Delete bool
+}
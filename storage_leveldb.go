@@ -0,0 +1,342 @@
+package ldbserver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/govlas/logger"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var errOldLayout = errors.New("database written by an older server; open it ReadOnly or upgrade it with ldbserver upgrade")
+
+// levelDBStorage is the default Storage.
+type levelDBStorage struct {
+	db *leveldb.DB
+}
+
+// OpenLevelDBStorage opens or creates the leveldb at path. A leveldb written
+// before the server kept its keys apart from those of clients is only opened
+// with ReadOnly, until UpgradeLevelDB rewrites it.
+func OpenLevelDBStorage(path string, o *opt.Options) (Storage, error) {
+	if err := finishUpgrade(path); err != nil {
+		return nil, err
+	}
+	db, err := leveldb.OpenFile(path, o)
+	if err != nil {
+		return nil, err
+	}
+	current, empty, err := levelDBLayout(db)
+	switch {
+	case err != nil:
+		db.Close()
+		return nil, err
+	case current, empty && o.GetReadOnly():
+		return &levelDBStorage{db}, nil
+	case empty:
+		if err := db.Put(layoutKey, layoutVersion, &opt.WriteOptions{Sync: true}); err != nil {
+			db.Close()
+			return nil, err
+		}
+		return &levelDBStorage{db}, nil
+	case o.GetReadOnly():
+		return &oldLayoutStorage{oldLayoutReader{db}, db}, nil
+	}
+	db.Close()
+	return nil, errOldLayout
+}
+
+// levelDBLayout reports whether db is in the current layout or empty.
+func levelDBLayout(db *leveldb.DB) (current, empty bool, err error) {
+	version, err := db.Get(layoutKey, nil)
+	if err == nil {
+		return bytes.Equal(version, layoutVersion), false, nil
+	}
+	if err != ErrNotFound {
+		return false, false, err
+	}
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+	return false, !it.First(), it.Error()
+}
+
+// UpgradeLevelDB rewrites a leveldb written before the server kept its keys
+// apart from those of clients in the current layout, which older servers can
+// not read. It needs the space of a second copy of the database; the original
+// is kept at path.old. A leveldb in the current layout is left alone.
+func UpgradeLevelDB(path string, o *opt.Options) error {
+	if err := finishUpgrade(path); err != nil {
+		return err
+	}
+	ro := opt.Options{ReadOnly: true, ErrorIfMissing: true}
+	if o != nil {
+		ro = *o
+		ro.ReadOnly, ro.ErrorIfMissing = true, true
+	}
+	db, err := leveldb.OpenFile(path, &ro)
+	if err != nil {
+		return err
+	}
+	current, empty, err := levelDBLayout(db)
+	db.Close()
+	if err != nil || current || empty {
+		return err
+	}
+	return upgradeLevelDB(path, o)
+}
+
+// upgradeLevelDB copies the keys of the leveldb at path, all written by
+// clients, to a new leveldb in the current layout, which then replaces it.
+func upgradeLevelDB(path string, o *opt.Options) error {
+	logger.Info("upgrading the layout of %s", path)
+	if _, err := os.Stat(path + ".old"); err == nil {
+		return fmt.Errorf("%s.old exists", path)
+	}
+	tmp := path + ".upgrade"
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	var to opt.Options
+	if o != nil {
+		to = *o
+	}
+	to.ErrorIfExist, to.ErrorIfMissing, to.ReadOnly = false, false, false
+	src, err := leveldb.OpenFile(path, o)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := leveldb.OpenFile(tmp, &to)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	if err := copyToLayout(dst, src); err != nil {
+		return err
+	}
+	batch := new(leveldb.Batch)
+	batch.Put(layoutKey, layoutVersion)
+	if err := dst.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	src.Close()
+
+	if err := os.Rename(path, path+".old"); err != nil {
+		return err
+	}
+	if err := finishUpgrade(path); err != nil {
+		return err
+	}
+	logger.Info("upgraded %s; the original is kept at %s.old", path, path)
+	return nil
+}
+
+// copyToLayout writes the keys of src to dst as keys of clients.
+func copyToLayout(dst, src *leveldb.DB) error {
+	it := src.NewIterator(nil, nil)
+	defer it.Release()
+	batch := new(leveldb.Batch)
+	for ok := it.First(); ok; ok = it.Next() {
+		batch.Put(dataKey(it.Key()), encodeValue(meta{}, it.Value()))
+		if batch.Len() == restoreBatchSize {
+			if err := dst.Write(batch, nil); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return dst.Write(batch, nil)
+}
+
+// finishUpgrade replaces the leveldb at path by its upgraded copy if an
+// upgrade stopped after moving the original away.
+func finishUpgrade(path string) error {
+	if _, err := os.Stat(path + ".old"); os.IsNotExist(err) {
+		return nil
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return os.Rename(path+".upgrade", path)
+	}
+	return nil
+}
+
+func (s *levelDBStorage) Get(key []byte) ([]byte, error) {
+	return s.db.Get(key, nil)
+}
+
+func (s *levelDBStorage) Iterate(start, limit []byte) Iterator {
+	return s.db.NewIterator(&util.Range{Start: start, Limit: limit}, nil)
+}
+
+func (s *levelDBStorage) Put(key, value []byte, sync bool) error {
+	return s.db.Put(key, value, levelDBWriteOptions(sync))
+}
+
+func (s *levelDBStorage) Delete(key []byte, sync bool) error {
+	return s.db.Delete(key, levelDBWriteOptions(sync))
+}
+
+func (s *levelDBStorage) Batch(ops []Operation, sync bool) error {
+	batch := new(leveldb.Batch)
+	for _, op := range ops {
+		if op.Delete {
+			batch.Delete(op.Key)
+		} else {
+			batch.Put(op.Key, op.Value)
+		}
+	}
+	return s.db.Write(batch, levelDBWriteOptions(sync))
+}
+
+func (s *levelDBStorage) Snapshot() (Snapshot, error) {
+	snap, err := s.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return levelDBSnapshot{snap}, nil
+}
+
+func (s *levelDBStorage) Close() error {
+	return s.db.Close()
+}
+
+// GetProperty returns goleveldb statistics for metrics.
+func (s *levelDBStorage) GetProperty(name string) (string, error) {
+	return s.db.GetProperty(name)
+}
+
+type levelDBSnapshot struct {
+	snap *leveldb.Snapshot
+}
+
+func (s levelDBSnapshot) Get(key []byte) ([]byte, error) {
+	return s.snap.Get(key, nil)
+}
+
+func (s levelDBSnapshot) Iterate(start, limit []byte) Iterator {
+	return s.snap.NewIterator(&util.Range{Start: start, Limit: limit}, nil)
+}
+
+func (s levelDBSnapshot) Release() {
+	s.snap.Release()
+}
+
+func levelDBWriteOptions(sync bool) *opt.WriteOptions {
+	if sync {
+		return &opt.WriteOptions{Sync: true}
+	}
+	return nil
+}
+
+// oldLayoutStorage serves a leveldb written by an older server read-only,
+// through a view of its keys in the current layout.
+type oldLayoutStorage struct {
+	oldLayoutReader
+	db *leveldb.DB
+}
+
+func (s *oldLayoutStorage) Put(key, value []byte, sync bool) error {
+	return leveldb.ErrReadOnly
+}
+
+func (s *oldLayoutStorage) Delete(key []byte, sync bool) error {
+	return leveldb.ErrReadOnly
+}
+
+func (s *oldLayoutStorage) Batch(ops []Operation, sync bool) error {
+	return leveldb.ErrReadOnly
+}
+
+func (s *oldLayoutStorage) Snapshot() (Snapshot, error) {
+	snap, err := s.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return oldLayoutSnapshot{oldLayoutReader{snap}, snap}, nil
+}
+
+func (s *oldLayoutStorage) Close() error {
+	return s.db.Close()
+}
+
+// GetProperty returns goleveldb statistics for metrics.
+func (s *oldLayoutStorage) GetProperty(name string) (string, error) {
+	return s.db.GetProperty(name)
+}
+
+type oldLayoutSnapshot struct {
+	oldLayoutReader
+	snap *leveldb.Snapshot
+}
+
+func (s oldLayoutSnapshot) Release() {
+	s.snap.Release()
+}
+
+// levelDBReader is implemented by a leveldb and its snapshots.
+type levelDBReader interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+	NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator
+}
+
+// oldLayoutReader presents the keys of a leveldb in the old layout, all
+// written by clients, as keys of the data namespace without metadata. The
+// other namespaces are empty and iterations leave out the layout key.
+type oldLayoutReader struct {
+	r levelDBReader
+}
+
+func (r oldLayoutReader) Get(key []byte) ([]byte, error) {
+	switch {
+	case len(key) == 0:
+		return layoutVersion, nil
+	case !bytes.HasPrefix(key, dataPrefix):
+		return nil, ErrNotFound
+	}
+	value, err := r.r.Get(key[len(dataPrefix):], nil)
+	if err != nil {
+		return nil, err
+	}
+	return encodeValue(meta{}, value), nil
+}
+
+func (r oldLayoutReader) Iterate(start, limit []byte) Iterator {
+	// narrow [start, limit) to the data namespace, then drop the prefix
+	rng := &util.Range{}
+	if bytes.Compare(start, dataEnd) >= 0 || (limit != nil && bytes.Compare(limit, dataPrefix) <= 0) {
+		return iterator.NewEmptyIterator(nil)
+	}
+	if bytes.Compare(start, dataPrefix) > 0 {
+		rng.Start = start[len(dataPrefix):]
+	}
+	if limit != nil && bytes.Compare(limit, dataEnd) < 0 {
+		rng.Limit = limit[len(dataPrefix):]
+	}
+	return &oldLayoutIterator{Iterator: r.r.NewIterator(rng, nil)}
+}
+
+// oldLayoutIterator returns the keys and values of an old layout leveldb as
+// stored in the data namespace.
+type oldLayoutIterator struct {
+	iterator.Iterator
+}
+
+func (it *oldLayoutIterator) Key() []byte {
+	return dataKey(it.Iterator.Key())
+}
+
+func (it *oldLayoutIterator) Value() []byte {
+	return encodeValue(meta{}, it.Iterator.Value())
+}
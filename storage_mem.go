@@ -0,0 +1,116 @@
+package ldbserver
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb/comparer"
+	"github.com/syndtr/goleveldb/leveldb/memdb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var errStorageClosed = errors.New("storage closed")
+
+// memStorage keeps keys in a goleveldb memdb. Snapshots copy all keys.
+type memStorage struct {
+	// mu is held for writing by batches and snapshots, so that they are
+	// atomic for Get and Snapshot
+	mu     sync.RWMutex
+	db     *memdb.DB
+	closed bool
+}
+
+// NewMemStorage returns an empty Storage held in memory. It is meant for
+// tests and data that need not survive a restart.
+func NewMemStorage() Storage {
+	return &memStorage{db: memdb.New(comparer.DefaultComparer, 0)}
+}
+
+func (s *memStorage) Get(key []byte) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, errStorageClosed
+	}
+	return s.db.Get(key)
+}
+
+func (s *memStorage) Iterate(start, limit []byte) Iterator {
+	return s.db.NewIterator(&util.Range{Start: start, Limit: limit})
+}
+
+func (s *memStorage) Put(key, value []byte, sync bool) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return errStorageClosed
+	}
+	return s.db.Put(key, value)
+}
+
+func (s *memStorage) Delete(key []byte, sync bool) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return errStorageClosed
+	}
+	if err := s.db.Delete(key); err != nil && err != ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+func (s *memStorage) Batch(ops []Operation, sync bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errStorageClosed
+	}
+	for _, op := range ops {
+		if op.Delete {
+			s.db.Delete(op.Key)
+		} else {
+			s.db.Put(op.Key, op.Value)
+		}
+	}
+	return nil
+}
+
+func (s *memStorage) Snapshot() (Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errStorageClosed
+	}
+	snap := memdb.New(comparer.DefaultComparer, s.db.Size())
+	it := s.db.NewIterator(nil)
+	defer it.Release()
+	for it.Next() {
+		snap.Put(it.Key(), it.Value())
+	}
+	return memSnapshot{snap}, nil
+}
+
+func (s *memStorage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.db.Reset()
+	return nil
+}
+
+type memSnapshot struct {
+	db *memdb.DB
+}
+
+func (s memSnapshot) Get(key []byte) ([]byte, error) {
+	return s.db.Get(key)
+}
+
+func (s memSnapshot) Iterate(start, limit []byte) Iterator {
+	return s.db.NewIterator(&util.Range{Start: start, Limit: limit})
+}
+
+func (s memSnapshot) Release() {
+	s.db.Reset()
+}
@@ -0,0 +1,156 @@
+package ldbserver
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+func testStorage(t *testing.T, name string, st Storage) {
+	keys := func(r Reader, start, limit []byte) (ret []string) {
+		it := r.Iterate(start, limit)
+		defer it.Release()
+		for ok := it.First(); ok; ok = it.Next() {
+			ret = append(ret, string(it.Key())+"="+string(it.Value()))
+		}
+		assert.NoError(t, it.Error(), name+" Iterate")
+		return
+	}
+
+	_, err := st.Get([]byte("a"))
+	assert.Equal(t, err, ErrNotFound, name+" Get missing")
+	assert.NoError(t, st.Put([]byte("a"), []byte("1"), false), name+" Put")
+	assert.NoError(t, st.Put([]byte("b"), []byte("2"), true), name+" Put sync")
+	value, err := st.Get([]byte("a"))
+	assert.NoError(t, err, name+" Get")
+	assert.Equal(t, value, []byte("1"), name+" Get")
+
+	snap, err := st.Snapshot()
+	if !assert.NoError(t, err, name+" Snapshot") {
+		return
+	}
+	defer snap.Release()
+
+	assert.NoError(t, st.Batch([]Operation{
+		{Key: []byte("a"), Delete: true},
+		{Key: []byte("c"), Value: []byte("3")},
+		{Key: []byte("d"), Value: []byte("4")},
+	}, false), name+" Batch")
+	assert.NoError(t, st.Delete([]byte("d"), false), name+" Delete")
+	assert.NoError(t, st.Delete([]byte("missing"), false), name+" Delete missing")
+
+	assert.Equal(t, keys(st, nil, nil), []string{"b=2", "c=3"}, name+" Iterate")
+	assert.Equal(t, keys(st, []byte("c"), nil), []string{"c=3"}, name+" Iterate start")
+	assert.Equal(t, keys(st, nil, []byte("c")), []string{"b=2"}, name+" Iterate limit")
+	assert.Equal(t, keys(snap, nil, nil), []string{"a=1", "b=2"}, name+" Iterate snapshot")
+	value, err = snap.Get([]byte("a"))
+	assert.NoError(t, err, name+" Get snapshot")
+	assert.Equal(t, value, []byte("1"), name+" Get snapshot")
+	_, err = snap.Get([]byte("c"))
+	assert.Equal(t, err, ErrNotFound, name+" Get snapshot missing")
+
+	it := st.Iterate(nil, nil)
+	if assert.True(t, it.Last(), name+" Last") {
+		assert.Equal(t, it.Key(), []byte("c"), name+" Last")
+		assert.True(t, it.Prev(), name+" Prev")
+		assert.Equal(t, it.Key(), []byte("b"), name+" Prev")
+		assert.False(t, it.Prev(), name+" Prev")
+	}
+	it.Release()
+}
+
+func TestStorage(t *testing.T) {
+	testStorage(t, "memory", NewMemStorage())
+
+	// without the layout version written by OpenLevelDBStorage
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("goleveldb-test-storage%d0%d", os.Getuid(), os.Getpid()))
+	db, err := leveldb.OpenFile(path, nil)
+	if assert.NoError(t, err, "OpenFile") {
+		defer func() {
+			db.Close()
+			os.RemoveAll(path)
+		}()
+		testStorage(t, "leveldb", &levelDBStorage{db})
+	}
+}
+
+func TestLevelDBUpgrade(t *testing.T) {
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("goleveldb-test-upgrade%d0%d", os.Getuid(), os.Getpid()))
+	defer os.RemoveAll(path)
+	old, err := leveldb.OpenFile(path, nil)
+	if !assert.NoError(t, err, "OpenFile") {
+		return
+	}
+	keys := []string{"a", "d", "x", "\xff\xffttl/k/a"}
+	for _, key := range keys {
+		old.Put([]byte(key), []byte("v"+key), nil)
+	}
+	old.Close()
+
+	defer os.RemoveAll(path + ".old")
+
+	check := func(db DBServer, msg string) {
+		assert.Equal(t, scanCommand(t, db, &TransportRange{}, MarshalingTypeJson), keys, "Scan "+msg)
+		assert.Equal(t, scanCommand(t, db, &TransportRange{Start: []byte("d"), End: []byte("x")}, MarshalingTypeJson), []string{"d"}, "Scan range "+msg)
+		for _, key := range keys {
+			if resp := serveCommand(t, db, TransportRequest_GET, []byte(key), nil, MarshalingTypeJson, true); resp != nil {
+				assert.Equal(t, resp.Body.Data, []byte("v"+key), "Get "+msg)
+			}
+		}
+	}
+
+	// the old layout is only read until it is upgraded
+	_, err = OpenLevelDBStorage(path, nil)
+	assert.Equal(t, err, errOldLayout, "Open old layout")
+	st, err := OpenLevelDBStorage(path, &opt.Options{ReadOnly: true})
+	if !assert.NoError(t, err, "Open old layout read-only") {
+		return
+	}
+	db := NewServer(st)
+	check(db, "old layout")
+	if resp := serveCommand(t, db, TransportRequest_PUT, []byte("a"), []byte("w"), MarshalingTypeJson, false); resp != nil {
+		assert.Equal(t, resp.GetStatus(), TransportResponse_DENIED, "Put old layout")
+	}
+	var buf bytes.Buffer
+	if snap, err := st.Snapshot(); assert.NoError(t, err, "Snapshot") {
+		n, err := Backup(&buf, snap)
+		assert.NoError(t, err, "Backup old layout")
+		assert.Equal(t, n, len(keys), "Backup old layout")
+		snap.Release()
+	}
+	db.Close()
+
+	if !assert.NoError(t, UpgradeLevelDB(path, nil), "UpgradeLevelDB") {
+		return
+	}
+	assert.NoError(t, UpgradeLevelDB(path, nil), "UpgradeLevelDB again")
+	db, err = NewLevelDbServer(path)
+	if !assert.NoError(t, err, "NewLevelDbServer") {
+		return
+	}
+	defer db.Close()
+	check(db, "upgraded")
+	_, err = os.Stat(path + ".old")
+	assert.NoError(t, err, "Original kept")
+}
+
+func TestMemServer(t *testing.T) {
+	db := NewServer(NewMemStorage())
+	defer db.Close()
+
+	key := []byte("hello")
+	serveCommand(t, db, TransportRequest_PUT, key, []byte("world"), MarshalingTypeJson, true)
+	if resp := serveCommand(t, db, TransportRequest_GET, key, nil, MarshalingTypeJson, true); resp != nil {
+		assert.Equal(t, resp.Body.Data, []byte("world"), "Get")
+	}
+	serveCommand(t, db, TransportRequest_DELETE, key, nil, MarshalingTypeJson, true)
+	if resp := serveCommand(t, db, TransportRequest_GET, key, nil, MarshalingTypeJson, false); resp != nil {
+		assert.Equal(t, resp.GetStatus(), TransportResponse_NOT_FOUND, "Get deleted")
+	}
+}
@@ -0,0 +1,185 @@
+package ldbserver
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+var errTextLineTooLong = errors.New("line too long")
+
+// textTransporter is the common part of the transporters of foreign text
+// protocols. A command is either answered by the transporter itself or
+// translated to requests of the server, whose responses make its reply.
+// Replies are written in the order of the commands, and the requests of one
+// command run in order only if the connection is served by one worker.
+type textTransporter struct {
+	r *bufio.Reader
+	// read reads the next command and returns the function queueing its
+	// reply, called with mu held. A command may be queued along with an
+	// error ending the connection.
+	read func() (func(), error)
+
+	mu      sync.Mutex
+	w       *bufio.Writer
+	token   *string
+	seq     uint64
+	pending []*TransportRequest
+	replies []*textReply
+	quit    bool
+}
+
+// textReply is the reply to one command, built from the responses to its
+// requests once they are all answered.
+type textReply struct {
+	first uint64
+	resps []*TransportResponse
+	left  int
+	// encode builds the reply; data is set once it is known
+	encode func(resps []*TransportResponse) []byte
+	data   []byte
+}
+
+func newTextTransporter(r io.Reader, w io.Writer) textTransporter {
+	return textTransporter{r: bufio.NewReader(r), w: bufio.NewWriter(w)}
+}
+
+func (t *textTransporter) GetRequest() (*TransportRequest, error) {
+	for {
+		t.mu.Lock()
+		if len(t.pending) != 0 {
+			req := t.pending[0]
+			t.pending = t.pending[1:]
+			t.mu.Unlock()
+			return req, nil
+		}
+		quit := t.quit
+		t.mu.Unlock()
+		if quit {
+			return nil, io.EOF
+		}
+
+		run, err := t.read()
+		t.mu.Lock()
+		if run != nil {
+			run()
+		}
+		if ferr := t.flush(); err == nil {
+			err = ferr
+		}
+		t.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *textTransporter) SendResponse(resp *TransportResponse) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, reply := range t.replies {
+		i := int(resp.GetSeq() - reply.first)
+		if reply.data != nil || resp.GetSeq() < reply.first || i >= len(reply.resps) {
+			continue
+		}
+		// scans are answered in several responses
+		if prev := reply.resps[i]; prev != nil && resp.GetStatus() == TransportResponse_OK {
+			prev.Pairs = append(prev.Pairs, resp.Pairs...)
+		} else {
+			reply.resps[i] = resp
+		}
+		if !resp.GetMore() {
+			if reply.left--; reply.left == 0 {
+				reply.data = reply.encode(reply.resps)
+			}
+		}
+		break
+	}
+	return t.flush()
+}
+
+// flush writes the replies known in the order of the commands.
+func (t *textTransporter) flush() error {
+	n := 0
+	for ; n < len(t.replies) && t.replies[n].data != nil; n++ {
+		t.w.Write(t.replies[n].data)
+	}
+	if n == 0 {
+		return nil
+	}
+	t.replies = t.replies[n:]
+	return t.w.Flush()
+}
+
+// local queues a reply given by the transporter itself. An empty reply
+// writes nothing.
+func (t *textTransporter) local(data []byte) {
+	if data == nil {
+		data = []byte{}
+	}
+	t.replies = append(t.replies, &textReply{data: data})
+}
+
+// queue sends reqs to the server and builds the reply with encode.
+func (t *textTransporter) queue(encode func([]*TransportResponse) []byte, reqs ...*TransportRequest) {
+	reply := &textReply{
+		first:  t.seq + 1,
+		resps:  make([]*TransportResponse, len(reqs)),
+		left:   len(reqs),
+		encode: encode,
+	}
+	for _, req := range reqs {
+		t.seq++
+		req.Seq = proto.Uint64(t.seq)
+		req.Token = t.token
+	}
+	t.replies = append(t.replies, reply)
+	t.pending = append(t.pending, reqs...)
+}
+
+// readLine returns the next line without its line break. It is only valid
+// until the next read.
+func (t *textTransporter) readLine() ([]byte, error) {
+	line, err := t.r.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		return nil, errTextLineTooLong
+	}
+	if err != nil {
+		if len(line) != 0 {
+			return nil, unexpectedEOF(err)
+		}
+		return nil, err
+	}
+	line = line[:len(line)-1]
+	if len(line) != 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+	return line, nil
+}
+
+// readBlock reads a block of size bytes followed by a line break. The block
+// grows with the bytes read rather than the size announced by the client.
+func (t *textTransporter) readBlock(size int) ([]byte, bool, error) {
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, t.r, int64(size)+2); err != nil {
+		return nil, false, unexpectedEOF(err)
+	}
+	block := buf.Bytes()
+	return block[:size], block[size] == '\r' && block[size+1] == '\n', nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// textRequest returns a request of cmd on key.
+func textRequest(cmd TransportRequest_Command, key []byte) *TransportRequest {
+	return &TransportRequest{Command: cmd.Enum(), Key: key}
+}
@@ -0,0 +1,38 @@
+package ldbserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+)
+
+// NewServerTLSConfig loads the server certificate and key. If caFile is not
+// empty clients must present a certificate signed by one of its CAs.
+func NewServerTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}}
+	if len(caFile) != 0 {
+		if cfg.ClientCAs, err = LoadCertPool(caFile); err != nil {
+			return nil, err
+		}
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// LoadCertPool reads PEM encoded certificates from fname.
+func LoadCertPool(fname string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(fname)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.New("no certificates in " + fname)
+	}
+	return pool, nil
+}
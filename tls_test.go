@@ -0,0 +1,141 @@
+package ldbserver_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/govlas/ldbserver"
+	"github.com/govlas/ldbserver/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCert{cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (c *testCert) write(t *testing.T, dir, name string) (certFile, keyFile string) {
+	keyDer, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile = filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	ioutil.WriteFile(certFile, c.pem, 0600)
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	return
+}
+
+func freeAddr(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().String()
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ldbserver-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		ca     = newTestCert(t, "ca", nil)
+		server = newTestCert(t, "server", ca)
+		client = newTestCert(t, "client", ca)
+	)
+	caFile, _ := ca.write(t, dir, "ca")
+	serverCert, serverKey := server.write(t, dir, "server")
+	clientCert, clientKey := client.write(t, dir, "client")
+
+	db, err := ldbserver.NewLevelDbServer(filepath.Join(dir, "db"))
+	if !assert.NoError(t, err, "NewLevelDbServer") {
+		return
+	}
+	defer db.Close()
+
+	stc, err := ldbserver.NewServerTLSConfig(serverCert, serverKey, caFile)
+	if !assert.NoError(t, err, "NewServerTLSConfig") {
+		return
+	}
+
+	for _, network := range []string{"tcp", "http"} {
+		addr := freeAddr(t)
+		ns := ldbserver.NewNetworkServer(network, addr)
+		ns.SetTLSConfig(stc)
+		done := make(chan error)
+		go func() {
+			done <- ns.ListenAndServe(db, ldbserver.JsonProtobufTransportFactory{Mt: ldbserver.MarshalingTypeProtobuf})
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		pool, err := ldbserver.LoadCertPool(caFile)
+		assert.NoError(t, err, "LoadCertPool")
+		pair, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		assert.NoError(t, err, "LoadX509KeyPair")
+
+		cli, err := api.NewClient(network, addr, ldbserver.MarshalingTypeProtobuf, api.WithTLS(&tls.Config{RootCAs: pool, Certificates: []tls.Certificate{pair}}))
+		if assert.NoError(t, err, fmt.Sprintf("%s api.NewClient", network)) {
+			assert.NoError(t, cli.Put([]byte("hello"), []byte("world")), fmt.Sprintf("%s api.client.Put", network))
+			res, err := cli.Get([]byte("hello"))
+			assert.NoError(t, err, fmt.Sprintf("%s api.client.Get", network))
+			assert.Equal(t, res, []byte("world"), fmt.Sprintf("%s api.client.Get", network))
+			cli.Close()
+		}
+
+		cli, err = api.NewClient(network, addr, ldbserver.MarshalingTypeProtobuf, api.WithTLS(&tls.Config{RootCAs: pool}))
+		if err == nil {
+			_, err = cli.Get([]byte("hello"))
+			cli.Close()
+		}
+		assert.Error(t, err, fmt.Sprintf("%s without client certificate", network))
+
+		ns.Stop()
+		<-done
+	}
+}
@@ -1,60 +1,129 @@
-// Code generated by protoc-gen-gogo.
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: transport.proto
-// DO NOT EDIT!
 
-/*
-	Package ldbserver is a generated protocol buffer package.
-
-	It is generated from these files:
-		transport.proto
-
-	It has these top-level messages:
-		TransportBody
-		TransportRequest
-		TransportResponse
-*/
 package ldbserver
 
-import proto "github.com/gogo/protobuf/proto"
-import math "math"
-
-// discarding unused import gogoproto "github.com/gogo/protobuf/gogoproto/gogo.pb"
-
-import io "io"
-import fmt "fmt"
-import github_com_gogo_protobuf_proto "github.com/gogo/protobuf/proto"
-
-import strings "strings"
-import sort "sort"
-import strconv "strconv"
-import reflect "reflect"
-
-import bytes "bytes"
+import (
+	bytes "bytes"
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	github_com_gogo_protobuf_proto "github.com/gogo/protobuf/proto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strings "strings"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
+var _ = fmt.Errorf
 var _ = math.Inf
 
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
 type TransportRequest_Command int32
 
 const (
-	TransportRequest_UNKNOWN TransportRequest_Command = 0
-	TransportRequest_GET     TransportRequest_Command = 1
-	TransportRequest_PUT     TransportRequest_Command = 2
-	TransportRequest_DELETE  TransportRequest_Command = 3
+	TransportRequest_UNKNOWN          TransportRequest_Command = 0
+	TransportRequest_GET              TransportRequest_Command = 1
+	TransportRequest_PUT              TransportRequest_Command = 2
+	TransportRequest_DELETE           TransportRequest_Command = 3
+	TransportRequest_SCAN             TransportRequest_Command = 4
+	TransportRequest_BATCH            TransportRequest_Command = 5
+	TransportRequest_SNAPSHOT_OPEN    TransportRequest_Command = 6
+	TransportRequest_SNAPSHOT_RELEASE TransportRequest_Command = 7
+	TransportRequest_CAS              TransportRequest_Command = 8
+	TransportRequest_PUT_IF_ABSENT    TransportRequest_Command = 9
+	TransportRequest_DELETE_IF_EQUAL  TransportRequest_Command = 10
+	// DB_CREATE and DB_DROP create and drop the database named by the
+	// database field
+	TransportRequest_DB_CREATE TransportRequest_Command = 11
+	TransportRequest_DB_DROP   TransportRequest_Command = 12
+	// BACKUP streams all pairs of a snapshot like SCAN, or writes them
+	// to path on the server; RESTORE loads the backup at path into an
+	// empty database
+	TransportRequest_BACKUP  TransportRequest_Command = 13
+	TransportRequest_RESTORE TransportRequest_Command = 14
+	// REPL_SNAPSHOT streams all pairs like BACKUP after a first response
+	// holding the log position of the snapshot; REPL_TAIL streams the
+	// log entries after log_seq; REPL_STATUS reports the replication
+	// state of the server
+	TransportRequest_REPL_SNAPSHOT TransportRequest_Command = 15
+	TransportRequest_REPL_TAIL     TransportRequest_Command = 16
+	TransportRequest_REPL_STATUS   TransportRequest_Command = 17
+	// CLUSTER_JOIN adds the node node_id at node_address to the raft
+	// cluster, CLUSTER_LEAVE removes node_id; CLUSTER_STATUS reports the
+	// leader and the members
+	TransportRequest_CLUSTER_JOIN   TransportRequest_Command = 18
+	TransportRequest_CLUSTER_LEAVE  TransportRequest_Command = 19
+	TransportRequest_CLUSTER_STATUS TransportRequest_Command = 20
+	// TTL reports the time to live of a key in the ttl field of the
+	// response, -1 if it does not expire; PERSIST removes its expiry
+	TransportRequest_TTL     TransportRequest_Command = 21
+	TransportRequest_PERSIST TransportRequest_Command = 22
+	// REPLACE puts the key only if it exists
+	TransportRequest_REPLACE TransportRequest_Command = 23
 )
 
 var TransportRequest_Command_name = map[int32]string{
-	0: "UNKNOWN",
-	1: "GET",
-	2: "PUT",
-	3: "DELETE",
+	0:  "UNKNOWN",
+	1:  "GET",
+	2:  "PUT",
+	3:  "DELETE",
+	4:  "SCAN",
+	5:  "BATCH",
+	6:  "SNAPSHOT_OPEN",
+	7:  "SNAPSHOT_RELEASE",
+	8:  "CAS",
+	9:  "PUT_IF_ABSENT",
+	10: "DELETE_IF_EQUAL",
+	11: "DB_CREATE",
+	12: "DB_DROP",
+	13: "BACKUP",
+	14: "RESTORE",
+	15: "REPL_SNAPSHOT",
+	16: "REPL_TAIL",
+	17: "REPL_STATUS",
+	18: "CLUSTER_JOIN",
+	19: "CLUSTER_LEAVE",
+	20: "CLUSTER_STATUS",
+	21: "TTL",
+	22: "PERSIST",
+	23: "REPLACE",
 }
+
 var TransportRequest_Command_value = map[string]int32{
-	"UNKNOWN": 0,
-	"GET":     1,
-	"PUT":     2,
-	"DELETE":  3,
+	"UNKNOWN":          0,
+	"GET":              1,
+	"PUT":              2,
+	"DELETE":           3,
+	"SCAN":             4,
+	"BATCH":            5,
+	"SNAPSHOT_OPEN":    6,
+	"SNAPSHOT_RELEASE": 7,
+	"CAS":              8,
+	"PUT_IF_ABSENT":    9,
+	"DELETE_IF_EQUAL":  10,
+	"DB_CREATE":        11,
+	"DB_DROP":          12,
+	"BACKUP":           13,
+	"RESTORE":          14,
+	"REPL_SNAPSHOT":    15,
+	"REPL_TAIL":        16,
+	"REPL_STATUS":      17,
+	"CLUSTER_JOIN":     18,
+	"CLUSTER_LEAVE":    19,
+	"CLUSTER_STATUS":   20,
+	"TTL":              21,
+	"PERSIST":          22,
+	"REPLACE":          23,
 }
 
 func (x TransportRequest_Command) Enum() *TransportRequest_Command {
@@ -62,9 +131,11 @@ func (x TransportRequest_Command) Enum() *TransportRequest_Command {
 	*p = x
 	return p
 }
+
 func (x TransportRequest_Command) String() string {
 	return proto.EnumName(TransportRequest_Command_name, int32(x))
 }
+
 func (x *TransportRequest_Command) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(TransportRequest_Command_value, data, "TransportRequest_Command")
 	if err != nil {
@@ -74,23 +145,55 @@ func (x *TransportRequest_Command) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (TransportRequest_Command) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{4, 0}
+}
+
 type TransportResponse_Status int32
 
 const (
-	TransportResponse_UNKNOWN TransportResponse_Status = 0
-	TransportResponse_OK      TransportResponse_Status = 1
-	TransportResponse_FAIL    TransportResponse_Status = 2
+	TransportResponse_UNKNOWN           TransportResponse_Status = 0
+	TransportResponse_OK                TransportResponse_Status = 1
+	TransportResponse_FAIL              TransportResponse_Status = 2
+	TransportResponse_NOT_FOUND         TransportResponse_Status = 3
+	TransportResponse_BAD_REQUEST       TransportResponse_Status = 4
+	TransportResponse_CHECKSUM_MISMATCH TransportResponse_Status = 5
+	TransportResponse_INTERNAL          TransportResponse_Status = 6
+	TransportResponse_CONDITION_FAILED  TransportResponse_Status = 7
+	TransportResponse_DENIED            TransportResponse_Status = 8
+	// READ_ONLY rejects writes sent to a replica
+	TransportResponse_READ_ONLY TransportResponse_Status = 9
+	// NOT_LEADER rejects writes sent to a cluster follower; leader is
+	// set if the follower knows the leader
+	TransportResponse_NOT_LEADER TransportResponse_Status = 10
 )
 
 var TransportResponse_Status_name = map[int32]string{
-	0: "UNKNOWN",
-	1: "OK",
-	2: "FAIL",
+	0:  "UNKNOWN",
+	1:  "OK",
+	2:  "FAIL",
+	3:  "NOT_FOUND",
+	4:  "BAD_REQUEST",
+	5:  "CHECKSUM_MISMATCH",
+	6:  "INTERNAL",
+	7:  "CONDITION_FAILED",
+	8:  "DENIED",
+	9:  "READ_ONLY",
+	10: "NOT_LEADER",
 }
+
 var TransportResponse_Status_value = map[string]int32{
-	"UNKNOWN": 0,
-	"OK":      1,
-	"FAIL":    2,
+	"UNKNOWN":           0,
+	"OK":                1,
+	"FAIL":              2,
+	"NOT_FOUND":         3,
+	"BAD_REQUEST":       4,
+	"CHECKSUM_MISMATCH": 5,
+	"INTERNAL":          6,
+	"CONDITION_FAILED":  7,
+	"DENIED":            8,
+	"READ_ONLY":         9,
+	"NOT_LEADER":        10,
 }
 
 func (x TransportResponse_Status) Enum() *TransportResponse_Status {
@@ -98,9 +201,11 @@ func (x TransportResponse_Status) Enum() *TransportResponse_Status {
 	*p = x
 	return p
 }
+
 func (x TransportResponse_Status) String() string {
 	return proto.EnumName(TransportResponse_Status_name, int32(x))
 }
+
 func (x *TransportResponse_Status) UnmarshalJSON(data []byte) error {
 	value, err := proto.UnmarshalJSONEnum(TransportResponse_Status_value, data, "TransportResponse_Status")
 	if err != nil {
@@ -110,15 +215,50 @@ func (x *TransportResponse_Status) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (TransportResponse_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{5, 0}
+}
+
 type TransportBody struct {
-	Checksum         *uint32 `protobuf:"varint,1,req,name=checksum" json:"checksum,omitempty"`
-	Data             []byte  `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
-	XXX_unrecognized []byte  `json:"-"`
+	Checksum             *uint32  `protobuf:"varint,1,req,name=checksum" json:"checksum,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TransportBody) Reset()         { *m = TransportBody{} }
 func (m *TransportBody) String() string { return proto.CompactTextString(m) }
 func (*TransportBody) ProtoMessage()    {}
+func (*TransportBody) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{0}
+}
+func (m *TransportBody) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransportBody) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransportBody.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransportBody) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransportBody.Merge(m, src)
+}
+func (m *TransportBody) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransportBody) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransportBody.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransportBody proto.InternalMessageInfo
 
 func (m *TransportBody) GetChecksum() uint32 {
 	if m != nil && m.Checksum != nil {
@@ -134,16 +274,303 @@ func (m *TransportBody) GetData() []byte {
 	return nil
 }
 
+type TransportRange struct {
+	Start                []byte   `protobuf:"bytes,1,opt,name=start" json:"start,omitempty"`
+	End                  []byte   `protobuf:"bytes,2,opt,name=end" json:"end,omitempty"`
+	Prefix               []byte   `protobuf:"bytes,3,opt,name=prefix" json:"prefix,omitempty"`
+	Limit                *uint32  `protobuf:"varint,4,opt,name=limit" json:"limit,omitempty"`
+	Reverse              *bool    `protobuf:"varint,5,opt,name=reverse" json:"reverse,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransportRange) Reset()         { *m = TransportRange{} }
+func (m *TransportRange) String() string { return proto.CompactTextString(m) }
+func (*TransportRange) ProtoMessage()    {}
+func (*TransportRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{1}
+}
+func (m *TransportRange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransportRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransportRange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransportRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransportRange.Merge(m, src)
+}
+func (m *TransportRange) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransportRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransportRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransportRange proto.InternalMessageInfo
+
+func (m *TransportRange) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *TransportRange) GetEnd() []byte {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *TransportRange) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+func (m *TransportRange) GetLimit() uint32 {
+	if m != nil && m.Limit != nil {
+		return *m.Limit
+	}
+	return 0
+}
+
+func (m *TransportRange) GetReverse() bool {
+	if m != nil && m.Reverse != nil {
+		return *m.Reverse
+	}
+	return false
+}
+
+type TransportPair struct {
+	Key   []byte         `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
+	Value *TransportBody `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	// the time the key expires at in unix nanoseconds, the flags and the
+	// version of the value, in scans and backups
+	Expires              *int64   `protobuf:"varint,3,opt,name=expires" json:"expires,omitempty"`
+	Flags                *uint32  `protobuf:"varint,4,opt,name=flags" json:"flags,omitempty"`
+	Version              *uint64  `protobuf:"varint,5,opt,name=version" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransportPair) Reset()         { *m = TransportPair{} }
+func (m *TransportPair) String() string { return proto.CompactTextString(m) }
+func (*TransportPair) ProtoMessage()    {}
+func (*TransportPair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{2}
+}
+func (m *TransportPair) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransportPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransportPair.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransportPair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransportPair.Merge(m, src)
+}
+func (m *TransportPair) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransportPair) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransportPair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransportPair proto.InternalMessageInfo
+
+func (m *TransportPair) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *TransportPair) GetValue() *TransportBody {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *TransportPair) GetExpires() int64 {
+	if m != nil && m.Expires != nil {
+		return *m.Expires
+	}
+	return 0
+}
+
+func (m *TransportPair) GetFlags() uint32 {
+	if m != nil && m.Flags != nil {
+		return *m.Flags
+	}
+	return 0
+}
+
+func (m *TransportPair) GetVersion() uint64 {
+	if m != nil && m.Version != nil {
+		return *m.Version
+	}
+	return 0
+}
+
+type TransportOperation struct {
+	Command              *TransportRequest_Command `protobuf:"varint,1,req,name=command,enum=ldbserver.TransportRequest_Command" json:"command,omitempty"`
+	Key                  []byte                    `protobuf:"bytes,2,req,name=key" json:"key,omitempty"`
+	Body                 *TransportBody            `protobuf:"bytes,3,opt,name=body" json:"body,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *TransportOperation) Reset()         { *m = TransportOperation{} }
+func (m *TransportOperation) String() string { return proto.CompactTextString(m) }
+func (*TransportOperation) ProtoMessage()    {}
+func (*TransportOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{3}
+}
+func (m *TransportOperation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransportOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransportOperation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransportOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransportOperation.Merge(m, src)
+}
+func (m *TransportOperation) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransportOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransportOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransportOperation proto.InternalMessageInfo
+
+func (m *TransportOperation) GetCommand() TransportRequest_Command {
+	if m != nil && m.Command != nil {
+		return *m.Command
+	}
+	return TransportRequest_UNKNOWN
+}
+
+func (m *TransportOperation) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *TransportOperation) GetBody() *TransportBody {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
 type TransportRequest struct {
-	Id               []byte                    `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
-	Command          *TransportRequest_Command `protobuf:"varint,2,req,name=command,enum=ldbserver.TransportRequest_Command" json:"command,omitempty"`
-	Body             *TransportBody            `protobuf:"bytes,3,opt,name=body" json:"body,omitempty"`
-	XXX_unrecognized []byte                    `json:"-"`
+	// id is the key of requests sent by clients without the key field
+	Id []byte `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// command is required, except by the gRPC methods, which imply it
+	Command *TransportRequest_Command `protobuf:"varint,2,opt,name=command,enum=ldbserver.TransportRequest_Command" json:"command,omitempty"`
+	Body    *TransportBody            `protobuf:"bytes,3,opt,name=body" json:"body,omitempty"`
+	Range   *TransportRange           `protobuf:"bytes,4,opt,name=range" json:"range,omitempty"`
+	Batch   []*TransportOperation     `protobuf:"bytes,5,rep,name=batch" json:"batch,omitempty"`
+	Sync    *bool                     `protobuf:"varint,6,opt,name=sync" json:"sync,omitempty"`
+	Key     []byte                    `protobuf:"bytes,7,opt,name=key" json:"key,omitempty"`
+	// seq is echoed in every response to the request
+	Seq *uint64 `protobuf:"varint,8,opt,name=seq" json:"seq,omitempty"`
+	// snapshot is a handle returned by SNAPSHOT_OPEN on the same connection
+	Snapshot *uint64 `protobuf:"varint,9,opt,name=snapshot" json:"snapshot,omitempty"`
+	// expected is the value required by CAS and DELETE_IF_EQUAL; CAS without
+	// it requires the key to be absent
+	Expected *TransportBody `protobuf:"bytes,10,opt,name=expected" json:"expected,omitempty"`
+	Token    *string        `protobuf:"bytes,11,opt,name=token" json:"token,omitempty"`
+	// database names the database of the request; empty means the default one
+	Database *string `protobuf:"bytes,12,opt,name=database" json:"database,omitempty"`
+	// path names a backup file relative to the backup directory of the server
+	Path *string `protobuf:"bytes,13,opt,name=path" json:"path,omitempty"`
+	// log_id and log_seq are the log position a replica has applied
+	LogId  *string `protobuf:"bytes,14,opt,name=log_id,json=logId" json:"log_id,omitempty"`
+	LogSeq *uint64 `protobuf:"varint,15,opt,name=log_seq,json=logSeq" json:"log_seq,omitempty"`
+	// node_id and node_address name a cluster member and its raft address
+	NodeId      *string `protobuf:"bytes,16,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+	NodeAddress *string `protobuf:"bytes,17,opt,name=node_address,json=nodeAddress" json:"node_address,omitempty"`
+	// ttl makes PUT, CAS, PUT_IF_ABSENT and REPLACE expire the key after
+	// ttl milliseconds
+	Ttl *uint64 `protobuf:"varint,18,opt,name=ttl" json:"ttl,omitempty"`
+	// expected_version replaces expected in CAS and DELETE_IF_EQUAL; it
+	// requires the key to exist with that version, as returned by GET
+	ExpectedVersion *uint64 `protobuf:"varint,19,opt,name=expected_version,json=expectedVersion" json:"expected_version,omitempty"`
+	// flags are kept with the value written by PUT, CAS, PUT_IF_ABSENT and
+	// REPLACE and returned by GET, for memcached clients
+	Flags                *uint32  `protobuf:"varint,20,opt,name=flags" json:"flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TransportRequest) Reset()         { *m = TransportRequest{} }
 func (m *TransportRequest) String() string { return proto.CompactTextString(m) }
 func (*TransportRequest) ProtoMessage()    {}
+func (*TransportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{4}
+}
+func (m *TransportRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransportRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransportRequest.Merge(m, src)
+}
+func (m *TransportRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransportRequest proto.InternalMessageInfo
 
 func (m *TransportRequest) GetId() []byte {
 	if m != nil {
@@ -166,16 +593,185 @@ func (m *TransportRequest) GetBody() *TransportBody {
 	return nil
 }
 
+func (m *TransportRequest) GetRange() *TransportRange {
+	if m != nil {
+		return m.Range
+	}
+	return nil
+}
+
+func (m *TransportRequest) GetBatch() []*TransportOperation {
+	if m != nil {
+		return m.Batch
+	}
+	return nil
+}
+
+func (m *TransportRequest) GetSync() bool {
+	if m != nil && m.Sync != nil {
+		return *m.Sync
+	}
+	return false
+}
+
+func (m *TransportRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *TransportRequest) GetSeq() uint64 {
+	if m != nil && m.Seq != nil {
+		return *m.Seq
+	}
+	return 0
+}
+
+func (m *TransportRequest) GetSnapshot() uint64 {
+	if m != nil && m.Snapshot != nil {
+		return *m.Snapshot
+	}
+	return 0
+}
+
+func (m *TransportRequest) GetExpected() *TransportBody {
+	if m != nil {
+		return m.Expected
+	}
+	return nil
+}
+
+func (m *TransportRequest) GetToken() string {
+	if m != nil && m.Token != nil {
+		return *m.Token
+	}
+	return ""
+}
+
+func (m *TransportRequest) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *TransportRequest) GetPath() string {
+	if m != nil && m.Path != nil {
+		return *m.Path
+	}
+	return ""
+}
+
+func (m *TransportRequest) GetLogId() string {
+	if m != nil && m.LogId != nil {
+		return *m.LogId
+	}
+	return ""
+}
+
+func (m *TransportRequest) GetLogSeq() uint64 {
+	if m != nil && m.LogSeq != nil {
+		return *m.LogSeq
+	}
+	return 0
+}
+
+func (m *TransportRequest) GetNodeId() string {
+	if m != nil && m.NodeId != nil {
+		return *m.NodeId
+	}
+	return ""
+}
+
+func (m *TransportRequest) GetNodeAddress() string {
+	if m != nil && m.NodeAddress != nil {
+		return *m.NodeAddress
+	}
+	return ""
+}
+
+func (m *TransportRequest) GetTtl() uint64 {
+	if m != nil && m.Ttl != nil {
+		return *m.Ttl
+	}
+	return 0
+}
+
+func (m *TransportRequest) GetExpectedVersion() uint64 {
+	if m != nil && m.ExpectedVersion != nil {
+		return *m.ExpectedVersion
+	}
+	return 0
+}
+
+func (m *TransportRequest) GetFlags() uint32 {
+	if m != nil && m.Flags != nil {
+		return *m.Flags
+	}
+	return 0
+}
+
 type TransportResponse struct {
-	Id               []byte                    `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
-	Status           *TransportResponse_Status `protobuf:"varint,2,req,name=status,enum=ldbserver.TransportResponse_Status" json:"status,omitempty"`
-	Body             *TransportBody            `protobuf:"bytes,3,opt,name=body" json:"body,omitempty"`
-	XXX_unrecognized []byte                    `json:"-"`
+	Id       []byte                    `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Status   *TransportResponse_Status `protobuf:"varint,2,req,name=status,enum=ldbserver.TransportResponse_Status" json:"status,omitempty"`
+	Body     *TransportBody            `protobuf:"bytes,3,opt,name=body" json:"body,omitempty"`
+	Pairs    []*TransportPair          `protobuf:"bytes,4,rep,name=pairs" json:"pairs,omitempty"`
+	More     *bool                     `protobuf:"varint,5,opt,name=more" json:"more,omitempty"`
+	Seq      *uint64                   `protobuf:"varint,6,opt,name=seq" json:"seq,omitempty"`
+	Snapshot *uint64                   `protobuf:"varint,7,opt,name=snapshot" json:"snapshot,omitempty"`
+	// log_id and log_seq are the replication log and its last sequence
+	LogId       *string            `protobuf:"bytes,8,opt,name=log_id,json=logId" json:"log_id,omitempty"`
+	LogSeq      *uint64            `protobuf:"varint,9,opt,name=log_seq,json=logSeq" json:"log_seq,omitempty"`
+	Entries     []*LogEntry        `protobuf:"bytes,10,rep,name=entries" json:"entries,omitempty"`
+	Replication *ReplicationStatus `protobuf:"bytes,11,opt,name=replication" json:"replication,omitempty"`
+	// leader is the id of the cluster leader, the address clients reach it at
+	Leader  *string        `protobuf:"bytes,12,opt,name=leader" json:"leader,omitempty"`
+	Cluster *ClusterStatus `protobuf:"bytes,13,opt,name=cluster" json:"cluster,omitempty"`
+	// ttl is the time to live in milliseconds reported by TTL
+	Ttl *int64 `protobuf:"varint,14,opt,name=ttl" json:"ttl,omitempty"`
+	// flags are those written with the value returned by GET
+	Flags *uint32 `protobuf:"varint,15,opt,name=flags" json:"flags,omitempty"`
+	// version is that of the value returned by GET; it changes with every
+	// write of the key
+	Version              *uint64  `protobuf:"varint,16,opt,name=version" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TransportResponse) Reset()         { *m = TransportResponse{} }
 func (m *TransportResponse) String() string { return proto.CompactTextString(m) }
 func (*TransportResponse) ProtoMessage()    {}
+func (*TransportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{5}
+}
+func (m *TransportResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransportResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransportResponse.Merge(m, src)
+}
+func (m *TransportResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransportResponse proto.InternalMessageInfo
 
 func (m *TransportResponse) GetId() []byte {
 	if m != nil {
@@ -198,695 +794,562 @@ func (m *TransportResponse) GetBody() *TransportBody {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("ldbserver.TransportRequest_Command", TransportRequest_Command_name, TransportRequest_Command_value)
-	proto.RegisterEnum("ldbserver.TransportResponse_Status", TransportResponse_Status_name, TransportResponse_Status_value)
+func (m *TransportResponse) GetPairs() []*TransportPair {
+	if m != nil {
+		return m.Pairs
+	}
+	return nil
 }
-func (m *TransportBody) Unmarshal(data []byte) error {
-	l := len(data)
-	index := 0
-	for index < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if index >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := data[index]
-			index++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
-			}
-			var v uint32
-			for shift := uint(0); ; shift += 7 {
-				if index >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[index]
-				index++
-				v |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Checksum = &v
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if index >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[index]
-				index++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			postIndex := index + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append([]byte{}, data[index:postIndex]...)
-			index = postIndex
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
-					break
-				}
-			}
-			index -= sizeOfWire
-			skippy, err := github_com_gogo_protobuf_proto.Skip(data[index:])
-			if err != nil {
-				return err
-			}
-			if (index + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, data[index:index+skippy]...)
-			index += skippy
-		}
+
+func (m *TransportResponse) GetMore() bool {
+	if m != nil && m.More != nil {
+		return *m.More
 	}
-	return nil
+	return false
 }
-func (m *TransportRequest) Unmarshal(data []byte) error {
-	l := len(data)
-	index := 0
-	for index < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if index >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := data[index]
-			index++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if index >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[index]
-				index++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			postIndex := index + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Id = append([]byte{}, data[index:postIndex]...)
-			index = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Command", wireType)
-			}
-			var v TransportRequest_Command
-			for shift := uint(0); ; shift += 7 {
-				if index >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[index]
-				index++
-				v |= (TransportRequest_Command(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Command = &v
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if index >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[index]
-				index++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			postIndex := index + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Body == nil {
-				m.Body = &TransportBody{}
-			}
-			if err := m.Body.Unmarshal(data[index:postIndex]); err != nil {
-				return err
-			}
-			index = postIndex
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
-					break
-				}
-			}
-			index -= sizeOfWire
-			skippy, err := github_com_gogo_protobuf_proto.Skip(data[index:])
-			if err != nil {
-				return err
-			}
-			if (index + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, data[index:index+skippy]...)
-			index += skippy
-		}
+
+func (m *TransportResponse) GetSeq() uint64 {
+	if m != nil && m.Seq != nil {
+		return *m.Seq
 	}
-	return nil
+	return 0
 }
-func (m *TransportResponse) Unmarshal(data []byte) error {
-	l := len(data)
-	index := 0
-	for index < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if index >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := data[index]
-			index++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if index >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[index]
-				index++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			postIndex := index + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Id = append([]byte{}, data[index:postIndex]...)
-			index = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
-			}
-			var v TransportResponse_Status
-			for shift := uint(0); ; shift += 7 {
-				if index >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[index]
-				index++
-				v |= (TransportResponse_Status(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Status = &v
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if index >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[index]
-				index++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			postIndex := index + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Body == nil {
-				m.Body = &TransportBody{}
-			}
-			if err := m.Body.Unmarshal(data[index:postIndex]); err != nil {
-				return err
-			}
-			index = postIndex
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
-					break
-				}
-			}
-			index -= sizeOfWire
-			skippy, err := github_com_gogo_protobuf_proto.Skip(data[index:])
-			if err != nil {
-				return err
-			}
-			if (index + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, data[index:index+skippy]...)
-			index += skippy
-		}
+
+func (m *TransportResponse) GetSnapshot() uint64 {
+	if m != nil && m.Snapshot != nil {
+		return *m.Snapshot
 	}
-	return nil
+	return 0
 }
-func (m *TransportBody) Size() (n int) {
-	var l int
-	_ = l
-	if m.Checksum != nil {
-		n += 1 + sovTransport(uint64(*m.Checksum))
-	}
-	if m.Data != nil {
-		l = len(m.Data)
-		n += 1 + l + sovTransport(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+
+func (m *TransportResponse) GetLogId() string {
+	if m != nil && m.LogId != nil {
+		return *m.LogId
 	}
-	return n
+	return ""
 }
 
-func (m *TransportRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = len(m.Id)
-		n += 1 + l + sovTransport(uint64(l))
-	}
-	if m.Command != nil {
-		n += 1 + sovTransport(uint64(*m.Command))
+func (m *TransportResponse) GetLogSeq() uint64 {
+	if m != nil && m.LogSeq != nil {
+		return *m.LogSeq
 	}
-	if m.Body != nil {
-		l = m.Body.Size()
-		n += 1 + l + sovTransport(uint64(l))
+	return 0
+}
+
+func (m *TransportResponse) GetEntries() []*LogEntry {
+	if m != nil {
+		return m.Entries
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+func (m *TransportResponse) GetReplication() *ReplicationStatus {
+	if m != nil {
+		return m.Replication
 	}
-	return n
+	return nil
 }
 
-func (m *TransportResponse) Size() (n int) {
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = len(m.Id)
-		n += 1 + l + sovTransport(uint64(l))
+func (m *TransportResponse) GetLeader() string {
+	if m != nil && m.Leader != nil {
+		return *m.Leader
 	}
-	if m.Status != nil {
-		n += 1 + sovTransport(uint64(*m.Status))
+	return ""
+}
+
+func (m *TransportResponse) GetCluster() *ClusterStatus {
+	if m != nil {
+		return m.Cluster
 	}
-	if m.Body != nil {
-		l = m.Body.Size()
-		n += 1 + l + sovTransport(uint64(l))
+	return nil
+}
+
+func (m *TransportResponse) GetTtl() int64 {
+	if m != nil && m.Ttl != nil {
+		return *m.Ttl
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return 0
+}
+
+func (m *TransportResponse) GetFlags() uint32 {
+	if m != nil && m.Flags != nil {
+		return *m.Flags
 	}
-	return n
+	return 0
 }
 
-func sovTransport(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *TransportResponse) GetVersion() uint64 {
+	if m != nil && m.Version != nil {
+		return *m.Version
 	}
-	return n
+	return 0
 }
-func sozTransport(x uint64) (n int) {
-	return sovTransport(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+// LogEntry is one mutation of the replication log. The operations of a batch
+// share one entry.
+type LogEntry struct {
+	Seq                  *uint64               `protobuf:"varint,1,req,name=seq" json:"seq,omitempty"`
+	Ops                  []*TransportOperation `protobuf:"bytes,2,rep,name=ops" json:"ops,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
-func NewPopulatedTransportBody(r randyTransport, easy bool) *TransportBody {
-	this := &TransportBody{}
-	v1 := r.Uint32()
-	this.Checksum = &v1
-	if r.Intn(10) != 0 {
-		v2 := r.Intn(100)
-		this.Data = make([]byte, v2)
-		for i := 0; i < v2; i++ {
-			this.Data[i] = byte(r.Intn(256))
+
+func (m *LogEntry) Reset()         { *m = LogEntry{} }
+func (m *LogEntry) String() string { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()    {}
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{6}
+}
+func (m *LogEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTransport(r, 3)
+}
+func (m *LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEntry.Merge(m, src)
+}
+func (m *LogEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogEntry proto.InternalMessageInfo
+
+func (m *LogEntry) GetSeq() uint64 {
+	if m != nil && m.Seq != nil {
+		return *m.Seq
 	}
-	return this
+	return 0
 }
 
-func NewPopulatedTransportRequest(r randyTransport, easy bool) *TransportRequest {
-	this := &TransportRequest{}
-	v3 := r.Intn(100)
-	this.Id = make([]byte, v3)
-	for i := 0; i < v3; i++ {
-		this.Id[i] = byte(r.Intn(256))
-	}
-	v4 := TransportRequest_Command([]int32{0, 1, 2, 3}[r.Intn(4)])
-	this.Command = &v4
-	if r.Intn(10) != 0 {
-		this.Body = NewPopulatedTransportBody(r, easy)
+func (m *LogEntry) GetOps() []*TransportOperation {
+	if m != nil {
+		return m.Ops
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTransport(r, 4)
-	}
-	return this
-}
-
-func NewPopulatedTransportResponse(r randyTransport, easy bool) *TransportResponse {
-	this := &TransportResponse{}
-	v5 := r.Intn(100)
-	this.Id = make([]byte, v5)
-	for i := 0; i < v5; i++ {
-		this.Id[i] = byte(r.Intn(256))
-	}
-	v6 := TransportResponse_Status([]int32{0, 1, 2}[r.Intn(3)])
-	this.Status = &v6
-	if r.Intn(10) != 0 {
-		this.Body = NewPopulatedTransportBody(r, easy)
-	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTransport(r, 4)
-	}
-	return this
+	return nil
 }
 
-type randyTransport interface {
-	Float32() float32
-	Float64() float64
-	Int63() int64
-	Int31() int32
-	Uint32() uint32
-	Intn(n int) int
+type ReplicationStatus struct {
+	// role is "primary", "replica" or empty if replication is disabled
+	Role      *string `protobuf:"bytes,1,opt,name=role" json:"role,omitempty"`
+	Primary   *string `protobuf:"bytes,2,opt,name=primary" json:"primary,omitempty"`
+	Connected *bool   `protobuf:"varint,3,opt,name=connected" json:"connected,omitempty"`
+	LogId     *string `protobuf:"bytes,4,opt,name=log_id,json=logId" json:"log_id,omitempty"`
+	// log_seq is the last sequence logged by a primary or applied by a replica
+	LogSeq               *uint64  `protobuf:"varint,5,opt,name=log_seq,json=logSeq" json:"log_seq,omitempty"`
+	PrimarySeq           *uint64  `protobuf:"varint,6,opt,name=primary_seq,json=primarySeq" json:"primary_seq,omitempty"`
+	LagSeconds           *float64 `protobuf:"fixed64,7,opt,name=lag_seconds,json=lagSeconds" json:"lag_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func randUTF8RuneTransport(r randyTransport) rune {
-	return rune(r.Intn(126-43) + 43)
+func (m *ReplicationStatus) Reset()         { *m = ReplicationStatus{} }
+func (m *ReplicationStatus) String() string { return proto.CompactTextString(m) }
+func (*ReplicationStatus) ProtoMessage()    {}
+func (*ReplicationStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{7}
 }
-func randStringTransport(r randyTransport) string {
-	v7 := r.Intn(100)
-	tmps := make([]rune, v7)
-	for i := 0; i < v7; i++ {
-		tmps[i] = randUTF8RuneTransport(r)
-	}
-	return string(tmps)
+func (m *ReplicationStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func randUnrecognizedTransport(r randyTransport, maxFieldNumber int) (data []byte) {
-	l := r.Intn(5)
-	for i := 0; i < l; i++ {
-		wire := r.Intn(4)
-		if wire == 3 {
-			wire = 5
+func (m *ReplicationStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplicationStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		fieldNumber := maxFieldNumber + r.Intn(100)
-		data = randFieldTransport(data, r, fieldNumber, wire)
+		return b[:n], nil
 	}
-	return data
 }
-func randFieldTransport(data []byte, r randyTransport, fieldNumber int, wire int) []byte {
-	key := uint32(fieldNumber)<<3 | uint32(wire)
-	switch wire {
-	case 0:
-		data = encodeVarintPopulateTransport(data, uint64(key))
-		v8 := r.Int63()
-		if r.Intn(2) == 0 {
-			v8 *= -1
-		}
-		data = encodeVarintPopulateTransport(data, uint64(v8))
-	case 1:
-		data = encodeVarintPopulateTransport(data, uint64(key))
-		data = append(data, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
-	case 2:
-		data = encodeVarintPopulateTransport(data, uint64(key))
-		ll := r.Intn(100)
-		data = encodeVarintPopulateTransport(data, uint64(ll))
-		for j := 0; j < ll; j++ {
-			data = append(data, byte(r.Intn(256)))
-		}
-	default:
-		data = encodeVarintPopulateTransport(data, uint64(key))
-		data = append(data, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
-	}
-	return data
+func (m *ReplicationStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicationStatus.Merge(m, src)
 }
-func encodeVarintPopulateTransport(data []byte, v uint64) []byte {
-	for v >= 1<<7 {
-		data = append(data, uint8(uint64(v)&0x7f|0x80))
-		v >>= 7
-	}
-	data = append(data, uint8(v))
-	return data
+func (m *ReplicationStatus) XXX_Size() int {
+	return m.Size()
 }
-func (m *TransportBody) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+func (m *ReplicationStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicationStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicationStatus proto.InternalMessageInfo
+
+func (m *ReplicationStatus) GetRole() string {
+	if m != nil && m.Role != nil {
+		return *m.Role
 	}
-	return data[:n], nil
+	return ""
 }
 
-func (m *TransportBody) MarshalTo(data []byte) (n int, err error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Checksum != nil {
-		data[i] = 0x8
-		i++
-		i = encodeVarintTransport(data, i, uint64(*m.Checksum))
+func (m *ReplicationStatus) GetPrimary() string {
+	if m != nil && m.Primary != nil {
+		return *m.Primary
 	}
-	if m.Data != nil {
-		data[i] = 0x12
-		i++
-		i = encodeVarintTransport(data, i, uint64(len(m.Data)))
-		i += copy(data[i:], m.Data)
+	return ""
+}
+
+func (m *ReplicationStatus) GetConnected() bool {
+	if m != nil && m.Connected != nil {
+		return *m.Connected
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(data[i:], m.XXX_unrecognized)
+	return false
+}
+
+func (m *ReplicationStatus) GetLogId() string {
+	if m != nil && m.LogId != nil {
+		return *m.LogId
 	}
-	return i, nil
+	return ""
 }
 
-func (m *TransportRequest) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+func (m *ReplicationStatus) GetLogSeq() uint64 {
+	if m != nil && m.LogSeq != nil {
+		return *m.LogSeq
 	}
-	return data[:n], nil
+	return 0
 }
 
-func (m *TransportRequest) MarshalTo(data []byte) (n int, err error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		data[i] = 0xa
-		i++
-		i = encodeVarintTransport(data, i, uint64(len(m.Id)))
-		i += copy(data[i:], m.Id)
+func (m *ReplicationStatus) GetPrimarySeq() uint64 {
+	if m != nil && m.PrimarySeq != nil {
+		return *m.PrimarySeq
 	}
-	if m.Command != nil {
-		data[i] = 0x10
-		i++
-		i = encodeVarintTransport(data, i, uint64(*m.Command))
+	return 0
+}
+
+func (m *ReplicationStatus) GetLagSeconds() float64 {
+	if m != nil && m.LagSeconds != nil {
+		return *m.LagSeconds
 	}
-	if m.Body != nil {
-		data[i] = 0x1a
-		i++
-		i = encodeVarintTransport(data, i, uint64(m.Body.Size()))
-		n1, err := m.Body.MarshalTo(data[i:])
+	return 0
+}
+
+type ClusterStatus struct {
+	NodeId *string `protobuf:"bytes,1,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+	// state is the raft state of the node: Leader, Follower or Candidate
+	State                *string        `protobuf:"bytes,2,opt,name=state" json:"state,omitempty"`
+	Leader               *string        `protobuf:"bytes,3,opt,name=leader" json:"leader,omitempty"`
+	Nodes                []*ClusterNode `protobuf:"bytes,4,rep,name=nodes" json:"nodes,omitempty"`
+	AppliedIndex         *uint64        `protobuf:"varint,5,opt,name=applied_index,json=appliedIndex" json:"applied_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ClusterStatus) Reset()         { *m = ClusterStatus{} }
+func (m *ClusterStatus) String() string { return proto.CompactTextString(m) }
+func (*ClusterStatus) ProtoMessage()    {}
+func (*ClusterStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{8}
+}
+func (m *ClusterStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClusterStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ClusterStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n1
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(data[i:], m.XXX_unrecognized)
+}
+func (m *ClusterStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterStatus.Merge(m, src)
+}
+func (m *ClusterStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClusterStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterStatus proto.InternalMessageInfo
+
+func (m *ClusterStatus) GetNodeId() string {
+	if m != nil && m.NodeId != nil {
+		return *m.NodeId
 	}
-	return i, nil
+	return ""
 }
 
-func (m *TransportResponse) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+func (m *ClusterStatus) GetState() string {
+	if m != nil && m.State != nil {
+		return *m.State
 	}
-	return data[:n], nil
+	return ""
 }
 
-func (m *TransportResponse) MarshalTo(data []byte) (n int, err error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		data[i] = 0xa
-		i++
-		i = encodeVarintTransport(data, i, uint64(len(m.Id)))
-		i += copy(data[i:], m.Id)
+func (m *ClusterStatus) GetLeader() string {
+	if m != nil && m.Leader != nil {
+		return *m.Leader
 	}
-	if m.Status != nil {
-		data[i] = 0x10
-		i++
-		i = encodeVarintTransport(data, i, uint64(*m.Status))
+	return ""
+}
+
+func (m *ClusterStatus) GetNodes() []*ClusterNode {
+	if m != nil {
+		return m.Nodes
 	}
-	if m.Body != nil {
-		data[i] = 0x1a
-		i++
-		i = encodeVarintTransport(data, i, uint64(m.Body.Size()))
-		n2, err := m.Body.MarshalTo(data[i:])
+	return nil
+}
+
+func (m *ClusterStatus) GetAppliedIndex() uint64 {
+	if m != nil && m.AppliedIndex != nil {
+		return *m.AppliedIndex
+	}
+	return 0
+}
+
+type ClusterNode struct {
+	Id                   *string  `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
+	Address              *string  `protobuf:"bytes,2,req,name=address" json:"address,omitempty"`
+	Voter                *bool    `protobuf:"varint,3,opt,name=voter" json:"voter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClusterNode) Reset()         { *m = ClusterNode{} }
+func (m *ClusterNode) String() string { return proto.CompactTextString(m) }
+func (*ClusterNode) ProtoMessage()    {}
+func (*ClusterNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{9}
+}
+func (m *ClusterNode) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClusterNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ClusterNode.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n2
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(data[i:], m.XXX_unrecognized)
-	}
-	return i, nil
-}
-
-func encodeFixed64Transport(data []byte, offset int, v uint64) int {
-	data[offset] = uint8(v)
-	data[offset+1] = uint8(v >> 8)
-	data[offset+2] = uint8(v >> 16)
-	data[offset+3] = uint8(v >> 24)
-	data[offset+4] = uint8(v >> 32)
-	data[offset+5] = uint8(v >> 40)
-	data[offset+6] = uint8(v >> 48)
-	data[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Transport(data []byte, offset int, v uint32) int {
-	data[offset] = uint8(v)
-	data[offset+1] = uint8(v >> 8)
-	data[offset+2] = uint8(v >> 16)
-	data[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintTransport(data []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		data[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+		return b[:n], nil
 	}
-	data[offset] = uint8(v)
-	return offset + 1
 }
-func (this *TransportBody) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&ldbserver.TransportBody{` +
-		`Checksum:` + valueToGoStringTransport(this.Checksum, "uint32"),
-		`Data:` + valueToGoStringTransport(this.Data, "byte"),
-		`XXX_unrecognized:` + fmt.Sprintf("%#v", this.XXX_unrecognized) + `}`}, ", ")
-	return s
+func (m *ClusterNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterNode.Merge(m, src)
 }
-func (this *TransportRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (m *ClusterNode) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClusterNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterNode proto.InternalMessageInfo
+
+func (m *ClusterNode) GetId() string {
+	if m != nil && m.Id != nil {
+		return *m.Id
 	}
-	s := strings.Join([]string{`&ldbserver.TransportRequest{` +
-		`Id:` + valueToGoStringTransport(this.Id, "byte"),
-		`Command:` + valueToGoStringTransport(this.Command, "ldbserver.TransportRequest_Command"),
-		`Body:` + fmt.Sprintf("%#v", this.Body),
-		`XXX_unrecognized:` + fmt.Sprintf("%#v", this.XXX_unrecognized) + `}`}, ", ")
-	return s
+	return ""
 }
-func (this *TransportResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *ClusterNode) GetAddress() string {
+	if m != nil && m.Address != nil {
+		return *m.Address
 	}
-	s := strings.Join([]string{`&ldbserver.TransportResponse{` +
-		`Id:` + valueToGoStringTransport(this.Id, "byte"),
-		`Status:` + valueToGoStringTransport(this.Status, "ldbserver.TransportResponse_Status"),
-		`Body:` + fmt.Sprintf("%#v", this.Body),
-		`XXX_unrecognized:` + fmt.Sprintf("%#v", this.XXX_unrecognized) + `}`}, ", ")
-	return s
+	return ""
 }
-func valueToGoStringTransport(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+func (m *ClusterNode) GetVoter() bool {
+	if m != nil && m.Voter != nil {
+		return *m.Voter
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+	return false
 }
-func extensionToGoStringTransport(e map[int32]github_com_gogo_protobuf_proto.Extension) string {
-	if e == nil {
-		return "nil"
+
+// BackupRecord is one record of a backup file. The last record holds only
+// count, the number of pairs before it.
+type BackupRecord struct {
+	Pair                 *TransportPair `protobuf:"bytes,1,opt,name=pair" json:"pair,omitempty"`
+	Count                *uint64        `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BackupRecord) Reset()         { *m = BackupRecord{} }
+func (m *BackupRecord) String() string { return proto.CompactTextString(m) }
+func (*BackupRecord) ProtoMessage()    {}
+func (*BackupRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a97e32c760ec1b28, []int{10}
+}
+func (m *BackupRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BackupRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BackupRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	s := "map[int32]proto.Extension{"
-	keys := make([]int, 0, len(e))
-	for k := range e {
-		keys = append(keys, int(k))
+}
+func (m *BackupRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRecord.Merge(m, src)
+}
+func (m *BackupRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *BackupRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupRecord proto.InternalMessageInfo
+
+func (m *BackupRecord) GetPair() *TransportPair {
+	if m != nil {
+		return m.Pair
 	}
-	sort.Ints(keys)
-	ss := []string{}
-	for _, k := range keys {
-		ss = append(ss, strconv.Itoa(k)+": "+e[int32(k)].GoString())
+	return nil
+}
+
+func (m *BackupRecord) GetCount() uint64 {
+	if m != nil && m.Count != nil {
+		return *m.Count
 	}
-	s += strings.Join(ss, ",") + "}"
-	return s
+	return 0
 }
+
+func init() {
+	proto.RegisterEnum("ldbserver.TransportRequest_Command", TransportRequest_Command_name, TransportRequest_Command_value)
+	proto.RegisterEnum("ldbserver.TransportResponse_Status", TransportResponse_Status_name, TransportResponse_Status_value)
+	proto.RegisterType((*TransportBody)(nil), "ldbserver.TransportBody")
+	proto.RegisterType((*TransportRange)(nil), "ldbserver.TransportRange")
+	proto.RegisterType((*TransportPair)(nil), "ldbserver.TransportPair")
+	proto.RegisterType((*TransportOperation)(nil), "ldbserver.TransportOperation")
+	proto.RegisterType((*TransportRequest)(nil), "ldbserver.TransportRequest")
+	proto.RegisterType((*TransportResponse)(nil), "ldbserver.TransportResponse")
+	proto.RegisterType((*LogEntry)(nil), "ldbserver.LogEntry")
+	proto.RegisterType((*ReplicationStatus)(nil), "ldbserver.ReplicationStatus")
+	proto.RegisterType((*ClusterStatus)(nil), "ldbserver.ClusterStatus")
+	proto.RegisterType((*ClusterNode)(nil), "ldbserver.ClusterNode")
+	proto.RegisterType((*BackupRecord)(nil), "ldbserver.BackupRecord")
+}
+
+func init() { proto.RegisterFile("transport.proto", fileDescriptor_a97e32c760ec1b28) }
+
+var fileDescriptor_a97e32c760ec1b28 = []byte{
+	// 1411 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x4d, 0x6f, 0xdb, 0x46,
+	0x13, 0x7e, 0xa9, 0x6f, 0x8d, 0x3e, 0xbc, 0x5e, 0x3b, 0x09, 0xdf, 0x20, 0x55, 0x5d, 0xa5, 0x07,
+	0x17, 0x48, 0x64, 0xc0, 0xed, 0xad, 0x68, 0x0b, 0x4a, 0x62, 0x1a, 0xd5, 0x32, 0xa5, 0x2c, 0xa9,
+	0x14, 0x3d, 0x11, 0xb4, 0xb8, 0x91, 0x09, 0xcb, 0x24, 0x43, 0x52, 0x86, 0x7d, 0xeb, 0x7f, 0xe8,
+	0xb1, 0x7f, 0xa0, 0x3d, 0xf4, 0xde, 0x63, 0x8f, 0x3d, 0xb6, 0x3f, 0xa0, 0x40, 0xe2, 0x4b, 0x6f,
+	0x45, 0x8f, 0x3d, 0x16, 0xb3, 0x4b, 0xca, 0x52, 0x6c, 0x23, 0xcd, 0x6d, 0x67, 0x76, 0x66, 0xf6,
+	0xd9, 0xe1, 0xf3, 0xec, 0x10, 0x36, 0x92, 0xc8, 0xf1, 0xe3, 0x30, 0x88, 0x92, 0x4e, 0x18, 0x05,
+	0x49, 0x40, 0xab, 0x73, 0xf7, 0x28, 0xe6, 0xd1, 0x19, 0x8f, 0xee, 0x3f, 0x9e, 0x79, 0xc9, 0xf1,
+	0xe2, 0xa8, 0x33, 0x0d, 0x4e, 0xf7, 0x66, 0xc1, 0x2c, 0xd8, 0x13, 0x11, 0x47, 0x8b, 0x17, 0xc2,
+	0x12, 0x86, 0x58, 0xc9, 0xcc, 0xf6, 0x17, 0xd0, 0xb0, 0xb2, 0x62, 0xdd, 0xc0, 0xbd, 0xa0, 0xf7,
+	0xa1, 0x32, 0x3d, 0xe6, 0xd3, 0x93, 0x78, 0x71, 0xaa, 0x2a, 0x3b, 0xb9, 0xdd, 0x06, 0x5b, 0xda,
+	0x94, 0x42, 0xc1, 0x75, 0x12, 0x47, 0xcd, 0xed, 0x28, 0xbb, 0x75, 0x26, 0xd6, 0xed, 0x6f, 0x15,
+	0x68, 0x2e, 0x2b, 0x30, 0xc7, 0x9f, 0x71, 0xba, 0x0d, 0xc5, 0x38, 0x71, 0xa2, 0x44, 0x55, 0x44,
+	0x9c, 0x34, 0x28, 0x81, 0x3c, 0xf7, 0xdd, 0x34, 0x17, 0x97, 0xf4, 0x2e, 0x94, 0xc2, 0x88, 0xbf,
+	0xf0, 0xce, 0xd5, 0xbc, 0x70, 0xa6, 0x16, 0xe6, 0xcf, 0xbd, 0x53, 0x2f, 0x51, 0x0b, 0x3b, 0xca,
+	0x6e, 0x83, 0x49, 0x83, 0xaa, 0x50, 0x8e, 0xf8, 0x19, 0x8f, 0x62, 0xae, 0x16, 0x77, 0x94, 0xdd,
+	0x0a, 0xcb, 0xcc, 0xf6, 0xf7, 0xca, 0xca, 0x25, 0xc6, 0x8e, 0x17, 0xe1, 0x59, 0x27, 0xfc, 0x42,
+	0xe0, 0xaf, 0x33, 0x5c, 0xd2, 0x0e, 0x14, 0xcf, 0x9c, 0xf9, 0x82, 0x8b, 0xf3, 0x6b, 0xfb, 0x6a,
+	0x67, 0xd9, 0xb1, 0xce, 0xda, 0xfd, 0x99, 0x0c, 0xc3, 0xd3, 0xf8, 0x79, 0xe8, 0x45, 0x3c, 0x16,
+	0xe0, 0xf2, 0x2c, 0x33, 0x11, 0xdd, 0x8b, 0xb9, 0x33, 0x8b, 0x33, 0x74, 0xc2, 0xc0, 0x78, 0x04,
+	0xe3, 0x05, 0xbe, 0x40, 0x57, 0x60, 0x99, 0xd9, 0xfe, 0x4e, 0x01, 0xba, 0x3c, 0x62, 0x14, 0xf2,
+	0xc8, 0x49, 0xbc, 0xc0, 0xa7, 0x9f, 0x41, 0x79, 0x1a, 0x9c, 0x9e, 0x3a, 0xbe, 0x2b, 0x60, 0x36,
+	0xf7, 0x1f, 0xde, 0x04, 0x89, 0xf1, 0x97, 0x0b, 0x1e, 0x27, 0x9d, 0x9e, 0x0c, 0x65, 0x59, 0x4e,
+	0x76, 0xc3, 0xdc, 0xd5, 0x0d, 0x1f, 0x41, 0xe1, 0x28, 0x70, 0x2f, 0xd4, 0xfc, 0x5b, 0x2e, 0x28,
+	0xa2, 0xda, 0x7f, 0x95, 0x81, 0xbc, 0x79, 0x0a, 0x6d, 0x42, 0xce, 0x73, 0xd3, 0xaf, 0x96, 0xf3,
+	0xdc, 0x55, 0x8c, 0xd8, 0xb6, 0x77, 0xc5, 0xf8, 0x4e, 0x88, 0xe8, 0x1e, 0x14, 0x23, 0xa4, 0x8f,
+	0xe8, 0x6b, 0x6d, 0xff, 0xff, 0x37, 0x1e, 0x85, 0x01, 0x4c, 0xc6, 0xd1, 0x8f, 0xa1, 0x78, 0xe4,
+	0x24, 0xd3, 0x63, 0xb5, 0xb8, 0x93, 0xdf, 0xad, 0xed, 0xbf, 0x77, 0x53, 0xc2, 0xb2, 0xdf, 0x4c,
+	0xc6, 0x22, 0x85, 0xe3, 0x0b, 0x7f, 0xaa, 0x96, 0x04, 0x85, 0xc4, 0x3a, 0xeb, 0x65, 0x79, 0x47,
+	0xc9, 0x7a, 0x49, 0x20, 0x1f, 0xf3, 0x97, 0x6a, 0x45, 0x7c, 0x49, 0x5c, 0xa2, 0x2c, 0x62, 0xdf,
+	0x09, 0xe3, 0xe3, 0x20, 0x51, 0xab, 0xc2, 0xbd, 0xb4, 0xe9, 0x27, 0x50, 0xe1, 0xe7, 0x21, 0x9f,
+	0x26, 0xdc, 0x55, 0xe1, 0x2d, 0x77, 0x5d, 0x46, 0x22, 0x8f, 0x92, 0xe0, 0x84, 0xfb, 0x6a, 0x6d,
+	0x47, 0xd9, 0xad, 0x32, 0x69, 0xe0, 0x39, 0x28, 0xab, 0x23, 0x27, 0xe6, 0x6a, 0x5d, 0x6c, 0x2c,
+	0x6d, 0xc4, 0x1e, 0x3a, 0xc9, 0xb1, 0xda, 0x10, 0x7e, 0xb1, 0xa6, 0x77, 0xa0, 0x34, 0x0f, 0x66,
+	0xb6, 0xe7, 0xaa, 0x4d, 0x59, 0x66, 0x1e, 0xcc, 0x06, 0x2e, 0xbd, 0x07, 0x65, 0x74, 0xe3, 0x25,
+	0x36, 0x04, 0x5a, 0x8c, 0x32, 0xf9, 0x4b, 0xdc, 0xf0, 0x03, 0x97, 0x63, 0x02, 0x11, 0x09, 0x25,
+	0x34, 0x07, 0x2e, 0xfd, 0x00, 0xea, 0x62, 0xc3, 0x71, 0xdd, 0x88, 0xc7, 0xb1, 0xba, 0x29, 0x76,
+	0x6b, 0xe8, 0xd3, 0xa4, 0x0b, 0xbb, 0x92, 0x24, 0x73, 0x95, 0xca, 0xae, 0x24, 0xc9, 0x9c, 0x7e,
+	0x04, 0x24, 0xbb, 0x8f, 0x9d, 0xd1, 0x7f, 0x4b, 0x6c, 0x6f, 0x64, 0xfe, 0xe7, 0xd2, 0x7d, 0x25,
+	0x9b, 0xed, 0x15, 0xd9, 0xb4, 0xff, 0xcc, 0x41, 0x39, 0xe5, 0x0d, 0xad, 0x41, 0x79, 0x62, 0x1c,
+	0x18, 0xa3, 0xaf, 0x0d, 0xf2, 0x3f, 0x5a, 0x86, 0xfc, 0x97, 0xba, 0x45, 0x14, 0x5c, 0x8c, 0x27,
+	0x16, 0xc9, 0x51, 0x80, 0x52, 0x5f, 0x1f, 0xea, 0x96, 0x4e, 0xf2, 0xb4, 0x02, 0x05, 0xb3, 0xa7,
+	0x19, 0xa4, 0x40, 0xab, 0x50, 0xec, 0x6a, 0x56, 0xef, 0x29, 0x29, 0xd2, 0x4d, 0x68, 0x98, 0x86,
+	0x36, 0x36, 0x9f, 0x8e, 0x2c, 0x7b, 0x34, 0xd6, 0x0d, 0x52, 0xa2, 0xdb, 0x40, 0x96, 0x2e, 0xa6,
+	0x0f, 0x75, 0xcd, 0xd4, 0x49, 0x19, 0x4b, 0xf6, 0x34, 0x93, 0x54, 0x30, 0x63, 0x3c, 0xb1, 0xec,
+	0xc1, 0x13, 0x5b, 0xeb, 0x9a, 0xba, 0x61, 0x91, 0x2a, 0xdd, 0x82, 0x0d, 0x79, 0x0a, 0x7a, 0xf5,
+	0x67, 0x13, 0x6d, 0x48, 0x80, 0x36, 0xa0, 0xda, 0xef, 0xda, 0x3d, 0xa6, 0x6b, 0x96, 0x4e, 0x6a,
+	0x08, 0xb4, 0xdf, 0xb5, 0xfb, 0x6c, 0x34, 0x26, 0x75, 0x84, 0xd5, 0xd5, 0x7a, 0x07, 0x93, 0x31,
+	0x69, 0xe0, 0x06, 0xd3, 0x4d, 0x6b, 0xc4, 0x74, 0xd2, 0xc4, 0xe2, 0x4c, 0x1f, 0x0f, 0xed, 0x0c,
+	0x00, 0xd9, 0xc0, 0x3a, 0xc2, 0x65, 0x69, 0x83, 0x21, 0x21, 0x74, 0x03, 0x6a, 0x32, 0xc2, 0xd2,
+	0xac, 0x89, 0x49, 0x36, 0x29, 0x81, 0x7a, 0x6f, 0x38, 0x31, 0x2d, 0x9d, 0xd9, 0x5f, 0x8d, 0x06,
+	0x06, 0xa1, 0x58, 0x24, 0xf3, 0x0c, 0x75, 0xed, 0xb9, 0x4e, 0xb6, 0x28, 0x85, 0x66, 0xe6, 0x4a,
+	0x13, 0xb7, 0xf1, 0x46, 0x96, 0x35, 0x24, 0x77, 0x10, 0xc1, 0x58, 0x67, 0xe6, 0xc0, 0xb4, 0xc8,
+	0x5d, 0x09, 0x67, 0x3c, 0xd4, 0x7a, 0x3a, 0xb9, 0xd7, 0xfe, 0xa3, 0x08, 0x9b, 0x2b, 0x92, 0x8d,
+	0xc3, 0xc0, 0x8f, 0xf9, 0x35, 0xc5, 0x7f, 0x0a, 0xa5, 0x38, 0x71, 0x92, 0x45, 0x2c, 0x5e, 0x96,
+	0x5b, 0x05, 0x2f, 0xb3, 0x3b, 0xa6, 0x08, 0x65, 0x69, 0xca, 0x3b, 0xea, 0xbd, 0x03, 0xc5, 0xd0,
+	0xf1, 0x22, 0x7c, 0x47, 0xf3, 0xb7, 0x85, 0xe3, 0x63, 0xce, 0x64, 0x18, 0xb2, 0xff, 0x34, 0x88,
+	0xb2, 0xc7, 0x5f, 0xac, 0x33, 0x9d, 0x96, 0x6e, 0xd6, 0x69, 0xf9, 0x0d, 0x9d, 0x5e, 0x69, 0xa5,
+	0x72, 0x8b, 0x56, 0xaa, 0x6b, 0x5a, 0x79, 0x0c, 0x65, 0xee, 0x27, 0x91, 0xc7, 0x63, 0x15, 0x04,
+	0xc6, 0xad, 0x15, 0x8c, 0xc3, 0x60, 0xa6, 0xfb, 0x49, 0x74, 0xc1, 0xb2, 0x18, 0xfa, 0x39, 0xd4,
+	0x22, 0x1e, 0xce, 0xbd, 0xa9, 0x78, 0x70, 0x84, 0xac, 0x6b, 0xfb, 0x0f, 0x56, 0x52, 0xd8, 0xd5,
+	0x6e, 0xda, 0xb9, 0xd5, 0x04, 0x1c, 0x87, 0x73, 0xee, 0xb8, 0x3c, 0x4a, 0x85, 0x9f, 0x5a, 0x74,
+	0x1f, 0xca, 0xd3, 0xf9, 0x22, 0x4e, 0x78, 0x24, 0x94, 0xbf, 0xde, 0xaa, 0x9e, 0xdc, 0x49, 0xeb,
+	0x65, 0x81, 0x99, 0x54, 0x9b, 0x62, 0x74, 0xe1, 0xf2, 0x4a, 0x7f, 0x1b, 0xb7, 0x8c, 0x2d, 0xb2,
+	0x3e, 0xb6, 0x7e, 0x52, 0xa0, 0x24, 0xab, 0xae, 0x0b, 0xb3, 0x04, 0xb9, 0xd1, 0x01, 0x51, 0x50,
+	0x82, 0x4f, 0x90, 0xc6, 0x39, 0x64, 0xb5, 0x31, 0xb2, 0xec, 0x27, 0xa3, 0x89, 0xd1, 0x27, 0x79,
+	0x64, 0x75, 0x57, 0xeb, 0xdb, 0x4c, 0x7f, 0x36, 0xd1, 0x4d, 0x8b, 0x14, 0xe8, 0x1d, 0xd8, 0xec,
+	0x3d, 0xd5, 0x7b, 0x07, 0xe6, 0xe4, 0xd0, 0x3e, 0x1c, 0x98, 0x87, 0xa9, 0x5c, 0xeb, 0x50, 0x19,
+	0x18, 0x96, 0xce, 0x0c, 0x6d, 0x28, 0x95, 0xda, 0x1b, 0x19, 0xfd, 0x81, 0x35, 0x18, 0x19, 0x36,
+	0x16, 0xd6, 0xfb, 0xa4, 0x2c, 0x35, 0x6f, 0x0c, 0xf4, 0x3e, 0xa9, 0x48, 0xf1, 0x68, 0x7d, 0x7b,
+	0x64, 0x0c, 0xbf, 0x21, 0x55, 0xda, 0x04, 0xc0, 0x53, 0x87, 0xba, 0xd6, 0xd7, 0x19, 0x81, 0xf6,
+	0x21, 0x54, 0xb2, 0x4f, 0x92, 0xd1, 0x02, 0xe7, 0x6a, 0x4a, 0x8b, 0x3d, 0xc8, 0x07, 0x21, 0x92,
+	0xfa, 0x3f, 0x4c, 0x0a, 0x8c, 0x6c, 0xff, 0xae, 0xc0, 0xe6, 0xb5, 0xef, 0x85, 0x1c, 0x8c, 0x82,
+	0x39, 0x17, 0x82, 0xa9, 0x32, 0xb1, 0xc6, 0x16, 0x86, 0x91, 0x77, 0xea, 0x44, 0x17, 0x62, 0x48,
+	0x56, 0x59, 0x66, 0xd2, 0x07, 0x50, 0x9d, 0x06, 0xbe, 0x2f, 0x07, 0x43, 0x5e, 0xd0, 0xf6, 0xca,
+	0xb1, 0xc2, 0xc6, 0xc2, 0x2d, 0x6c, 0x2c, 0xae, 0xb1, 0xf1, 0x7d, 0xa8, 0xa5, 0x85, 0xed, 0x2b,
+	0xce, 0x43, 0xea, 0x4a, 0x03, 0xe6, 0x0e, 0x66, 0x4e, 0x03, 0xdf, 0x8d, 0x05, 0xfb, 0x15, 0x06,
+	0x73, 0x67, 0x66, 0x4a, 0x4f, 0xfb, 0x47, 0x05, 0x1a, 0x6b, 0x7c, 0x59, 0x9d, 0x06, 0xca, 0xda,
+	0x34, 0x90, 0xbf, 0x70, 0x09, 0x4f, 0xaf, 0x24, 0x8d, 0x15, 0x86, 0xe6, 0xd7, 0x18, 0xfa, 0x08,
+	0x8a, 0x98, 0x97, 0x49, 0xf9, 0xee, 0x75, 0x7e, 0x1a, 0x81, 0xcb, 0x99, 0x0c, 0xa2, 0x0f, 0xa1,
+	0xe1, 0x84, 0xe1, 0xdc, 0xe3, 0xae, 0xed, 0xf9, 0x2e, 0x3f, 0x4f, 0xef, 0x59, 0x4f, 0x9d, 0x03,
+	0xf4, 0xb5, 0x0f, 0xa1, 0xb6, 0x92, 0xba, 0x7c, 0xa7, 0x72, 0xbb, 0x55, 0xf1, 0x4e, 0xa9, 0x50,
+	0xce, 0x06, 0x55, 0x4e, 0x38, 0x33, 0x13, 0x91, 0x9f, 0x05, 0x49, 0x0a, 0xb1, 0xc2, 0xa4, 0xd1,
+	0x66, 0x50, 0xef, 0x3a, 0xd3, 0x93, 0x45, 0xc8, 0xf8, 0x34, 0x88, 0xc4, 0xaf, 0x09, 0xbe, 0x2a,
+	0xaa, 0x72, 0x4d, 0x50, 0xeb, 0x6f, 0x8f, 0x88, 0xc2, 0x9a, 0xd3, 0x60, 0xe1, 0x27, 0xa2, 0x1b,
+	0x05, 0x26, 0x8d, 0xee, 0x87, 0xaf, 0x5e, 0xb7, 0x94, 0xbf, 0x5f, 0xb7, 0x94, 0x7f, 0x5e, 0xb7,
+	0x94, 0x1f, 0x2e, 0x5b, 0xca, 0xcf, 0x97, 0x2d, 0xe5, 0x97, 0xcb, 0x96, 0xf2, 0xeb, 0x65, 0x4b,
+	0xf9, 0xed, 0xb2, 0xa5, 0xbc, 0xba, 0x6c, 0x29, 0xff, 0x0e, 0x00, 0x84, 0xc0, 0x4f, 0x8c, 0xac,
+	0x0b, 0x00, 0x00,
+}
+
 func (this *TransportBody) VerboseEqual(that interface{}) error {
 	if that == nil {
 		if this == nil {
@@ -897,7 +1360,12 @@ func (this *TransportBody) VerboseEqual(that interface{}) error {
 
 	that1, ok := that.(*TransportBody)
 	if !ok {
-		return fmt.Errorf("that is not of type *TransportBody")
+		that2, ok := that.(TransportBody)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *TransportBody")
+		}
 	}
 	if that1 == nil {
 		if this == nil {
@@ -905,7 +1373,7 @@ func (this *TransportBody) VerboseEqual(that interface{}) error {
 		}
 		return fmt.Errorf("that is type *TransportBody but is nil && this != nil")
 	} else if this == nil {
-		return fmt.Errorf("that is type *TransportBodybut is not nil && this == nil")
+		return fmt.Errorf("that is type *TransportBody but is not nil && this == nil")
 	}
 	if this.Checksum != nil && that1.Checksum != nil {
 		if *this.Checksum != *that1.Checksum {
@@ -926,21 +1394,20 @@ func (this *TransportBody) VerboseEqual(that interface{}) error {
 }
 func (this *TransportBody) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
 	that1, ok := that.(*TransportBody)
 	if !ok {
-		return false
+		that2, ok := that.(TransportBody)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
@@ -961,7 +1428,7 @@ func (this *TransportBody) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *TransportRequest) VerboseEqual(that interface{}) error {
+func (this *TransportRange) VerboseEqual(that interface{}) error {
 	if that == nil {
 		if this == nil {
 			return nil
@@ -969,71 +1436,99 @@ func (this *TransportRequest) VerboseEqual(that interface{}) error {
 		return fmt.Errorf("that == nil && this != nil")
 	}
 
-	that1, ok := that.(*TransportRequest)
+	that1, ok := that.(*TransportRange)
 	if !ok {
-		return fmt.Errorf("that is not of type *TransportRequest")
+		that2, ok := that.(TransportRange)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *TransportRange")
+		}
 	}
 	if that1 == nil {
 		if this == nil {
 			return nil
 		}
-		return fmt.Errorf("that is type *TransportRequest but is nil && this != nil")
+		return fmt.Errorf("that is type *TransportRange but is nil && this != nil")
 	} else if this == nil {
-		return fmt.Errorf("that is type *TransportRequestbut is not nil && this == nil")
+		return fmt.Errorf("that is type *TransportRange but is not nil && this == nil")
 	}
-	if !bytes.Equal(this.Id, that1.Id) {
-		return fmt.Errorf("Id this(%v) Not Equal that(%v)", this.Id, that1.Id)
+	if !bytes.Equal(this.Start, that1.Start) {
+		return fmt.Errorf("Start this(%v) Not Equal that(%v)", this.Start, that1.Start)
 	}
-	if this.Command != nil && that1.Command != nil {
-		if *this.Command != *that1.Command {
-			return fmt.Errorf("Command this(%v) Not Equal that(%v)", *this.Command, *that1.Command)
+	if !bytes.Equal(this.End, that1.End) {
+		return fmt.Errorf("End this(%v) Not Equal that(%v)", this.End, that1.End)
+	}
+	if !bytes.Equal(this.Prefix, that1.Prefix) {
+		return fmt.Errorf("Prefix this(%v) Not Equal that(%v)", this.Prefix, that1.Prefix)
+	}
+	if this.Limit != nil && that1.Limit != nil {
+		if *this.Limit != *that1.Limit {
+			return fmt.Errorf("Limit this(%v) Not Equal that(%v)", *this.Limit, *that1.Limit)
 		}
-	} else if this.Command != nil {
-		return fmt.Errorf("this.Command == nil && that.Command != nil")
-	} else if that1.Command != nil {
-		return fmt.Errorf("Command this(%v) Not Equal that(%v)", this.Command, that1.Command)
+	} else if this.Limit != nil {
+		return fmt.Errorf("this.Limit == nil && that.Limit != nil")
+	} else if that1.Limit != nil {
+		return fmt.Errorf("Limit this(%v) Not Equal that(%v)", this.Limit, that1.Limit)
 	}
-	if !this.Body.Equal(that1.Body) {
-		return fmt.Errorf("Body this(%v) Not Equal that(%v)", this.Body, that1.Body)
+	if this.Reverse != nil && that1.Reverse != nil {
+		if *this.Reverse != *that1.Reverse {
+			return fmt.Errorf("Reverse this(%v) Not Equal that(%v)", *this.Reverse, *that1.Reverse)
+		}
+	} else if this.Reverse != nil {
+		return fmt.Errorf("this.Reverse == nil && that.Reverse != nil")
+	} else if that1.Reverse != nil {
+		return fmt.Errorf("Reverse this(%v) Not Equal that(%v)", this.Reverse, that1.Reverse)
 	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
 	}
 	return nil
 }
-func (this *TransportRequest) Equal(that interface{}) bool {
+func (this *TransportRange) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
-	that1, ok := that.(*TransportRequest)
+	that1, ok := that.(*TransportRange)
 	if !ok {
-		return false
+		that2, ok := that.(TransportRange)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.Id, that1.Id) {
+	if !bytes.Equal(this.Start, that1.Start) {
 		return false
 	}
-	if this.Command != nil && that1.Command != nil {
-		if *this.Command != *that1.Command {
+	if !bytes.Equal(this.End, that1.End) {
+		return false
+	}
+	if !bytes.Equal(this.Prefix, that1.Prefix) {
+		return false
+	}
+	if this.Limit != nil && that1.Limit != nil {
+		if *this.Limit != *that1.Limit {
 			return false
 		}
-	} else if this.Command != nil {
+	} else if this.Limit != nil {
 		return false
-	} else if that1.Command != nil {
+	} else if that1.Limit != nil {
 		return false
 	}
-	if !this.Body.Equal(that1.Body) {
+	if this.Reverse != nil && that1.Reverse != nil {
+		if *this.Reverse != *that1.Reverse {
+			return false
+		}
+	} else if this.Reverse != nil {
+		return false
+	} else if that1.Reverse != nil {
 		return false
 	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
@@ -1041,7 +1536,7 @@ func (this *TransportRequest) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *TransportResponse) VerboseEqual(that interface{}) error {
+func (this *TransportPair) VerboseEqual(that interface{}) error {
 	if that == nil {
 		if this == nil {
 			return nil
@@ -1049,75 +1544,6148 @@ func (this *TransportResponse) VerboseEqual(that interface{}) error {
 		return fmt.Errorf("that == nil && this != nil")
 	}
 
-	that1, ok := that.(*TransportResponse)
+	that1, ok := that.(*TransportPair)
 	if !ok {
-		return fmt.Errorf("that is not of type *TransportResponse")
+		that2, ok := that.(TransportPair)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *TransportPair")
+		}
 	}
 	if that1 == nil {
 		if this == nil {
 			return nil
 		}
-		return fmt.Errorf("that is type *TransportResponse but is nil && this != nil")
+		return fmt.Errorf("that is type *TransportPair but is nil && this != nil")
 	} else if this == nil {
-		return fmt.Errorf("that is type *TransportResponsebut is not nil && this == nil")
+		return fmt.Errorf("that is type *TransportPair but is not nil && this == nil")
 	}
-	if !bytes.Equal(this.Id, that1.Id) {
-		return fmt.Errorf("Id this(%v) Not Equal that(%v)", this.Id, that1.Id)
+	if !bytes.Equal(this.Key, that1.Key) {
+		return fmt.Errorf("Key this(%v) Not Equal that(%v)", this.Key, that1.Key)
 	}
-	if this.Status != nil && that1.Status != nil {
-		if *this.Status != *that1.Status {
-			return fmt.Errorf("Status this(%v) Not Equal that(%v)", *this.Status, *that1.Status)
+	if !this.Value.Equal(that1.Value) {
+		return fmt.Errorf("Value this(%v) Not Equal that(%v)", this.Value, that1.Value)
+	}
+	if this.Expires != nil && that1.Expires != nil {
+		if *this.Expires != *that1.Expires {
+			return fmt.Errorf("Expires this(%v) Not Equal that(%v)", *this.Expires, *that1.Expires)
 		}
-	} else if this.Status != nil {
-		return fmt.Errorf("this.Status == nil && that.Status != nil")
-	} else if that1.Status != nil {
-		return fmt.Errorf("Status this(%v) Not Equal that(%v)", this.Status, that1.Status)
+	} else if this.Expires != nil {
+		return fmt.Errorf("this.Expires == nil && that.Expires != nil")
+	} else if that1.Expires != nil {
+		return fmt.Errorf("Expires this(%v) Not Equal that(%v)", this.Expires, that1.Expires)
 	}
-	if !this.Body.Equal(that1.Body) {
-		return fmt.Errorf("Body this(%v) Not Equal that(%v)", this.Body, that1.Body)
+	if this.Flags != nil && that1.Flags != nil {
+		if *this.Flags != *that1.Flags {
+			return fmt.Errorf("Flags this(%v) Not Equal that(%v)", *this.Flags, *that1.Flags)
+		}
+	} else if this.Flags != nil {
+		return fmt.Errorf("this.Flags == nil && that.Flags != nil")
+	} else if that1.Flags != nil {
+		return fmt.Errorf("Flags this(%v) Not Equal that(%v)", this.Flags, that1.Flags)
+	}
+	if this.Version != nil && that1.Version != nil {
+		if *this.Version != *that1.Version {
+			return fmt.Errorf("Version this(%v) Not Equal that(%v)", *this.Version, *that1.Version)
+		}
+	} else if this.Version != nil {
+		return fmt.Errorf("this.Version == nil && that.Version != nil")
+	} else if that1.Version != nil {
+		return fmt.Errorf("Version this(%v) Not Equal that(%v)", this.Version, that1.Version)
 	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
 	}
 	return nil
 }
-func (this *TransportResponse) Equal(that interface{}) bool {
+func (this *TransportPair) Equal(that interface{}) bool {
 	if that == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	}
 
-	that1, ok := that.(*TransportResponse)
+	that1, ok := that.(*TransportPair)
 	if !ok {
-		return false
+		that2, ok := that.(TransportPair)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
 	if that1 == nil {
-		if this == nil {
-			return true
-		}
-		return false
+		return this == nil
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.Id, that1.Id) {
+	if !bytes.Equal(this.Key, that1.Key) {
 		return false
 	}
-	if this.Status != nil && that1.Status != nil {
-		if *this.Status != *that1.Status {
+	if !this.Value.Equal(that1.Value) {
+		return false
+	}
+	if this.Expires != nil && that1.Expires != nil {
+		if *this.Expires != *that1.Expires {
 			return false
 		}
-	} else if this.Status != nil {
+	} else if this.Expires != nil {
 		return false
-	} else if that1.Status != nil {
+	} else if that1.Expires != nil {
+		return false
+	}
+	if this.Flags != nil && that1.Flags != nil {
+		if *this.Flags != *that1.Flags {
+			return false
+		}
+	} else if this.Flags != nil {
+		return false
+	} else if that1.Flags != nil {
+		return false
+	}
+	if this.Version != nil && that1.Version != nil {
+		if *this.Version != *that1.Version {
+			return false
+		}
+	} else if this.Version != nil {
+		return false
+	} else if that1.Version != nil {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *TransportOperation) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
+	}
+
+	that1, ok := that.(*TransportOperation)
+	if !ok {
+		that2, ok := that.(TransportOperation)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *TransportOperation")
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *TransportOperation but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *TransportOperation but is not nil && this == nil")
+	}
+	if this.Command != nil && that1.Command != nil {
+		if *this.Command != *that1.Command {
+			return fmt.Errorf("Command this(%v) Not Equal that(%v)", *this.Command, *that1.Command)
+		}
+	} else if this.Command != nil {
+		return fmt.Errorf("this.Command == nil && that.Command != nil")
+	} else if that1.Command != nil {
+		return fmt.Errorf("Command this(%v) Not Equal that(%v)", this.Command, that1.Command)
+	}
+	if !bytes.Equal(this.Key, that1.Key) {
+		return fmt.Errorf("Key this(%v) Not Equal that(%v)", this.Key, that1.Key)
+	}
+	if !this.Body.Equal(that1.Body) {
+		return fmt.Errorf("Body this(%v) Not Equal that(%v)", this.Body, that1.Body)
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
+	}
+	return nil
+}
+func (this *TransportOperation) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TransportOperation)
+	if !ok {
+		that2, ok := that.(TransportOperation)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Command != nil && that1.Command != nil {
+		if *this.Command != *that1.Command {
+			return false
+		}
+	} else if this.Command != nil {
+		return false
+	} else if that1.Command != nil {
+		return false
+	}
+	if !bytes.Equal(this.Key, that1.Key) {
+		return false
+	}
+	if !this.Body.Equal(that1.Body) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *TransportRequest) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
+	}
+
+	that1, ok := that.(*TransportRequest)
+	if !ok {
+		that2, ok := that.(TransportRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *TransportRequest")
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *TransportRequest but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *TransportRequest but is not nil && this == nil")
+	}
+	if !bytes.Equal(this.Id, that1.Id) {
+		return fmt.Errorf("Id this(%v) Not Equal that(%v)", this.Id, that1.Id)
+	}
+	if this.Command != nil && that1.Command != nil {
+		if *this.Command != *that1.Command {
+			return fmt.Errorf("Command this(%v) Not Equal that(%v)", *this.Command, *that1.Command)
+		}
+	} else if this.Command != nil {
+		return fmt.Errorf("this.Command == nil && that.Command != nil")
+	} else if that1.Command != nil {
+		return fmt.Errorf("Command this(%v) Not Equal that(%v)", this.Command, that1.Command)
+	}
+	if !this.Body.Equal(that1.Body) {
+		return fmt.Errorf("Body this(%v) Not Equal that(%v)", this.Body, that1.Body)
+	}
+	if !this.Range.Equal(that1.Range) {
+		return fmt.Errorf("Range this(%v) Not Equal that(%v)", this.Range, that1.Range)
+	}
+	if len(this.Batch) != len(that1.Batch) {
+		return fmt.Errorf("Batch this(%v) Not Equal that(%v)", len(this.Batch), len(that1.Batch))
+	}
+	for i := range this.Batch {
+		if !this.Batch[i].Equal(that1.Batch[i]) {
+			return fmt.Errorf("Batch this[%v](%v) Not Equal that[%v](%v)", i, this.Batch[i], i, that1.Batch[i])
+		}
+	}
+	if this.Sync != nil && that1.Sync != nil {
+		if *this.Sync != *that1.Sync {
+			return fmt.Errorf("Sync this(%v) Not Equal that(%v)", *this.Sync, *that1.Sync)
+		}
+	} else if this.Sync != nil {
+		return fmt.Errorf("this.Sync == nil && that.Sync != nil")
+	} else if that1.Sync != nil {
+		return fmt.Errorf("Sync this(%v) Not Equal that(%v)", this.Sync, that1.Sync)
+	}
+	if !bytes.Equal(this.Key, that1.Key) {
+		return fmt.Errorf("Key this(%v) Not Equal that(%v)", this.Key, that1.Key)
+	}
+	if this.Seq != nil && that1.Seq != nil {
+		if *this.Seq != *that1.Seq {
+			return fmt.Errorf("Seq this(%v) Not Equal that(%v)", *this.Seq, *that1.Seq)
+		}
+	} else if this.Seq != nil {
+		return fmt.Errorf("this.Seq == nil && that.Seq != nil")
+	} else if that1.Seq != nil {
+		return fmt.Errorf("Seq this(%v) Not Equal that(%v)", this.Seq, that1.Seq)
+	}
+	if this.Snapshot != nil && that1.Snapshot != nil {
+		if *this.Snapshot != *that1.Snapshot {
+			return fmt.Errorf("Snapshot this(%v) Not Equal that(%v)", *this.Snapshot, *that1.Snapshot)
+		}
+	} else if this.Snapshot != nil {
+		return fmt.Errorf("this.Snapshot == nil && that.Snapshot != nil")
+	} else if that1.Snapshot != nil {
+		return fmt.Errorf("Snapshot this(%v) Not Equal that(%v)", this.Snapshot, that1.Snapshot)
+	}
+	if !this.Expected.Equal(that1.Expected) {
+		return fmt.Errorf("Expected this(%v) Not Equal that(%v)", this.Expected, that1.Expected)
+	}
+	if this.Token != nil && that1.Token != nil {
+		if *this.Token != *that1.Token {
+			return fmt.Errorf("Token this(%v) Not Equal that(%v)", *this.Token, *that1.Token)
+		}
+	} else if this.Token != nil {
+		return fmt.Errorf("this.Token == nil && that.Token != nil")
+	} else if that1.Token != nil {
+		return fmt.Errorf("Token this(%v) Not Equal that(%v)", this.Token, that1.Token)
+	}
+	if this.Database != nil && that1.Database != nil {
+		if *this.Database != *that1.Database {
+			return fmt.Errorf("Database this(%v) Not Equal that(%v)", *this.Database, *that1.Database)
+		}
+	} else if this.Database != nil {
+		return fmt.Errorf("this.Database == nil && that.Database != nil")
+	} else if that1.Database != nil {
+		return fmt.Errorf("Database this(%v) Not Equal that(%v)", this.Database, that1.Database)
+	}
+	if this.Path != nil && that1.Path != nil {
+		if *this.Path != *that1.Path {
+			return fmt.Errorf("Path this(%v) Not Equal that(%v)", *this.Path, *that1.Path)
+		}
+	} else if this.Path != nil {
+		return fmt.Errorf("this.Path == nil && that.Path != nil")
+	} else if that1.Path != nil {
+		return fmt.Errorf("Path this(%v) Not Equal that(%v)", this.Path, that1.Path)
+	}
+	if this.LogId != nil && that1.LogId != nil {
+		if *this.LogId != *that1.LogId {
+			return fmt.Errorf("LogId this(%v) Not Equal that(%v)", *this.LogId, *that1.LogId)
+		}
+	} else if this.LogId != nil {
+		return fmt.Errorf("this.LogId == nil && that.LogId != nil")
+	} else if that1.LogId != nil {
+		return fmt.Errorf("LogId this(%v) Not Equal that(%v)", this.LogId, that1.LogId)
+	}
+	if this.LogSeq != nil && that1.LogSeq != nil {
+		if *this.LogSeq != *that1.LogSeq {
+			return fmt.Errorf("LogSeq this(%v) Not Equal that(%v)", *this.LogSeq, *that1.LogSeq)
+		}
+	} else if this.LogSeq != nil {
+		return fmt.Errorf("this.LogSeq == nil && that.LogSeq != nil")
+	} else if that1.LogSeq != nil {
+		return fmt.Errorf("LogSeq this(%v) Not Equal that(%v)", this.LogSeq, that1.LogSeq)
+	}
+	if this.NodeId != nil && that1.NodeId != nil {
+		if *this.NodeId != *that1.NodeId {
+			return fmt.Errorf("NodeId this(%v) Not Equal that(%v)", *this.NodeId, *that1.NodeId)
+		}
+	} else if this.NodeId != nil {
+		return fmt.Errorf("this.NodeId == nil && that.NodeId != nil")
+	} else if that1.NodeId != nil {
+		return fmt.Errorf("NodeId this(%v) Not Equal that(%v)", this.NodeId, that1.NodeId)
+	}
+	if this.NodeAddress != nil && that1.NodeAddress != nil {
+		if *this.NodeAddress != *that1.NodeAddress {
+			return fmt.Errorf("NodeAddress this(%v) Not Equal that(%v)", *this.NodeAddress, *that1.NodeAddress)
+		}
+	} else if this.NodeAddress != nil {
+		return fmt.Errorf("this.NodeAddress == nil && that.NodeAddress != nil")
+	} else if that1.NodeAddress != nil {
+		return fmt.Errorf("NodeAddress this(%v) Not Equal that(%v)", this.NodeAddress, that1.NodeAddress)
+	}
+	if this.Ttl != nil && that1.Ttl != nil {
+		if *this.Ttl != *that1.Ttl {
+			return fmt.Errorf("Ttl this(%v) Not Equal that(%v)", *this.Ttl, *that1.Ttl)
+		}
+	} else if this.Ttl != nil {
+		return fmt.Errorf("this.Ttl == nil && that.Ttl != nil")
+	} else if that1.Ttl != nil {
+		return fmt.Errorf("Ttl this(%v) Not Equal that(%v)", this.Ttl, that1.Ttl)
+	}
+	if this.ExpectedVersion != nil && that1.ExpectedVersion != nil {
+		if *this.ExpectedVersion != *that1.ExpectedVersion {
+			return fmt.Errorf("ExpectedVersion this(%v) Not Equal that(%v)", *this.ExpectedVersion, *that1.ExpectedVersion)
+		}
+	} else if this.ExpectedVersion != nil {
+		return fmt.Errorf("this.ExpectedVersion == nil && that.ExpectedVersion != nil")
+	} else if that1.ExpectedVersion != nil {
+		return fmt.Errorf("ExpectedVersion this(%v) Not Equal that(%v)", this.ExpectedVersion, that1.ExpectedVersion)
+	}
+	if this.Flags != nil && that1.Flags != nil {
+		if *this.Flags != *that1.Flags {
+			return fmt.Errorf("Flags this(%v) Not Equal that(%v)", *this.Flags, *that1.Flags)
+		}
+	} else if this.Flags != nil {
+		return fmt.Errorf("this.Flags == nil && that.Flags != nil")
+	} else if that1.Flags != nil {
+		return fmt.Errorf("Flags this(%v) Not Equal that(%v)", this.Flags, that1.Flags)
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
+	}
+	return nil
+}
+func (this *TransportRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TransportRequest)
+	if !ok {
+		that2, ok := that.(TransportRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Id, that1.Id) {
+		return false
+	}
+	if this.Command != nil && that1.Command != nil {
+		if *this.Command != *that1.Command {
+			return false
+		}
+	} else if this.Command != nil {
+		return false
+	} else if that1.Command != nil {
 		return false
 	}
 	if !this.Body.Equal(that1.Body) {
 		return false
 	}
+	if !this.Range.Equal(that1.Range) {
+		return false
+	}
+	if len(this.Batch) != len(that1.Batch) {
+		return false
+	}
+	for i := range this.Batch {
+		if !this.Batch[i].Equal(that1.Batch[i]) {
+			return false
+		}
+	}
+	if this.Sync != nil && that1.Sync != nil {
+		if *this.Sync != *that1.Sync {
+			return false
+		}
+	} else if this.Sync != nil {
+		return false
+	} else if that1.Sync != nil {
+		return false
+	}
+	if !bytes.Equal(this.Key, that1.Key) {
+		return false
+	}
+	if this.Seq != nil && that1.Seq != nil {
+		if *this.Seq != *that1.Seq {
+			return false
+		}
+	} else if this.Seq != nil {
+		return false
+	} else if that1.Seq != nil {
+		return false
+	}
+	if this.Snapshot != nil && that1.Snapshot != nil {
+		if *this.Snapshot != *that1.Snapshot {
+			return false
+		}
+	} else if this.Snapshot != nil {
+		return false
+	} else if that1.Snapshot != nil {
+		return false
+	}
+	if !this.Expected.Equal(that1.Expected) {
+		return false
+	}
+	if this.Token != nil && that1.Token != nil {
+		if *this.Token != *that1.Token {
+			return false
+		}
+	} else if this.Token != nil {
+		return false
+	} else if that1.Token != nil {
+		return false
+	}
+	if this.Database != nil && that1.Database != nil {
+		if *this.Database != *that1.Database {
+			return false
+		}
+	} else if this.Database != nil {
+		return false
+	} else if that1.Database != nil {
+		return false
+	}
+	if this.Path != nil && that1.Path != nil {
+		if *this.Path != *that1.Path {
+			return false
+		}
+	} else if this.Path != nil {
+		return false
+	} else if that1.Path != nil {
+		return false
+	}
+	if this.LogId != nil && that1.LogId != nil {
+		if *this.LogId != *that1.LogId {
+			return false
+		}
+	} else if this.LogId != nil {
+		return false
+	} else if that1.LogId != nil {
+		return false
+	}
+	if this.LogSeq != nil && that1.LogSeq != nil {
+		if *this.LogSeq != *that1.LogSeq {
+			return false
+		}
+	} else if this.LogSeq != nil {
+		return false
+	} else if that1.LogSeq != nil {
+		return false
+	}
+	if this.NodeId != nil && that1.NodeId != nil {
+		if *this.NodeId != *that1.NodeId {
+			return false
+		}
+	} else if this.NodeId != nil {
+		return false
+	} else if that1.NodeId != nil {
+		return false
+	}
+	if this.NodeAddress != nil && that1.NodeAddress != nil {
+		if *this.NodeAddress != *that1.NodeAddress {
+			return false
+		}
+	} else if this.NodeAddress != nil {
+		return false
+	} else if that1.NodeAddress != nil {
+		return false
+	}
+	if this.Ttl != nil && that1.Ttl != nil {
+		if *this.Ttl != *that1.Ttl {
+			return false
+		}
+	} else if this.Ttl != nil {
+		return false
+	} else if that1.Ttl != nil {
+		return false
+	}
+	if this.ExpectedVersion != nil && that1.ExpectedVersion != nil {
+		if *this.ExpectedVersion != *that1.ExpectedVersion {
+			return false
+		}
+	} else if this.ExpectedVersion != nil {
+		return false
+	} else if that1.ExpectedVersion != nil {
+		return false
+	}
+	if this.Flags != nil && that1.Flags != nil {
+		if *this.Flags != *that1.Flags {
+			return false
+		}
+	} else if this.Flags != nil {
+		return false
+	} else if that1.Flags != nil {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
 	return true
 }
+func (this *TransportResponse) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
+	}
+
+	that1, ok := that.(*TransportResponse)
+	if !ok {
+		that2, ok := that.(TransportResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *TransportResponse")
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *TransportResponse but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *TransportResponse but is not nil && this == nil")
+	}
+	if !bytes.Equal(this.Id, that1.Id) {
+		return fmt.Errorf("Id this(%v) Not Equal that(%v)", this.Id, that1.Id)
+	}
+	if this.Status != nil && that1.Status != nil {
+		if *this.Status != *that1.Status {
+			return fmt.Errorf("Status this(%v) Not Equal that(%v)", *this.Status, *that1.Status)
+		}
+	} else if this.Status != nil {
+		return fmt.Errorf("this.Status == nil && that.Status != nil")
+	} else if that1.Status != nil {
+		return fmt.Errorf("Status this(%v) Not Equal that(%v)", this.Status, that1.Status)
+	}
+	if !this.Body.Equal(that1.Body) {
+		return fmt.Errorf("Body this(%v) Not Equal that(%v)", this.Body, that1.Body)
+	}
+	if len(this.Pairs) != len(that1.Pairs) {
+		return fmt.Errorf("Pairs this(%v) Not Equal that(%v)", len(this.Pairs), len(that1.Pairs))
+	}
+	for i := range this.Pairs {
+		if !this.Pairs[i].Equal(that1.Pairs[i]) {
+			return fmt.Errorf("Pairs this[%v](%v) Not Equal that[%v](%v)", i, this.Pairs[i], i, that1.Pairs[i])
+		}
+	}
+	if this.More != nil && that1.More != nil {
+		if *this.More != *that1.More {
+			return fmt.Errorf("More this(%v) Not Equal that(%v)", *this.More, *that1.More)
+		}
+	} else if this.More != nil {
+		return fmt.Errorf("this.More == nil && that.More != nil")
+	} else if that1.More != nil {
+		return fmt.Errorf("More this(%v) Not Equal that(%v)", this.More, that1.More)
+	}
+	if this.Seq != nil && that1.Seq != nil {
+		if *this.Seq != *that1.Seq {
+			return fmt.Errorf("Seq this(%v) Not Equal that(%v)", *this.Seq, *that1.Seq)
+		}
+	} else if this.Seq != nil {
+		return fmt.Errorf("this.Seq == nil && that.Seq != nil")
+	} else if that1.Seq != nil {
+		return fmt.Errorf("Seq this(%v) Not Equal that(%v)", this.Seq, that1.Seq)
+	}
+	if this.Snapshot != nil && that1.Snapshot != nil {
+		if *this.Snapshot != *that1.Snapshot {
+			return fmt.Errorf("Snapshot this(%v) Not Equal that(%v)", *this.Snapshot, *that1.Snapshot)
+		}
+	} else if this.Snapshot != nil {
+		return fmt.Errorf("this.Snapshot == nil && that.Snapshot != nil")
+	} else if that1.Snapshot != nil {
+		return fmt.Errorf("Snapshot this(%v) Not Equal that(%v)", this.Snapshot, that1.Snapshot)
+	}
+	if this.LogId != nil && that1.LogId != nil {
+		if *this.LogId != *that1.LogId {
+			return fmt.Errorf("LogId this(%v) Not Equal that(%v)", *this.LogId, *that1.LogId)
+		}
+	} else if this.LogId != nil {
+		return fmt.Errorf("this.LogId == nil && that.LogId != nil")
+	} else if that1.LogId != nil {
+		return fmt.Errorf("LogId this(%v) Not Equal that(%v)", this.LogId, that1.LogId)
+	}
+	if this.LogSeq != nil && that1.LogSeq != nil {
+		if *this.LogSeq != *that1.LogSeq {
+			return fmt.Errorf("LogSeq this(%v) Not Equal that(%v)", *this.LogSeq, *that1.LogSeq)
+		}
+	} else if this.LogSeq != nil {
+		return fmt.Errorf("this.LogSeq == nil && that.LogSeq != nil")
+	} else if that1.LogSeq != nil {
+		return fmt.Errorf("LogSeq this(%v) Not Equal that(%v)", this.LogSeq, that1.LogSeq)
+	}
+	if len(this.Entries) != len(that1.Entries) {
+		return fmt.Errorf("Entries this(%v) Not Equal that(%v)", len(this.Entries), len(that1.Entries))
+	}
+	for i := range this.Entries {
+		if !this.Entries[i].Equal(that1.Entries[i]) {
+			return fmt.Errorf("Entries this[%v](%v) Not Equal that[%v](%v)", i, this.Entries[i], i, that1.Entries[i])
+		}
+	}
+	if !this.Replication.Equal(that1.Replication) {
+		return fmt.Errorf("Replication this(%v) Not Equal that(%v)", this.Replication, that1.Replication)
+	}
+	if this.Leader != nil && that1.Leader != nil {
+		if *this.Leader != *that1.Leader {
+			return fmt.Errorf("Leader this(%v) Not Equal that(%v)", *this.Leader, *that1.Leader)
+		}
+	} else if this.Leader != nil {
+		return fmt.Errorf("this.Leader == nil && that.Leader != nil")
+	} else if that1.Leader != nil {
+		return fmt.Errorf("Leader this(%v) Not Equal that(%v)", this.Leader, that1.Leader)
+	}
+	if !this.Cluster.Equal(that1.Cluster) {
+		return fmt.Errorf("Cluster this(%v) Not Equal that(%v)", this.Cluster, that1.Cluster)
+	}
+	if this.Ttl != nil && that1.Ttl != nil {
+		if *this.Ttl != *that1.Ttl {
+			return fmt.Errorf("Ttl this(%v) Not Equal that(%v)", *this.Ttl, *that1.Ttl)
+		}
+	} else if this.Ttl != nil {
+		return fmt.Errorf("this.Ttl == nil && that.Ttl != nil")
+	} else if that1.Ttl != nil {
+		return fmt.Errorf("Ttl this(%v) Not Equal that(%v)", this.Ttl, that1.Ttl)
+	}
+	if this.Flags != nil && that1.Flags != nil {
+		if *this.Flags != *that1.Flags {
+			return fmt.Errorf("Flags this(%v) Not Equal that(%v)", *this.Flags, *that1.Flags)
+		}
+	} else if this.Flags != nil {
+		return fmt.Errorf("this.Flags == nil && that.Flags != nil")
+	} else if that1.Flags != nil {
+		return fmt.Errorf("Flags this(%v) Not Equal that(%v)", this.Flags, that1.Flags)
+	}
+	if this.Version != nil && that1.Version != nil {
+		if *this.Version != *that1.Version {
+			return fmt.Errorf("Version this(%v) Not Equal that(%v)", *this.Version, *that1.Version)
+		}
+	} else if this.Version != nil {
+		return fmt.Errorf("this.Version == nil && that.Version != nil")
+	} else if that1.Version != nil {
+		return fmt.Errorf("Version this(%v) Not Equal that(%v)", this.Version, that1.Version)
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
+	}
+	return nil
+}
+func (this *TransportResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TransportResponse)
+	if !ok {
+		that2, ok := that.(TransportResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Id, that1.Id) {
+		return false
+	}
+	if this.Status != nil && that1.Status != nil {
+		if *this.Status != *that1.Status {
+			return false
+		}
+	} else if this.Status != nil {
+		return false
+	} else if that1.Status != nil {
+		return false
+	}
+	if !this.Body.Equal(that1.Body) {
+		return false
+	}
+	if len(this.Pairs) != len(that1.Pairs) {
+		return false
+	}
+	for i := range this.Pairs {
+		if !this.Pairs[i].Equal(that1.Pairs[i]) {
+			return false
+		}
+	}
+	if this.More != nil && that1.More != nil {
+		if *this.More != *that1.More {
+			return false
+		}
+	} else if this.More != nil {
+		return false
+	} else if that1.More != nil {
+		return false
+	}
+	if this.Seq != nil && that1.Seq != nil {
+		if *this.Seq != *that1.Seq {
+			return false
+		}
+	} else if this.Seq != nil {
+		return false
+	} else if that1.Seq != nil {
+		return false
+	}
+	if this.Snapshot != nil && that1.Snapshot != nil {
+		if *this.Snapshot != *that1.Snapshot {
+			return false
+		}
+	} else if this.Snapshot != nil {
+		return false
+	} else if that1.Snapshot != nil {
+		return false
+	}
+	if this.LogId != nil && that1.LogId != nil {
+		if *this.LogId != *that1.LogId {
+			return false
+		}
+	} else if this.LogId != nil {
+		return false
+	} else if that1.LogId != nil {
+		return false
+	}
+	if this.LogSeq != nil && that1.LogSeq != nil {
+		if *this.LogSeq != *that1.LogSeq {
+			return false
+		}
+	} else if this.LogSeq != nil {
+		return false
+	} else if that1.LogSeq != nil {
+		return false
+	}
+	if len(this.Entries) != len(that1.Entries) {
+		return false
+	}
+	for i := range this.Entries {
+		if !this.Entries[i].Equal(that1.Entries[i]) {
+			return false
+		}
+	}
+	if !this.Replication.Equal(that1.Replication) {
+		return false
+	}
+	if this.Leader != nil && that1.Leader != nil {
+		if *this.Leader != *that1.Leader {
+			return false
+		}
+	} else if this.Leader != nil {
+		return false
+	} else if that1.Leader != nil {
+		return false
+	}
+	if !this.Cluster.Equal(that1.Cluster) {
+		return false
+	}
+	if this.Ttl != nil && that1.Ttl != nil {
+		if *this.Ttl != *that1.Ttl {
+			return false
+		}
+	} else if this.Ttl != nil {
+		return false
+	} else if that1.Ttl != nil {
+		return false
+	}
+	if this.Flags != nil && that1.Flags != nil {
+		if *this.Flags != *that1.Flags {
+			return false
+		}
+	} else if this.Flags != nil {
+		return false
+	} else if that1.Flags != nil {
+		return false
+	}
+	if this.Version != nil && that1.Version != nil {
+		if *this.Version != *that1.Version {
+			return false
+		}
+	} else if this.Version != nil {
+		return false
+	} else if that1.Version != nil {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *LogEntry) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
+	}
+
+	that1, ok := that.(*LogEntry)
+	if !ok {
+		that2, ok := that.(LogEntry)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *LogEntry")
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *LogEntry but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *LogEntry but is not nil && this == nil")
+	}
+	if this.Seq != nil && that1.Seq != nil {
+		if *this.Seq != *that1.Seq {
+			return fmt.Errorf("Seq this(%v) Not Equal that(%v)", *this.Seq, *that1.Seq)
+		}
+	} else if this.Seq != nil {
+		return fmt.Errorf("this.Seq == nil && that.Seq != nil")
+	} else if that1.Seq != nil {
+		return fmt.Errorf("Seq this(%v) Not Equal that(%v)", this.Seq, that1.Seq)
+	}
+	if len(this.Ops) != len(that1.Ops) {
+		return fmt.Errorf("Ops this(%v) Not Equal that(%v)", len(this.Ops), len(that1.Ops))
+	}
+	for i := range this.Ops {
+		if !this.Ops[i].Equal(that1.Ops[i]) {
+			return fmt.Errorf("Ops this[%v](%v) Not Equal that[%v](%v)", i, this.Ops[i], i, that1.Ops[i])
+		}
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
+	}
+	return nil
+}
+func (this *LogEntry) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LogEntry)
+	if !ok {
+		that2, ok := that.(LogEntry)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Seq != nil && that1.Seq != nil {
+		if *this.Seq != *that1.Seq {
+			return false
+		}
+	} else if this.Seq != nil {
+		return false
+	} else if that1.Seq != nil {
+		return false
+	}
+	if len(this.Ops) != len(that1.Ops) {
+		return false
+	}
+	for i := range this.Ops {
+		if !this.Ops[i].Equal(that1.Ops[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *ReplicationStatus) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
+	}
+
+	that1, ok := that.(*ReplicationStatus)
+	if !ok {
+		that2, ok := that.(ReplicationStatus)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *ReplicationStatus")
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *ReplicationStatus but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *ReplicationStatus but is not nil && this == nil")
+	}
+	if this.Role != nil && that1.Role != nil {
+		if *this.Role != *that1.Role {
+			return fmt.Errorf("Role this(%v) Not Equal that(%v)", *this.Role, *that1.Role)
+		}
+	} else if this.Role != nil {
+		return fmt.Errorf("this.Role == nil && that.Role != nil")
+	} else if that1.Role != nil {
+		return fmt.Errorf("Role this(%v) Not Equal that(%v)", this.Role, that1.Role)
+	}
+	if this.Primary != nil && that1.Primary != nil {
+		if *this.Primary != *that1.Primary {
+			return fmt.Errorf("Primary this(%v) Not Equal that(%v)", *this.Primary, *that1.Primary)
+		}
+	} else if this.Primary != nil {
+		return fmt.Errorf("this.Primary == nil && that.Primary != nil")
+	} else if that1.Primary != nil {
+		return fmt.Errorf("Primary this(%v) Not Equal that(%v)", this.Primary, that1.Primary)
+	}
+	if this.Connected != nil && that1.Connected != nil {
+		if *this.Connected != *that1.Connected {
+			return fmt.Errorf("Connected this(%v) Not Equal that(%v)", *this.Connected, *that1.Connected)
+		}
+	} else if this.Connected != nil {
+		return fmt.Errorf("this.Connected == nil && that.Connected != nil")
+	} else if that1.Connected != nil {
+		return fmt.Errorf("Connected this(%v) Not Equal that(%v)", this.Connected, that1.Connected)
+	}
+	if this.LogId != nil && that1.LogId != nil {
+		if *this.LogId != *that1.LogId {
+			return fmt.Errorf("LogId this(%v) Not Equal that(%v)", *this.LogId, *that1.LogId)
+		}
+	} else if this.LogId != nil {
+		return fmt.Errorf("this.LogId == nil && that.LogId != nil")
+	} else if that1.LogId != nil {
+		return fmt.Errorf("LogId this(%v) Not Equal that(%v)", this.LogId, that1.LogId)
+	}
+	if this.LogSeq != nil && that1.LogSeq != nil {
+		if *this.LogSeq != *that1.LogSeq {
+			return fmt.Errorf("LogSeq this(%v) Not Equal that(%v)", *this.LogSeq, *that1.LogSeq)
+		}
+	} else if this.LogSeq != nil {
+		return fmt.Errorf("this.LogSeq == nil && that.LogSeq != nil")
+	} else if that1.LogSeq != nil {
+		return fmt.Errorf("LogSeq this(%v) Not Equal that(%v)", this.LogSeq, that1.LogSeq)
+	}
+	if this.PrimarySeq != nil && that1.PrimarySeq != nil {
+		if *this.PrimarySeq != *that1.PrimarySeq {
+			return fmt.Errorf("PrimarySeq this(%v) Not Equal that(%v)", *this.PrimarySeq, *that1.PrimarySeq)
+		}
+	} else if this.PrimarySeq != nil {
+		return fmt.Errorf("this.PrimarySeq == nil && that.PrimarySeq != nil")
+	} else if that1.PrimarySeq != nil {
+		return fmt.Errorf("PrimarySeq this(%v) Not Equal that(%v)", this.PrimarySeq, that1.PrimarySeq)
+	}
+	if this.LagSeconds != nil && that1.LagSeconds != nil {
+		if *this.LagSeconds != *that1.LagSeconds {
+			return fmt.Errorf("LagSeconds this(%v) Not Equal that(%v)", *this.LagSeconds, *that1.LagSeconds)
+		}
+	} else if this.LagSeconds != nil {
+		return fmt.Errorf("this.LagSeconds == nil && that.LagSeconds != nil")
+	} else if that1.LagSeconds != nil {
+		return fmt.Errorf("LagSeconds this(%v) Not Equal that(%v)", this.LagSeconds, that1.LagSeconds)
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
+	}
+	return nil
+}
+func (this *ReplicationStatus) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ReplicationStatus)
+	if !ok {
+		that2, ok := that.(ReplicationStatus)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Role != nil && that1.Role != nil {
+		if *this.Role != *that1.Role {
+			return false
+		}
+	} else if this.Role != nil {
+		return false
+	} else if that1.Role != nil {
+		return false
+	}
+	if this.Primary != nil && that1.Primary != nil {
+		if *this.Primary != *that1.Primary {
+			return false
+		}
+	} else if this.Primary != nil {
+		return false
+	} else if that1.Primary != nil {
+		return false
+	}
+	if this.Connected != nil && that1.Connected != nil {
+		if *this.Connected != *that1.Connected {
+			return false
+		}
+	} else if this.Connected != nil {
+		return false
+	} else if that1.Connected != nil {
+		return false
+	}
+	if this.LogId != nil && that1.LogId != nil {
+		if *this.LogId != *that1.LogId {
+			return false
+		}
+	} else if this.LogId != nil {
+		return false
+	} else if that1.LogId != nil {
+		return false
+	}
+	if this.LogSeq != nil && that1.LogSeq != nil {
+		if *this.LogSeq != *that1.LogSeq {
+			return false
+		}
+	} else if this.LogSeq != nil {
+		return false
+	} else if that1.LogSeq != nil {
+		return false
+	}
+	if this.PrimarySeq != nil && that1.PrimarySeq != nil {
+		if *this.PrimarySeq != *that1.PrimarySeq {
+			return false
+		}
+	} else if this.PrimarySeq != nil {
+		return false
+	} else if that1.PrimarySeq != nil {
+		return false
+	}
+	if this.LagSeconds != nil && that1.LagSeconds != nil {
+		if *this.LagSeconds != *that1.LagSeconds {
+			return false
+		}
+	} else if this.LagSeconds != nil {
+		return false
+	} else if that1.LagSeconds != nil {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *ClusterStatus) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
+	}
+
+	that1, ok := that.(*ClusterStatus)
+	if !ok {
+		that2, ok := that.(ClusterStatus)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *ClusterStatus")
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *ClusterStatus but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *ClusterStatus but is not nil && this == nil")
+	}
+	if this.NodeId != nil && that1.NodeId != nil {
+		if *this.NodeId != *that1.NodeId {
+			return fmt.Errorf("NodeId this(%v) Not Equal that(%v)", *this.NodeId, *that1.NodeId)
+		}
+	} else if this.NodeId != nil {
+		return fmt.Errorf("this.NodeId == nil && that.NodeId != nil")
+	} else if that1.NodeId != nil {
+		return fmt.Errorf("NodeId this(%v) Not Equal that(%v)", this.NodeId, that1.NodeId)
+	}
+	if this.State != nil && that1.State != nil {
+		if *this.State != *that1.State {
+			return fmt.Errorf("State this(%v) Not Equal that(%v)", *this.State, *that1.State)
+		}
+	} else if this.State != nil {
+		return fmt.Errorf("this.State == nil && that.State != nil")
+	} else if that1.State != nil {
+		return fmt.Errorf("State this(%v) Not Equal that(%v)", this.State, that1.State)
+	}
+	if this.Leader != nil && that1.Leader != nil {
+		if *this.Leader != *that1.Leader {
+			return fmt.Errorf("Leader this(%v) Not Equal that(%v)", *this.Leader, *that1.Leader)
+		}
+	} else if this.Leader != nil {
+		return fmt.Errorf("this.Leader == nil && that.Leader != nil")
+	} else if that1.Leader != nil {
+		return fmt.Errorf("Leader this(%v) Not Equal that(%v)", this.Leader, that1.Leader)
+	}
+	if len(this.Nodes) != len(that1.Nodes) {
+		return fmt.Errorf("Nodes this(%v) Not Equal that(%v)", len(this.Nodes), len(that1.Nodes))
+	}
+	for i := range this.Nodes {
+		if !this.Nodes[i].Equal(that1.Nodes[i]) {
+			return fmt.Errorf("Nodes this[%v](%v) Not Equal that[%v](%v)", i, this.Nodes[i], i, that1.Nodes[i])
+		}
+	}
+	if this.AppliedIndex != nil && that1.AppliedIndex != nil {
+		if *this.AppliedIndex != *that1.AppliedIndex {
+			return fmt.Errorf("AppliedIndex this(%v) Not Equal that(%v)", *this.AppliedIndex, *that1.AppliedIndex)
+		}
+	} else if this.AppliedIndex != nil {
+		return fmt.Errorf("this.AppliedIndex == nil && that.AppliedIndex != nil")
+	} else if that1.AppliedIndex != nil {
+		return fmt.Errorf("AppliedIndex this(%v) Not Equal that(%v)", this.AppliedIndex, that1.AppliedIndex)
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
+	}
+	return nil
+}
+func (this *ClusterStatus) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ClusterStatus)
+	if !ok {
+		that2, ok := that.(ClusterStatus)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.NodeId != nil && that1.NodeId != nil {
+		if *this.NodeId != *that1.NodeId {
+			return false
+		}
+	} else if this.NodeId != nil {
+		return false
+	} else if that1.NodeId != nil {
+		return false
+	}
+	if this.State != nil && that1.State != nil {
+		if *this.State != *that1.State {
+			return false
+		}
+	} else if this.State != nil {
+		return false
+	} else if that1.State != nil {
+		return false
+	}
+	if this.Leader != nil && that1.Leader != nil {
+		if *this.Leader != *that1.Leader {
+			return false
+		}
+	} else if this.Leader != nil {
+		return false
+	} else if that1.Leader != nil {
+		return false
+	}
+	if len(this.Nodes) != len(that1.Nodes) {
+		return false
+	}
+	for i := range this.Nodes {
+		if !this.Nodes[i].Equal(that1.Nodes[i]) {
+			return false
+		}
+	}
+	if this.AppliedIndex != nil && that1.AppliedIndex != nil {
+		if *this.AppliedIndex != *that1.AppliedIndex {
+			return false
+		}
+	} else if this.AppliedIndex != nil {
+		return false
+	} else if that1.AppliedIndex != nil {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *ClusterNode) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
+	}
+
+	that1, ok := that.(*ClusterNode)
+	if !ok {
+		that2, ok := that.(ClusterNode)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *ClusterNode")
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *ClusterNode but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *ClusterNode but is not nil && this == nil")
+	}
+	if this.Id != nil && that1.Id != nil {
+		if *this.Id != *that1.Id {
+			return fmt.Errorf("Id this(%v) Not Equal that(%v)", *this.Id, *that1.Id)
+		}
+	} else if this.Id != nil {
+		return fmt.Errorf("this.Id == nil && that.Id != nil")
+	} else if that1.Id != nil {
+		return fmt.Errorf("Id this(%v) Not Equal that(%v)", this.Id, that1.Id)
+	}
+	if this.Address != nil && that1.Address != nil {
+		if *this.Address != *that1.Address {
+			return fmt.Errorf("Address this(%v) Not Equal that(%v)", *this.Address, *that1.Address)
+		}
+	} else if this.Address != nil {
+		return fmt.Errorf("this.Address == nil && that.Address != nil")
+	} else if that1.Address != nil {
+		return fmt.Errorf("Address this(%v) Not Equal that(%v)", this.Address, that1.Address)
+	}
+	if this.Voter != nil && that1.Voter != nil {
+		if *this.Voter != *that1.Voter {
+			return fmt.Errorf("Voter this(%v) Not Equal that(%v)", *this.Voter, *that1.Voter)
+		}
+	} else if this.Voter != nil {
+		return fmt.Errorf("this.Voter == nil && that.Voter != nil")
+	} else if that1.Voter != nil {
+		return fmt.Errorf("Voter this(%v) Not Equal that(%v)", this.Voter, that1.Voter)
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
+	}
+	return nil
+}
+func (this *ClusterNode) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ClusterNode)
+	if !ok {
+		that2, ok := that.(ClusterNode)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Id != nil && that1.Id != nil {
+		if *this.Id != *that1.Id {
+			return false
+		}
+	} else if this.Id != nil {
+		return false
+	} else if that1.Id != nil {
+		return false
+	}
+	if this.Address != nil && that1.Address != nil {
+		if *this.Address != *that1.Address {
+			return false
+		}
+	} else if this.Address != nil {
+		return false
+	} else if that1.Address != nil {
+		return false
+	}
+	if this.Voter != nil && that1.Voter != nil {
+		if *this.Voter != *that1.Voter {
+			return false
+		}
+	} else if this.Voter != nil {
+		return false
+	} else if that1.Voter != nil {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *BackupRecord) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
+	}
+
+	that1, ok := that.(*BackupRecord)
+	if !ok {
+		that2, ok := that.(BackupRecord)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *BackupRecord")
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *BackupRecord but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *BackupRecord but is not nil && this == nil")
+	}
+	if !this.Pair.Equal(that1.Pair) {
+		return fmt.Errorf("Pair this(%v) Not Equal that(%v)", this.Pair, that1.Pair)
+	}
+	if this.Count != nil && that1.Count != nil {
+		if *this.Count != *that1.Count {
+			return fmt.Errorf("Count this(%v) Not Equal that(%v)", *this.Count, *that1.Count)
+		}
+	} else if this.Count != nil {
+		return fmt.Errorf("this.Count == nil && that.Count != nil")
+	} else if that1.Count != nil {
+		return fmt.Errorf("Count this(%v) Not Equal that(%v)", this.Count, that1.Count)
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
+	}
+	return nil
+}
+func (this *BackupRecord) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*BackupRecord)
+	if !ok {
+		that2, ok := that.(BackupRecord)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Pair.Equal(that1.Pair) {
+		return false
+	}
+	if this.Count != nil && that1.Count != nil {
+		if *this.Count != *that1.Count {
+			return false
+		}
+	} else if this.Count != nil {
+		return false
+	} else if that1.Count != nil {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *TransportBody) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&ldbserver.TransportBody{")
+	if this.Checksum != nil {
+		s = append(s, "Checksum: "+valueToGoStringTransport(this.Checksum, "uint32")+",\n")
+	}
+	if this.Data != nil {
+		s = append(s, "Data: "+valueToGoStringTransport(this.Data, "byte")+",\n")
+	}
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TransportRange) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&ldbserver.TransportRange{")
+	if this.Start != nil {
+		s = append(s, "Start: "+valueToGoStringTransport(this.Start, "byte")+",\n")
+	}
+	if this.End != nil {
+		s = append(s, "End: "+valueToGoStringTransport(this.End, "byte")+",\n")
+	}
+	if this.Prefix != nil {
+		s = append(s, "Prefix: "+valueToGoStringTransport(this.Prefix, "byte")+",\n")
+	}
+	if this.Limit != nil {
+		s = append(s, "Limit: "+valueToGoStringTransport(this.Limit, "uint32")+",\n")
+	}
+	if this.Reverse != nil {
+		s = append(s, "Reverse: "+valueToGoStringTransport(this.Reverse, "bool")+",\n")
+	}
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TransportPair) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&ldbserver.TransportPair{")
+	if this.Key != nil {
+		s = append(s, "Key: "+valueToGoStringTransport(this.Key, "byte")+",\n")
+	}
+	if this.Value != nil {
+		s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	}
+	if this.Expires != nil {
+		s = append(s, "Expires: "+valueToGoStringTransport(this.Expires, "int64")+",\n")
+	}
+	if this.Flags != nil {
+		s = append(s, "Flags: "+valueToGoStringTransport(this.Flags, "uint32")+",\n")
+	}
+	if this.Version != nil {
+		s = append(s, "Version: "+valueToGoStringTransport(this.Version, "uint64")+",\n")
+	}
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TransportOperation) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&ldbserver.TransportOperation{")
+	if this.Command != nil {
+		s = append(s, "Command: "+valueToGoStringTransport(this.Command, "TransportRequest_Command")+",\n")
+	}
+	if this.Key != nil {
+		s = append(s, "Key: "+valueToGoStringTransport(this.Key, "byte")+",\n")
+	}
+	if this.Body != nil {
+		s = append(s, "Body: "+fmt.Sprintf("%#v", this.Body)+",\n")
+	}
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TransportRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 24)
+	s = append(s, "&ldbserver.TransportRequest{")
+	if this.Id != nil {
+		s = append(s, "Id: "+valueToGoStringTransport(this.Id, "byte")+",\n")
+	}
+	if this.Command != nil {
+		s = append(s, "Command: "+valueToGoStringTransport(this.Command, "TransportRequest_Command")+",\n")
+	}
+	if this.Body != nil {
+		s = append(s, "Body: "+fmt.Sprintf("%#v", this.Body)+",\n")
+	}
+	if this.Range != nil {
+		s = append(s, "Range: "+fmt.Sprintf("%#v", this.Range)+",\n")
+	}
+	if this.Batch != nil {
+		s = append(s, "Batch: "+fmt.Sprintf("%#v", this.Batch)+",\n")
+	}
+	if this.Sync != nil {
+		s = append(s, "Sync: "+valueToGoStringTransport(this.Sync, "bool")+",\n")
+	}
+	if this.Key != nil {
+		s = append(s, "Key: "+valueToGoStringTransport(this.Key, "byte")+",\n")
+	}
+	if this.Seq != nil {
+		s = append(s, "Seq: "+valueToGoStringTransport(this.Seq, "uint64")+",\n")
+	}
+	if this.Snapshot != nil {
+		s = append(s, "Snapshot: "+valueToGoStringTransport(this.Snapshot, "uint64")+",\n")
+	}
+	if this.Expected != nil {
+		s = append(s, "Expected: "+fmt.Sprintf("%#v", this.Expected)+",\n")
+	}
+	if this.Token != nil {
+		s = append(s, "Token: "+valueToGoStringTransport(this.Token, "string")+",\n")
+	}
+	if this.Database != nil {
+		s = append(s, "Database: "+valueToGoStringTransport(this.Database, "string")+",\n")
+	}
+	if this.Path != nil {
+		s = append(s, "Path: "+valueToGoStringTransport(this.Path, "string")+",\n")
+	}
+	if this.LogId != nil {
+		s = append(s, "LogId: "+valueToGoStringTransport(this.LogId, "string")+",\n")
+	}
+	if this.LogSeq != nil {
+		s = append(s, "LogSeq: "+valueToGoStringTransport(this.LogSeq, "uint64")+",\n")
+	}
+	if this.NodeId != nil {
+		s = append(s, "NodeId: "+valueToGoStringTransport(this.NodeId, "string")+",\n")
+	}
+	if this.NodeAddress != nil {
+		s = append(s, "NodeAddress: "+valueToGoStringTransport(this.NodeAddress, "string")+",\n")
+	}
+	if this.Ttl != nil {
+		s = append(s, "Ttl: "+valueToGoStringTransport(this.Ttl, "uint64")+",\n")
+	}
+	if this.ExpectedVersion != nil {
+		s = append(s, "ExpectedVersion: "+valueToGoStringTransport(this.ExpectedVersion, "uint64")+",\n")
+	}
+	if this.Flags != nil {
+		s = append(s, "Flags: "+valueToGoStringTransport(this.Flags, "uint32")+",\n")
+	}
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TransportResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 20)
+	s = append(s, "&ldbserver.TransportResponse{")
+	if this.Id != nil {
+		s = append(s, "Id: "+valueToGoStringTransport(this.Id, "byte")+",\n")
+	}
+	if this.Status != nil {
+		s = append(s, "Status: "+valueToGoStringTransport(this.Status, "TransportResponse_Status")+",\n")
+	}
+	if this.Body != nil {
+		s = append(s, "Body: "+fmt.Sprintf("%#v", this.Body)+",\n")
+	}
+	if this.Pairs != nil {
+		s = append(s, "Pairs: "+fmt.Sprintf("%#v", this.Pairs)+",\n")
+	}
+	if this.More != nil {
+		s = append(s, "More: "+valueToGoStringTransport(this.More, "bool")+",\n")
+	}
+	if this.Seq != nil {
+		s = append(s, "Seq: "+valueToGoStringTransport(this.Seq, "uint64")+",\n")
+	}
+	if this.Snapshot != nil {
+		s = append(s, "Snapshot: "+valueToGoStringTransport(this.Snapshot, "uint64")+",\n")
+	}
+	if this.LogId != nil {
+		s = append(s, "LogId: "+valueToGoStringTransport(this.LogId, "string")+",\n")
+	}
+	if this.LogSeq != nil {
+		s = append(s, "LogSeq: "+valueToGoStringTransport(this.LogSeq, "uint64")+",\n")
+	}
+	if this.Entries != nil {
+		s = append(s, "Entries: "+fmt.Sprintf("%#v", this.Entries)+",\n")
+	}
+	if this.Replication != nil {
+		s = append(s, "Replication: "+fmt.Sprintf("%#v", this.Replication)+",\n")
+	}
+	if this.Leader != nil {
+		s = append(s, "Leader: "+valueToGoStringTransport(this.Leader, "string")+",\n")
+	}
+	if this.Cluster != nil {
+		s = append(s, "Cluster: "+fmt.Sprintf("%#v", this.Cluster)+",\n")
+	}
+	if this.Ttl != nil {
+		s = append(s, "Ttl: "+valueToGoStringTransport(this.Ttl, "int64")+",\n")
+	}
+	if this.Flags != nil {
+		s = append(s, "Flags: "+valueToGoStringTransport(this.Flags, "uint32")+",\n")
+	}
+	if this.Version != nil {
+		s = append(s, "Version: "+valueToGoStringTransport(this.Version, "uint64")+",\n")
+	}
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LogEntry) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&ldbserver.LogEntry{")
+	if this.Seq != nil {
+		s = append(s, "Seq: "+valueToGoStringTransport(this.Seq, "uint64")+",\n")
+	}
+	if this.Ops != nil {
+		s = append(s, "Ops: "+fmt.Sprintf("%#v", this.Ops)+",\n")
+	}
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ReplicationStatus) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 11)
+	s = append(s, "&ldbserver.ReplicationStatus{")
+	if this.Role != nil {
+		s = append(s, "Role: "+valueToGoStringTransport(this.Role, "string")+",\n")
+	}
+	if this.Primary != nil {
+		s = append(s, "Primary: "+valueToGoStringTransport(this.Primary, "string")+",\n")
+	}
+	if this.Connected != nil {
+		s = append(s, "Connected: "+valueToGoStringTransport(this.Connected, "bool")+",\n")
+	}
+	if this.LogId != nil {
+		s = append(s, "LogId: "+valueToGoStringTransport(this.LogId, "string")+",\n")
+	}
+	if this.LogSeq != nil {
+		s = append(s, "LogSeq: "+valueToGoStringTransport(this.LogSeq, "uint64")+",\n")
+	}
+	if this.PrimarySeq != nil {
+		s = append(s, "PrimarySeq: "+valueToGoStringTransport(this.PrimarySeq, "uint64")+",\n")
+	}
+	if this.LagSeconds != nil {
+		s = append(s, "LagSeconds: "+valueToGoStringTransport(this.LagSeconds, "float64")+",\n")
+	}
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ClusterStatus) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&ldbserver.ClusterStatus{")
+	if this.NodeId != nil {
+		s = append(s, "NodeId: "+valueToGoStringTransport(this.NodeId, "string")+",\n")
+	}
+	if this.State != nil {
+		s = append(s, "State: "+valueToGoStringTransport(this.State, "string")+",\n")
+	}
+	if this.Leader != nil {
+		s = append(s, "Leader: "+valueToGoStringTransport(this.Leader, "string")+",\n")
+	}
+	if this.Nodes != nil {
+		s = append(s, "Nodes: "+fmt.Sprintf("%#v", this.Nodes)+",\n")
+	}
+	if this.AppliedIndex != nil {
+		s = append(s, "AppliedIndex: "+valueToGoStringTransport(this.AppliedIndex, "uint64")+",\n")
+	}
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ClusterNode) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&ldbserver.ClusterNode{")
+	if this.Id != nil {
+		s = append(s, "Id: "+valueToGoStringTransport(this.Id, "string")+",\n")
+	}
+	if this.Address != nil {
+		s = append(s, "Address: "+valueToGoStringTransport(this.Address, "string")+",\n")
+	}
+	if this.Voter != nil {
+		s = append(s, "Voter: "+valueToGoStringTransport(this.Voter, "bool")+",\n")
+	}
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *BackupRecord) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&ldbserver.BackupRecord{")
+	if this.Pair != nil {
+		s = append(s, "Pair: "+fmt.Sprintf("%#v", this.Pair)+",\n")
+	}
+	if this.Count != nil {
+		s = append(s, "Count: "+valueToGoStringTransport(this.Count, "uint64")+",\n")
+	}
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringTransport(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *TransportBody) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransportBody) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransportBody) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Data != nil {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintTransport(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Checksum == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("checksum")
+	} else {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Checksum))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransportRange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransportRange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransportRange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Reverse != nil {
+		i--
+		if *m.Reverse {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Limit != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Limit))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Prefix != nil {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintTransport(dAtA, i, uint64(len(m.Prefix)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.End != nil {
+		i -= len(m.End)
+		copy(dAtA[i:], m.End)
+		i = encodeVarintTransport(dAtA, i, uint64(len(m.End)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Start != nil {
+		i -= len(m.Start)
+		copy(dAtA[i:], m.Start)
+		i = encodeVarintTransport(dAtA, i, uint64(len(m.Start)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransportPair) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransportPair) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransportPair) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Version))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Flags != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Flags))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Expires != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Expires))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Value != nil {
+		{
+			size, err := m.Value.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransport(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Key == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("key")
+	} else {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintTransport(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransportOperation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransportOperation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransportOperation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransport(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Key == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("key")
+	} else {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintTransport(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Command == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("command")
+	} else {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Command))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Flags != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Flags))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.ExpectedVersion != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.ExpectedVersion))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.Ttl != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Ttl))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.NodeAddress != nil {
+		i -= len(*m.NodeAddress)
+		copy(dAtA[i:], *m.NodeAddress)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.NodeAddress)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.NodeId != nil {
+		i -= len(*m.NodeId)
+		copy(dAtA[i:], *m.NodeId)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.NodeId)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.LogSeq != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.LogSeq))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.LogId != nil {
+		i -= len(*m.LogId)
+		copy(dAtA[i:], *m.LogId)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.LogId)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.Path != nil {
+		i -= len(*m.Path)
+		copy(dAtA[i:], *m.Path)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.Path)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Database != nil {
+		i -= len(*m.Database)
+		copy(dAtA[i:], *m.Database)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.Database)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.Token != nil {
+		i -= len(*m.Token)
+		copy(dAtA[i:], *m.Token)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.Token)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.Expected != nil {
+		{
+			size, err := m.Expected.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransport(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.Snapshot != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Snapshot))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Seq != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Seq))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Key != nil {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintTransport(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Sync != nil {
+		i--
+		if *m.Sync {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Batch) > 0 {
+		for iNdEx := len(m.Batch) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Batch[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTransport(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Range != nil {
+		{
+			size, err := m.Range.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransport(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransport(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Command != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Command))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Id != nil {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintTransport(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransportResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransportResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransportResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Version))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.Flags != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Flags))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.Ttl != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Ttl))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.Cluster != nil {
+		{
+			size, err := m.Cluster.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransport(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Leader != nil {
+		i -= len(*m.Leader)
+		copy(dAtA[i:], *m.Leader)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.Leader)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.Replication != nil {
+		{
+			size, err := m.Replication.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransport(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.Entries) > 0 {
+		for iNdEx := len(m.Entries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Entries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTransport(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if m.LogSeq != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.LogSeq))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.LogId != nil {
+		i -= len(*m.LogId)
+		copy(dAtA[i:], *m.LogId)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.LogId)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Snapshot != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Snapshot))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Seq != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Seq))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.More != nil {
+		i--
+		if *m.More {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Pairs) > 0 {
+		for iNdEx := len(m.Pairs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Pairs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTransport(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransport(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Status == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("status")
+	} else {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Status))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Id != nil {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintTransport(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Ops) > 0 {
+		for iNdEx := len(m.Ops) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ops[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTransport(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Seq == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("seq")
+	} else {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Seq))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReplicationStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReplicationStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReplicationStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.LagSeconds != nil {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(*m.LagSeconds))))
+		i--
+		dAtA[i] = 0x39
+	}
+	if m.PrimarySeq != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.PrimarySeq))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.LogSeq != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.LogSeq))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.LogId != nil {
+		i -= len(*m.LogId)
+		copy(dAtA[i:], *m.LogId)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.LogId)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Connected != nil {
+		i--
+		if *m.Connected {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Primary != nil {
+		i -= len(*m.Primary)
+		copy(dAtA[i:], *m.Primary)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.Primary)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Role != nil {
+		i -= len(*m.Role)
+		copy(dAtA[i:], *m.Role)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.Role)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ClusterStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClusterStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.AppliedIndex != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.AppliedIndex))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Nodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTransport(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Leader != nil {
+		i -= len(*m.Leader)
+		copy(dAtA[i:], *m.Leader)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.Leader)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.State != nil {
+		i -= len(*m.State)
+		copy(dAtA[i:], *m.State)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.State)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.NodeId != nil {
+		i -= len(*m.NodeId)
+		copy(dAtA[i:], *m.NodeId)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.NodeId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ClusterNode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterNode) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClusterNode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Voter != nil {
+		i--
+		if *m.Voter {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Address == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("address")
+	} else {
+		i -= len(*m.Address)
+		copy(dAtA[i:], *m.Address)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	} else {
+		i -= len(*m.Id)
+		copy(dAtA[i:], *m.Id)
+		i = encodeVarintTransport(dAtA, i, uint64(len(*m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BackupRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BackupRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BackupRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Count != nil {
+		i = encodeVarintTransport(dAtA, i, uint64(*m.Count))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Pair != nil {
+		{
+			size, err := m.Pair.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransport(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTransport(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTransport(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func NewPopulatedTransportBody(r randyTransport, easy bool) *TransportBody {
+	this := &TransportBody{}
+	v1 := uint32(r.Uint32())
+	this.Checksum = &v1
+	if r.Intn(5) != 0 {
+		v2 := r.Intn(100)
+		this.Data = make([]byte, v2)
+		for i := 0; i < v2; i++ {
+			this.Data[i] = byte(r.Intn(256))
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTransport(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedTransportRange(r randyTransport, easy bool) *TransportRange {
+	this := &TransportRange{}
+	if r.Intn(5) != 0 {
+		v3 := r.Intn(100)
+		this.Start = make([]byte, v3)
+		for i := 0; i < v3; i++ {
+			this.Start[i] = byte(r.Intn(256))
+		}
+	}
+	if r.Intn(5) != 0 {
+		v4 := r.Intn(100)
+		this.End = make([]byte, v4)
+		for i := 0; i < v4; i++ {
+			this.End[i] = byte(r.Intn(256))
+		}
+	}
+	if r.Intn(5) != 0 {
+		v5 := r.Intn(100)
+		this.Prefix = make([]byte, v5)
+		for i := 0; i < v5; i++ {
+			this.Prefix[i] = byte(r.Intn(256))
+		}
+	}
+	if r.Intn(5) != 0 {
+		v6 := uint32(r.Uint32())
+		this.Limit = &v6
+	}
+	if r.Intn(5) != 0 {
+		v7 := bool(bool(r.Intn(2) == 0))
+		this.Reverse = &v7
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTransport(r, 6)
+	}
+	return this
+}
+
+func NewPopulatedTransportPair(r randyTransport, easy bool) *TransportPair {
+	this := &TransportPair{}
+	v8 := r.Intn(100)
+	this.Key = make([]byte, v8)
+	for i := 0; i < v8; i++ {
+		this.Key[i] = byte(r.Intn(256))
+	}
+	if r.Intn(5) != 0 {
+		this.Value = NewPopulatedTransportBody(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		v9 := int64(r.Int63())
+		if r.Intn(2) == 0 {
+			v9 *= -1
+		}
+		this.Expires = &v9
+	}
+	if r.Intn(5) != 0 {
+		v10 := uint32(r.Uint32())
+		this.Flags = &v10
+	}
+	if r.Intn(5) != 0 {
+		v11 := uint64(uint64(r.Uint32()))
+		this.Version = &v11
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTransport(r, 6)
+	}
+	return this
+}
+
+func NewPopulatedTransportOperation(r randyTransport, easy bool) *TransportOperation {
+	this := &TransportOperation{}
+	v12 := TransportRequest_Command([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}[r.Intn(24)])
+	this.Command = &v12
+	v13 := r.Intn(100)
+	this.Key = make([]byte, v13)
+	for i := 0; i < v13; i++ {
+		this.Key[i] = byte(r.Intn(256))
+	}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedTransportBody(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTransport(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedTransportRequest(r randyTransport, easy bool) *TransportRequest {
+	this := &TransportRequest{}
+	if r.Intn(5) != 0 {
+		v14 := r.Intn(100)
+		this.Id = make([]byte, v14)
+		for i := 0; i < v14; i++ {
+			this.Id[i] = byte(r.Intn(256))
+		}
+	}
+	if r.Intn(5) != 0 {
+		v15 := TransportRequest_Command([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}[r.Intn(24)])
+		this.Command = &v15
+	}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedTransportBody(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.Range = NewPopulatedTransportRange(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		v16 := r.Intn(5)
+		this.Batch = make([]*TransportOperation, v16)
+		for i := 0; i < v16; i++ {
+			this.Batch[i] = NewPopulatedTransportOperation(r, easy)
+		}
+	}
+	if r.Intn(5) != 0 {
+		v17 := bool(bool(r.Intn(2) == 0))
+		this.Sync = &v17
+	}
+	if r.Intn(5) != 0 {
+		v18 := r.Intn(100)
+		this.Key = make([]byte, v18)
+		for i := 0; i < v18; i++ {
+			this.Key[i] = byte(r.Intn(256))
+		}
+	}
+	if r.Intn(5) != 0 {
+		v19 := uint64(uint64(r.Uint32()))
+		this.Seq = &v19
+	}
+	if r.Intn(5) != 0 {
+		v20 := uint64(uint64(r.Uint32()))
+		this.Snapshot = &v20
+	}
+	if r.Intn(5) != 0 {
+		this.Expected = NewPopulatedTransportBody(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		v21 := string(randStringTransport(r))
+		this.Token = &v21
+	}
+	if r.Intn(5) != 0 {
+		v22 := string(randStringTransport(r))
+		this.Database = &v22
+	}
+	if r.Intn(5) != 0 {
+		v23 := string(randStringTransport(r))
+		this.Path = &v23
+	}
+	if r.Intn(5) != 0 {
+		v24 := string(randStringTransport(r))
+		this.LogId = &v24
+	}
+	if r.Intn(5) != 0 {
+		v25 := uint64(uint64(r.Uint32()))
+		this.LogSeq = &v25
+	}
+	if r.Intn(5) != 0 {
+		v26 := string(randStringTransport(r))
+		this.NodeId = &v26
+	}
+	if r.Intn(5) != 0 {
+		v27 := string(randStringTransport(r))
+		this.NodeAddress = &v27
+	}
+	if r.Intn(5) != 0 {
+		v28 := uint64(uint64(r.Uint32()))
+		this.Ttl = &v28
+	}
+	if r.Intn(5) != 0 {
+		v29 := uint64(uint64(r.Uint32()))
+		this.ExpectedVersion = &v29
+	}
+	if r.Intn(5) != 0 {
+		v30 := uint32(r.Uint32())
+		this.Flags = &v30
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTransport(r, 21)
+	}
+	return this
+}
+
+func NewPopulatedTransportResponse(r randyTransport, easy bool) *TransportResponse {
+	this := &TransportResponse{}
+	if r.Intn(5) != 0 {
+		v31 := r.Intn(100)
+		this.Id = make([]byte, v31)
+		for i := 0; i < v31; i++ {
+			this.Id[i] = byte(r.Intn(256))
+		}
+	}
+	v32 := TransportResponse_Status([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}[r.Intn(11)])
+	this.Status = &v32
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedTransportBody(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		v33 := r.Intn(5)
+		this.Pairs = make([]*TransportPair, v33)
+		for i := 0; i < v33; i++ {
+			this.Pairs[i] = NewPopulatedTransportPair(r, easy)
+		}
+	}
+	if r.Intn(5) != 0 {
+		v34 := bool(bool(r.Intn(2) == 0))
+		this.More = &v34
+	}
+	if r.Intn(5) != 0 {
+		v35 := uint64(uint64(r.Uint32()))
+		this.Seq = &v35
+	}
+	if r.Intn(5) != 0 {
+		v36 := uint64(uint64(r.Uint32()))
+		this.Snapshot = &v36
+	}
+	if r.Intn(5) != 0 {
+		v37 := string(randStringTransport(r))
+		this.LogId = &v37
+	}
+	if r.Intn(5) != 0 {
+		v38 := uint64(uint64(r.Uint32()))
+		this.LogSeq = &v38
+	}
+	if r.Intn(5) != 0 {
+		v39 := r.Intn(5)
+		this.Entries = make([]*LogEntry, v39)
+		for i := 0; i < v39; i++ {
+			this.Entries[i] = NewPopulatedLogEntry(r, easy)
+		}
+	}
+	if r.Intn(5) != 0 {
+		this.Replication = NewPopulatedReplicationStatus(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		v40 := string(randStringTransport(r))
+		this.Leader = &v40
+	}
+	if r.Intn(5) != 0 {
+		this.Cluster = NewPopulatedClusterStatus(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		v41 := int64(r.Int63())
+		if r.Intn(2) == 0 {
+			v41 *= -1
+		}
+		this.Ttl = &v41
+	}
+	if r.Intn(5) != 0 {
+		v42 := uint32(r.Uint32())
+		this.Flags = &v42
+	}
+	if r.Intn(5) != 0 {
+		v43 := uint64(uint64(r.Uint32()))
+		this.Version = &v43
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTransport(r, 17)
+	}
+	return this
+}
+
+func NewPopulatedLogEntry(r randyTransport, easy bool) *LogEntry {
+	this := &LogEntry{}
+	v44 := uint64(uint64(r.Uint32()))
+	this.Seq = &v44
+	if r.Intn(5) != 0 {
+		v45 := r.Intn(5)
+		this.Ops = make([]*TransportOperation, v45)
+		for i := 0; i < v45; i++ {
+			this.Ops[i] = NewPopulatedTransportOperation(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTransport(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedReplicationStatus(r randyTransport, easy bool) *ReplicationStatus {
+	this := &ReplicationStatus{}
+	if r.Intn(5) != 0 {
+		v46 := string(randStringTransport(r))
+		this.Role = &v46
+	}
+	if r.Intn(5) != 0 {
+		v47 := string(randStringTransport(r))
+		this.Primary = &v47
+	}
+	if r.Intn(5) != 0 {
+		v48 := bool(bool(r.Intn(2) == 0))
+		this.Connected = &v48
+	}
+	if r.Intn(5) != 0 {
+		v49 := string(randStringTransport(r))
+		this.LogId = &v49
+	}
+	if r.Intn(5) != 0 {
+		v50 := uint64(uint64(r.Uint32()))
+		this.LogSeq = &v50
+	}
+	if r.Intn(5) != 0 {
+		v51 := uint64(uint64(r.Uint32()))
+		this.PrimarySeq = &v51
+	}
+	if r.Intn(5) != 0 {
+		v52 := float64(r.Float64())
+		if r.Intn(2) == 0 {
+			v52 *= -1
+		}
+		this.LagSeconds = &v52
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTransport(r, 8)
+	}
+	return this
+}
+
+func NewPopulatedClusterStatus(r randyTransport, easy bool) *ClusterStatus {
+	this := &ClusterStatus{}
+	if r.Intn(5) != 0 {
+		v53 := string(randStringTransport(r))
+		this.NodeId = &v53
+	}
+	if r.Intn(5) != 0 {
+		v54 := string(randStringTransport(r))
+		this.State = &v54
+	}
+	if r.Intn(5) != 0 {
+		v55 := string(randStringTransport(r))
+		this.Leader = &v55
+	}
+	if r.Intn(5) != 0 {
+		v56 := r.Intn(5)
+		this.Nodes = make([]*ClusterNode, v56)
+		for i := 0; i < v56; i++ {
+			this.Nodes[i] = NewPopulatedClusterNode(r, easy)
+		}
+	}
+	if r.Intn(5) != 0 {
+		v57 := uint64(uint64(r.Uint32()))
+		this.AppliedIndex = &v57
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTransport(r, 6)
+	}
+	return this
+}
+
+func NewPopulatedClusterNode(r randyTransport, easy bool) *ClusterNode {
+	this := &ClusterNode{}
+	v58 := string(randStringTransport(r))
+	this.Id = &v58
+	v59 := string(randStringTransport(r))
+	this.Address = &v59
+	if r.Intn(5) != 0 {
+		v60 := bool(bool(r.Intn(2) == 0))
+		this.Voter = &v60
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTransport(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedBackupRecord(r randyTransport, easy bool) *BackupRecord {
+	this := &BackupRecord{}
+	if r.Intn(5) != 0 {
+		this.Pair = NewPopulatedTransportPair(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		v61 := uint64(uint64(r.Uint32()))
+		this.Count = &v61
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTransport(r, 3)
+	}
+	return this
+}
+
+type randyTransport interface {
+	Float32() float32
+	Float64() float64
+	Int63() int64
+	Int31() int32
+	Uint32() uint32
+	Intn(n int) int
+}
+
+func randUTF8RuneTransport(r randyTransport) rune {
+	ru := r.Intn(62)
+	if ru < 10 {
+		return rune(ru + 48)
+	} else if ru < 36 {
+		return rune(ru + 55)
+	}
+	return rune(ru + 61)
+}
+func randStringTransport(r randyTransport) string {
+	v62 := r.Intn(100)
+	tmps := make([]rune, v62)
+	for i := 0; i < v62; i++ {
+		tmps[i] = randUTF8RuneTransport(r)
+	}
+	return string(tmps)
+}
+func randUnrecognizedTransport(r randyTransport, maxFieldNumber int) (dAtA []byte) {
+	l := r.Intn(5)
+	for i := 0; i < l; i++ {
+		wire := r.Intn(4)
+		if wire == 3 {
+			wire = 5
+		}
+		fieldNumber := maxFieldNumber + r.Intn(100)
+		dAtA = randFieldTransport(dAtA, r, fieldNumber, wire)
+	}
+	return dAtA
+}
+func randFieldTransport(dAtA []byte, r randyTransport, fieldNumber int, wire int) []byte {
+	key := uint32(fieldNumber)<<3 | uint32(wire)
+	switch wire {
+	case 0:
+		dAtA = encodeVarintPopulateTransport(dAtA, uint64(key))
+		v63 := r.Int63()
+		if r.Intn(2) == 0 {
+			v63 *= -1
+		}
+		dAtA = encodeVarintPopulateTransport(dAtA, uint64(v63))
+	case 1:
+		dAtA = encodeVarintPopulateTransport(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	case 2:
+		dAtA = encodeVarintPopulateTransport(dAtA, uint64(key))
+		ll := r.Intn(100)
+		dAtA = encodeVarintPopulateTransport(dAtA, uint64(ll))
+		for j := 0; j < ll; j++ {
+			dAtA = append(dAtA, byte(r.Intn(256)))
+		}
+	default:
+		dAtA = encodeVarintPopulateTransport(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	}
+	return dAtA
+}
+func encodeVarintPopulateTransport(dAtA []byte, v uint64) []byte {
+	for v >= 1<<7 {
+		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
+		v >>= 7
+	}
+	dAtA = append(dAtA, uint8(v))
+	return dAtA
+}
+func (m *TransportBody) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Checksum != nil {
+		n += 1 + sovTransport(uint64(*m.Checksum))
+	}
+	if m.Data != nil {
+		l = len(m.Data)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransportRange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Start != nil {
+		l = len(m.Start)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.End != nil {
+		l = len(m.End)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Prefix != nil {
+		l = len(m.Prefix)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Limit != nil {
+		n += 1 + sovTransport(uint64(*m.Limit))
+	}
+	if m.Reverse != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransportPair) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Key != nil {
+		l = len(m.Key)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Value != nil {
+		l = m.Value.Size()
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Expires != nil {
+		n += 1 + sovTransport(uint64(*m.Expires))
+	}
+	if m.Flags != nil {
+		n += 1 + sovTransport(uint64(*m.Flags))
+	}
+	if m.Version != nil {
+		n += 1 + sovTransport(uint64(*m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransportOperation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Command != nil {
+		n += 1 + sovTransport(uint64(*m.Command))
+	}
+	if m.Key != nil {
+		l = len(m.Key)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = len(m.Id)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Command != nil {
+		n += 1 + sovTransport(uint64(*m.Command))
+	}
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Range != nil {
+		l = m.Range.Size()
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if len(m.Batch) > 0 {
+		for _, e := range m.Batch {
+			l = e.Size()
+			n += 1 + l + sovTransport(uint64(l))
+		}
+	}
+	if m.Sync != nil {
+		n += 2
+	}
+	if m.Key != nil {
+		l = len(m.Key)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Seq != nil {
+		n += 1 + sovTransport(uint64(*m.Seq))
+	}
+	if m.Snapshot != nil {
+		n += 1 + sovTransport(uint64(*m.Snapshot))
+	}
+	if m.Expected != nil {
+		l = m.Expected.Size()
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Token != nil {
+		l = len(*m.Token)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Database != nil {
+		l = len(*m.Database)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Path != nil {
+		l = len(*m.Path)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.LogId != nil {
+		l = len(*m.LogId)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.LogSeq != nil {
+		n += 1 + sovTransport(uint64(*m.LogSeq))
+	}
+	if m.NodeId != nil {
+		l = len(*m.NodeId)
+		n += 2 + l + sovTransport(uint64(l))
+	}
+	if m.NodeAddress != nil {
+		l = len(*m.NodeAddress)
+		n += 2 + l + sovTransport(uint64(l))
+	}
+	if m.Ttl != nil {
+		n += 2 + sovTransport(uint64(*m.Ttl))
+	}
+	if m.ExpectedVersion != nil {
+		n += 2 + sovTransport(uint64(*m.ExpectedVersion))
+	}
+	if m.Flags != nil {
+		n += 2 + sovTransport(uint64(*m.Flags))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransportResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = len(m.Id)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Status != nil {
+		n += 1 + sovTransport(uint64(*m.Status))
+	}
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if len(m.Pairs) > 0 {
+		for _, e := range m.Pairs {
+			l = e.Size()
+			n += 1 + l + sovTransport(uint64(l))
+		}
+	}
+	if m.More != nil {
+		n += 2
+	}
+	if m.Seq != nil {
+		n += 1 + sovTransport(uint64(*m.Seq))
+	}
+	if m.Snapshot != nil {
+		n += 1 + sovTransport(uint64(*m.Snapshot))
+	}
+	if m.LogId != nil {
+		l = len(*m.LogId)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.LogSeq != nil {
+		n += 1 + sovTransport(uint64(*m.LogSeq))
+	}
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovTransport(uint64(l))
+		}
+	}
+	if m.Replication != nil {
+		l = m.Replication.Size()
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Leader != nil {
+		l = len(*m.Leader)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Cluster != nil {
+		l = m.Cluster.Size()
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Ttl != nil {
+		n += 1 + sovTransport(uint64(*m.Ttl))
+	}
+	if m.Flags != nil {
+		n += 1 + sovTransport(uint64(*m.Flags))
+	}
+	if m.Version != nil {
+		n += 2 + sovTransport(uint64(*m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LogEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Seq != nil {
+		n += 1 + sovTransport(uint64(*m.Seq))
+	}
+	if len(m.Ops) > 0 {
+		for _, e := range m.Ops {
+			l = e.Size()
+			n += 1 + l + sovTransport(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReplicationStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Role != nil {
+		l = len(*m.Role)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Primary != nil {
+		l = len(*m.Primary)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Connected != nil {
+		n += 2
+	}
+	if m.LogId != nil {
+		l = len(*m.LogId)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.LogSeq != nil {
+		n += 1 + sovTransport(uint64(*m.LogSeq))
+	}
+	if m.PrimarySeq != nil {
+		n += 1 + sovTransport(uint64(*m.PrimarySeq))
+	}
+	if m.LagSeconds != nil {
+		n += 9
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ClusterStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NodeId != nil {
+		l = len(*m.NodeId)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.State != nil {
+		l = len(*m.State)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Leader != nil {
+		l = len(*m.Leader)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if len(m.Nodes) > 0 {
+		for _, e := range m.Nodes {
+			l = e.Size()
+			n += 1 + l + sovTransport(uint64(l))
+		}
+	}
+	if m.AppliedIndex != nil {
+		n += 1 + sovTransport(uint64(*m.AppliedIndex))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ClusterNode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = len(*m.Id)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Address != nil {
+		l = len(*m.Address)
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Voter != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BackupRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pair != nil {
+		l = m.Pair.Size()
+		n += 1 + l + sovTransport(uint64(l))
+	}
+	if m.Count != nil {
+		n += 1 + sovTransport(uint64(*m.Count))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovTransport(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTransport(x uint64) (n int) {
+	return sovTransport(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *TransportBody) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransportBody: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransportBody: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Checksum = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransport(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("checksum")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransportRange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransportRange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransportRange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
+			if m.Start == nil {
+				m.Start = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
+			if m.End == nil {
+				m.End = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = append(m.Prefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.Prefix == nil {
+				m.Prefix = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Limit = &v
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reverse", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Reverse = &b
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransport(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransportPair) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransportPair: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransportPair: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Value == nil {
+				m.Value = &TransportBody{}
+			}
+			if err := m.Value.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Expires = &v
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Flags = &v
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Version = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransport(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("key")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransportOperation) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransportOperation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransportOperation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Command", wireType)
+			}
+			var v TransportRequest_Command
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= TransportRequest_Command(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Command = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &TransportBody{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransport(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("command")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("key")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Command", wireType)
+			}
+			var v TransportRequest_Command
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= TransportRequest_Command(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Command = &v
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &TransportBody{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Range", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Range == nil {
+				m.Range = &TransportRange{}
+			}
+			if err := m.Range.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Batch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Batch = append(m.Batch, &TransportOperation{})
+			if err := m.Batch[len(m.Batch)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sync", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Sync = &b
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Seq = &v
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshot", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Snapshot = &v
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expected", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expected == nil {
+				m.Expected = &TransportBody{}
+			}
+			if err := m.Expected.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Token = &s
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Database", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Database = &s
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Path = &s
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.LogId = &s
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogSeq", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LogSeq = &v
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.NodeId = &s
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.NodeAddress = &s
+			iNdEx = postIndex
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ttl", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ttl = &v
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedVersion", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExpectedVersion = &v
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Flags = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransport(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransportResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransportResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var v TransportResponse_Status
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= TransportResponse_Status(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Status = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &TransportBody{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pairs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pairs = append(m.Pairs, &TransportPair{})
+			if err := m.Pairs[len(m.Pairs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field More", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.More = &b
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Seq = &v
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshot", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Snapshot = &v
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.LogId = &s
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogSeq", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LogSeq = &v
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, &LogEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replication", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Replication == nil {
+				m.Replication = &ReplicationStatus{}
+			}
+			if err := m.Replication.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Leader = &s
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Cluster == nil {
+				m.Cluster = &ClusterStatus{}
+			}
+			if err := m.Cluster.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ttl", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ttl = &v
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Flags = &v
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Version = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransport(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("status")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogEntry) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Seq = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ops", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ops = append(m.Ops, &TransportOperation{})
+			if err := m.Ops[len(m.Ops)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransport(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("seq")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReplicationStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReplicationStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReplicationStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Role = &s
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Primary", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Primary = &s
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connected", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Connected = &b
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.LogId = &s
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogSeq", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LogSeq = &v
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrimarySeq", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PrimarySeq = &v
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LagSeconds", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			v2 := float64(math.Float64frombits(v))
+			m.LagSeconds = &v2
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransport(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ClusterStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClusterStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClusterStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.NodeId = &s
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.State = &s
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Leader = &s
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, &ClusterNode{})
+			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedIndex", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AppliedIndex = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransport(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ClusterNode) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClusterNode: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClusterNode: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Id = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Address = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Voter", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Voter = &b
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransport(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("address")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BackupRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BackupRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BackupRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pair", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransport
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pair == nil {
+				m.Pair = &TransportPair{}
+			}
+			if err := m.Pair.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Count = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransport(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransport
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipTransport(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowTransport
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTransport
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthTransport
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupTransport
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthTransport
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthTransport        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowTransport          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupTransport = fmt.Errorf("proto: unexpected end of group")
+)
@@ -1,20 +1,23 @@
 package ldbserver
 
 import (
-	"encoding/binary"
-	"encoding/json"
 	"errors"
 	"hash/crc32"
 	"io"
+	"sync"
 
-	pio "github.com/gogo/protobuf/io"
 	"github.com/gogo/protobuf/proto"
 )
 
 //go:generate protoc --gogo_out=. -I.:$GOPATH/src:/usr/local/include transport.proto
+//go:generate protoc --gogo_out=plugins=grpc:. -I.:$GOPATH/src:/usr/local/include service.proto
 
 type DBServer interface {
+	// serve reads one request from the transporter and answers it
 	serve(Transporter) error
+	// handle answers a request already read from the transporter within
+	// the session of its connection
+	handle(*session, Transporter, *TransportRequest) error
 	Close()
 }
 
@@ -27,69 +30,90 @@ type TransporterFactory interface {
 	NewTransporter(r io.Reader, w io.Writer) Transporter
 }
 
+// ErrChecksumMismatch is returned by Transporter.GetRequest with the request
+// whose body does not match its checksum.
+var ErrChecksumMismatch = errors.New("bad checksum in request body")
+
+// MarshalingType names one of the built-in codecs.
 type MarshalingType int
 
 const (
 	MarshalingTypeJson MarshalingType = iota
 	MarshalingTypeProtobuf
+	// MarshalingTypeMsgpack and MarshalingTypeCBOR encode messages like
+	// json, as maps keyed by the json names of the fields, but keep keys and
+	// values binary.
+	MarshalingTypeMsgpack
+	MarshalingTypeCBOR
 )
 
 type JsonProtobufTransportFactory struct{ Mt MarshalingType }
 
 func (f JsonProtobufTransportFactory) NewTransporter(r io.Reader, w io.Writer) Transporter {
+	return CodecTransportFactory{f.Mt.Codec()}.NewTransporter(r, w)
+}
+
+// CodecTransportFactory reads requests and writes responses with Codec, such
+// as one registered with RegisterCodec.
+type CodecTransportFactory struct{ Codec Codec }
+
+func (f CodecTransportFactory) NewTransporter(r io.Reader, w io.Writer) Transporter {
 	ret := new(rwTransporter)
-	ret.mt = f.Mt
+	ret.codec = f.Codec
 	ret.req = r
 	ret.resp = w
 	return ret
 }
 
+// rwTransporter creates its decoder and encoder on first use, so that the
+// decoder can buffer a stream of requests.
 type rwTransporter struct {
-	mt   MarshalingType
-	req  io.Reader
-	resp io.Writer
+	codec Codec
+	req   io.Reader
+	resp  io.Writer
+	dec   Decoder
+	enc   Encoder
 }
 
-//func newRwTransporter(r io.Reader, w io.Writer, mt MarshalingType) *rwTransporter {
-//	ret := new(rwTransporter)
-//	ret.mt = mt
-//	ret.req = r
-//	ret.resp = w
-//	return ret
-//}
+var errUnsupportedCodec = errors.New("unsupported marshaling type")
 
 func (rw *rwTransporter) GetRequest() (req *TransportRequest, err error) {
-	req = &TransportRequest{}
-	switch rw.mt {
-	case MarshalingTypeJson:
-		dec := json.NewDecoder(rw.req)
-		err = dec.Decode(req)
-
-	case MarshalingTypeProtobuf:
-		dec := pio.NewUint32DelimitedReader(rw.req, binary.LittleEndian, 1024*1024)
-		err = dec.ReadMsg(req)
+	if rw.codec == nil {
+		return nil, errUnsupportedCodec
 	}
-	if err == nil {
-		if !CheckBody(req.Body) {
-			err = errors.New("bad checksum in request body")
-		}
+	if rw.dec == nil {
+		rw.dec = rw.codec.NewDecoder(rw.req)
 	}
-	if err != nil {
+	req = &TransportRequest{}
+	if err = rw.dec.Decode(req); err != nil {
 		return nil, err
 	}
+	if !CheckBody(req.Body) {
+		return req, ErrChecksumMismatch
+	}
 	return
 }
 func (rw *rwTransporter) SendResponse(resp *TransportResponse) error {
-	SetBodyChecksum(resp.Body)
-	switch rw.mt {
-	case MarshalingTypeJson:
-		enc := json.NewEncoder(rw.resp)
-		return enc.Encode(resp)
-	case MarshalingTypeProtobuf:
-		enc := pio.NewUint32DelimitedWriter(rw.resp, binary.LittleEndian)
-		return enc.WriteMsg(resp)
+	if rw.codec == nil {
+		return errUnsupportedCodec
 	}
-	return errors.New("unsupported marshaling type")
+	if rw.enc == nil {
+		rw.enc = rw.codec.NewEncoder(rw.resp)
+	}
+	setResponseChecksums(resp)
+	return rw.enc.Encode(resp)
+}
+
+// syncTransporter serializes responses sent from concurrent handlers.
+type syncTransporter struct {
+	Transporter
+	mu sync.Mutex
+}
+
+func (st *syncTransporter) SendResponse(resp *TransportResponse) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.Transporter.SendResponse(resp)
 }
 
 // ------------
@@ -117,6 +141,14 @@ func CheckBody(body *TransportBody) bool {
 	return chk == body.GetChecksum()
 }
 
+// setResponseChecksums sets the checksums of the body and the pairs of resp.
+func setResponseChecksums(resp *TransportResponse) {
+	SetBodyChecksum(resp.Body)
+	for _, pair := range resp.Pairs {
+		SetBodyChecksum(pair.Value)
+	}
+}
+
 func SetBodyChecksum(body *TransportBody) {
 	if body == nil {
 		return
@@ -0,0 +1,275 @@
+package ldbserver
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/govlas/logger"
+)
+
+const (
+	// ttlSweepInterval is the time between two sweeps of the expired keys.
+	ttlSweepInterval = time.Second
+	// ttlSweepBatchSize is the number of expired keys deleted at once.
+	ttlSweepBatchSize = 1000
+)
+
+// requestMeta returns the metadata of the value written by req at now.
+func requestMeta(req *TransportRequest, now time.Time) meta {
+	m := meta{flags: req.GetFlags()}
+	if ttl := req.GetTtl(); ttl != 0 {
+		m.expires = now.Add(time.Duration(ttl) * time.Millisecond).UnixNano()
+	}
+	return m
+}
+
+// read returns the metadata and the value of key, expired or not.
+func read(r Reader, key []byte) (meta, []byte, error) {
+	b, err := r.Get(dataKey(key))
+	if err != nil {
+		return meta{}, nil, err
+	}
+	return decodeValue(b)
+}
+
+// get returns the metadata and the value of key unless it has expired at now.
+func get(r Reader, key []byte, now time.Time) (meta, []byte, error) {
+	m, value, err := read(r, key)
+	if err == nil && m.expired(now) {
+		return meta{}, nil, ErrNotFound
+	}
+	return m, value, err
+}
+
+// writeOps appends to ops the writes replacing key, whose metadata was old,
+// by value with the metadata m, or deleting it if del.
+func writeOps(ops []Operation, key, value []byte, del bool, old, m meta) []Operation {
+	if del {
+		ops = append(ops, Operation{Key: dataKey(key), Delete: true})
+	} else {
+		ops = append(ops, Operation{Key: dataKey(key), Value: encodeValue(m, value)})
+	}
+	if old.expires != m.expires {
+		if old.expires != 0 {
+			ops = append(ops, Operation{Key: expiryIndexKey(old.expires, key), Delete: true})
+		}
+		if m.expires != 0 {
+			ops = append(ops, Operation{Key: expiryIndexKey(m.expires, key), Value: []byte{}})
+		}
+	}
+	return ops
+}
+
+// write puts value to key at now with the metadata m and a new version, or
+// deletes key if del. The key must be locked.
+func (d *database) write(key, value []byte, del bool, m meta, now time.Time, sync bool) error {
+	old, _, err := read(d.st, key)
+	if err != nil && err != ErrNotFound {
+		return err
+	}
+	if del {
+		m = meta{}
+	} else {
+		m.version = nextVersion(old, now)
+	}
+	return d.st.Batch(writeOps(nil, key, value, del, old, m), sync)
+}
+
+// ttl answers the time to live of key in milliseconds, -1 if it does not
+// expire.
+func (d *database) ttl(r Reader, key []byte, now time.Time) *TransportResponse {
+	m, _, err := get(r, key, now)
+	if err != nil {
+		return makeDbErrorResponse(err)
+	}
+	ttl := int64(-1)
+	if m.expires != 0 {
+		// rounded up, so that a live key never reports zero
+		ttl = (m.expires - now.UnixNano() + int64(time.Millisecond) - 1) / int64(time.Millisecond)
+	}
+	return &TransportResponse{Status: TransportResponse_OK.Enum(), Ttl: proto.Int64(ttl)}
+}
+
+// persist removes the expiry of key.
+func (d *database) persist(key []byte, now time.Time, sync bool) *TransportResponse {
+	defer d.locks.lock(key)()
+	old, value, err := get(d.st, key, now)
+	if err == nil && old.expires != 0 {
+		m := old
+		m.expires = 0
+		err = d.st.Batch(writeOps(nil, key, value, false, old, m), sync)
+	}
+	if err != nil {
+		return makeDbErrorResponse(err)
+	}
+	return &TransportResponse{Status: TransportResponse_OK.Enum()}
+}
+
+// sweep deletes up to max keys expired at now. It returns the number of
+// expiries read, which is max if there may be more.
+func (d *database) sweep(now time.Time, max int) (int, error) {
+	var expired [][]byte
+	it := d.st.Iterate(expiryIndexPrefix, expiryIndexKey(now.UnixNano()+1, nil))
+	for ok := it.First(); ok && len(expired) < max; ok = it.Next() {
+		expired = append(expired, append([]byte(nil), it.Key()...))
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+
+	for _, index := range expired {
+		expires := int64(binary.BigEndian.Uint64(index[len(expiryIndexPrefix):]))
+		key := index[len(expiryIndexPrefix)+8:]
+		unlock := d.locks.lock(key)
+		// the key may have been written again since it was read
+		cur, _, err := read(d.st, key)
+		if err == nil || err == ErrNotFound {
+			ops := []Operation{{Key: index, Delete: true}}
+			if cur.expires == expires {
+				ops = append(ops, Operation{Key: dataKey(key), Delete: true})
+			}
+			err = d.st.Batch(ops, false)
+		}
+		unlock()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
+
+// expired reports whether keys of d have expired at now.
+func (d *database) expired(now time.Time) (bool, error) {
+	it := d.st.Iterate(expiryIndexPrefix, expiryIndexKey(now.UnixNano()+1, nil))
+	defer it.Release()
+	return it.First(), it.Error()
+}
+
+// sweepExpired deletes the expired keys of the open databases every
+// ttlSweepInterval until stop is closed. Replicas leave the default database
+// to the deletes of their primary, cluster nodes to those of the raft log.
+func (s *leveldbServer) sweepExpired(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(ttlSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		var dbs []*database
+		s.mu.Lock()
+		cluster := s.cluster
+		if s.replica == nil && cluster == nil {
+			dbs = append(dbs, s.db)
+		}
+		for _, d := range s.databases {
+			dbs = append(dbs, d)
+		}
+		s.mu.Unlock()
+		if cluster != nil {
+			cluster.sweep(s.db, stop)
+		}
+		for _, d := range dbs {
+			d.sweepOpen(stop)
+		}
+	}
+}
+
+// sweepOpen deletes the expired keys of d unless it is closed.
+func (d *database) sweepOpen(stop <-chan struct{}) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.st == nil {
+		return
+	}
+	now := time.Now()
+	for {
+		n, err := d.sweep(now, ttlSweepBatchSize)
+		if err != nil {
+			logger.WarningErr(err)
+			return
+		}
+		if n < ttlSweepBatchSize {
+			return
+		}
+		select {
+		case <-stop:
+			return
+		default:
+		}
+	}
+}
+
+// dataIterator walks over the keys of clients in a range of the data
+// namespace. Unless now is zero, it skips the keys expired at now.
+type dataIterator struct {
+	it    Iterator
+	now   time.Time
+	meta  meta
+	value []byte
+	err   error
+}
+
+// iterateData returns an iterator over the keys of clients of r in the range
+// [start, limit); nil bounds are open.
+func iterateData(r Reader, start, limit []byte, now time.Time) *dataIterator {
+	ds, dl := dataPrefix, dataEnd
+	if start != nil {
+		ds = dataKey(start)
+	}
+	if limit != nil {
+		dl = dataKey(limit)
+	}
+	return &dataIterator{it: r.Iterate(ds, dl), now: now}
+}
+
+func (it *dataIterator) First() bool {
+	return it.skip(it.it.First(), it.it.Next)
+}
+
+func (it *dataIterator) Last() bool {
+	return it.skip(it.it.Last(), it.it.Prev)
+}
+
+func (it *dataIterator) Next() bool {
+	return it.skip(it.it.Next(), it.it.Next)
+}
+
+func (it *dataIterator) Prev() bool {
+	return it.skip(it.it.Prev(), it.it.Prev)
+}
+
+func (it *dataIterator) skip(ok bool, move func() bool) bool {
+	for ; ok; ok = move() {
+		if it.meta, it.value, it.err = decodeValue(it.it.Value()); it.err != nil {
+			return false
+		}
+		if it.now.IsZero() || !it.meta.expired(it.now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (it *dataIterator) Key() []byte {
+	return it.it.Key()[len(dataPrefix):]
+}
+
+func (it *dataIterator) Value() []byte {
+	return it.value
+}
+
+func (it *dataIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.it.Error()
+}
+
+func (it *dataIterator) Release() {
+	it.it.Release()
+}
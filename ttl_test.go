@@ -0,0 +1,73 @@
+package ldbserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTL(t *testing.T) {
+	db := NewServer(NewMemStorage())
+	defer db.Close()
+	d := db.db
+
+	now := time.Now()
+	execute := func(cmd TransportRequest_Command, key, value string, ttl uint64, at time.Time) *TransportResponse {
+		req := &TransportRequest{Command: cmd.Enum(), Key: []byte(key)}
+		if len(value) != 0 {
+			req.Body = &TransportBody{Data: []byte(value)}
+			SetBodyChecksum(req.Body)
+		}
+		if ttl != 0 {
+			req.Ttl = proto.Uint64(ttl)
+		}
+		return d.execute(nil, req, at)
+	}
+	status := func(cmd TransportRequest_Command, key string, at time.Time) TransportResponse_Status {
+		return execute(cmd, key, "", 0, at).GetStatus()
+	}
+
+	execute(TransportRequest_PUT, "a", "va", 1000, now)
+	execute(TransportRequest_PUT, "b", "vb", 2000, now)
+	execute(TransportRequest_PUT, "c", "vc", 0, now)
+	assert.Equal(t, execute(TransportRequest_TTL, "a", "", 0, now).GetTtl(), int64(1000), "TTL")
+	assert.Equal(t, execute(TransportRequest_TTL, "c", "", 0, now).GetTtl(), int64(-1), "TTL without expiry")
+	assert.Equal(t, status(TransportRequest_TTL, "x", now), TransportResponse_NOT_FOUND, "TTL missing")
+
+	later := now.Add(1500 * time.Millisecond)
+	assert.Equal(t, status(TransportRequest_GET, "a", later), TransportResponse_NOT_FOUND, "GET expired")
+	assert.Equal(t, status(TransportRequest_GET, "b", later), TransportResponse_OK, "GET live")
+	assert.Equal(t, status(TransportRequest_TTL, "a", later), TransportResponse_NOT_FOUND, "TTL expired")
+	assert.Equal(t, execute(TransportRequest_PUT_IF_ABSENT, "a", "va", 0, later).GetStatus(), TransportResponse_OK, "PUT_IF_ABSENT expired")
+	assert.Equal(t, execute(TransportRequest_TTL, "a", "", 0, later).GetTtl(), int64(-1), "write removes expiry")
+
+	assert.Equal(t, status(TransportRequest_PERSIST, "b", later), TransportResponse_OK, "PERSIST")
+	assert.Equal(t, status(TransportRequest_GET, "b", now.Add(time.Hour)), TransportResponse_OK, "GET persisted")
+	assert.Equal(t, status(TransportRequest_PERSIST, "x", later), TransportResponse_NOT_FOUND, "PERSIST missing")
+
+	// expired keys are left out of scans and deleted by the sweeper
+	execute(TransportRequest_PUT, "d", "vd", 1000, now)
+	execute(TransportRequest_PUT, "e", "ve", 1000, now)
+	execute(TransportRequest_PUT, "e", "ve", 5000, now)
+	assert.Equal(t, scanCommand(t, db, &TransportRange{}, MarshalingTypeJson), []string{"a", "b", "c", "d", "e"}, "scan")
+	assert.Equal(t, scanCommand(t, db, &TransportRange{Reverse: proto.Bool(true)}, MarshalingTypeJson), []string{"e", "d", "c", "b", "a"}, "reverse scan")
+
+	// keys of clients never pass for expiries
+	execute(TransportRequest_PUT, string(expiryIndexKey(now.UnixNano(), []byte("c"))), "x", 0, now)
+	n, err := d.sweep(later, 10)
+	assert.NoError(t, err, "sweep")
+	assert.Equal(t, n, 1, "sweep")
+	_, _, err = read(d.st, []byte("d"))
+	assert.Equal(t, err, ErrNotFound, "swept key")
+	assert.Equal(t, status(TransportRequest_GET, "e", later), TransportResponse_OK, "key written again")
+	assert.Equal(t, status(TransportRequest_GET, "c", later), TransportResponse_OK, "key with a forged expiry")
+
+	n, err = d.sweep(now.Add(time.Hour), 10)
+	assert.NoError(t, err, "sweep")
+	assert.Equal(t, n, 1, "sweep")
+	it := d.st.Iterate(expiryIndexPrefix, nil)
+	assert.False(t, it.First(), "expiries left after sweep")
+	it.Release()
+}